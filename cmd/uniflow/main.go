@@ -28,8 +28,10 @@ import (
 	"github.com/siyul-park/uniflow/pkg/language/yaml"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/plugin"
+	"github.com/siyul-park/uniflow/pkg/resolver"
 	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/value"
@@ -45,7 +47,11 @@ const (
 	keyDatabaseURL      = "database.url"
 	keyCollectionSpecs  = "collection.specs"
 	keyCollectionValues = "collection.values"
+	keySecretKeyFile    = "secret.keyfile"
+	keyVaultFile        = "vault.file"
 	keyPlugins          = "plugins"
+	keyAdminAddress     = "admin.address"
+	keyAdminToken       = "admin.token"
 )
 
 var k = koanf.New(".")
@@ -116,6 +122,18 @@ func main() {
 
 	fs := afero.NewOsFs()
 
+	snapshot := testing.NewSnapshot(fs)
+	corpus := testing.NewCorpus(fs)
+
+	resolverRegistry := resolver.NewRegistry()
+	defer resolverRegistry.Close()
+
+	cmd.Fatal(resolverRegistry.Register("env", resolver.NewEnvResolver()))
+	cmd.Fatal(resolverRegistry.Register("file", resolver.NewFileResolver(fs)))
+	if filename := k.String(keyVaultFile); filename != "" {
+		cmd.Fatal(resolverRegistry.Register("vault", resolver.NewVaultResolver(fs, filename)))
+	}
+
 	pluginLoader := plugin.NewLoader(fs)
 
 	for _, cfg := range k.Slices(keyPlugins) {
@@ -126,13 +144,21 @@ func main() {
 		cmd.Fatal(pluginRegistry.Register(p))
 	}
 
-	deps := []any{runner, connProxy, agent, fs, schemeBuilder, hookBuilder, pluginRegistry, driverRegistry, languageRegistry}
+	deps := []any{runner, snapshot, corpus, connProxy, agent, fs, schemeBuilder, hookBuilder, pluginRegistry, driverRegistry, languageRegistry, resolverRegistry}
 	for _, dep := range deps {
 		cmd.Must(pluginRegistry.Inject(dep))
 	}
 
 	cmd.Fatal(pluginRegistry.Load(ctx))
 
+	pluginManager := plugin.NewManager(plugin.ManagerConfig{
+		Registry:      pluginRegistry,
+		Loader:        pluginLoader,
+		SchemeBuilder: schemeBuilder,
+		HookBuilder:   hookBuilder,
+		Dependencies:  deps,
+	})
+
 	sc := cmd.Must(schemeBuilder.Build())
 	hk := cmd.Must(hookBuilder.Build())
 
@@ -164,6 +190,11 @@ func main() {
 		Filter: map[string]any{value.KeyName: map[string]any{"$exists": true}},
 	}))
 
+	var keyProvider secret.KeyProvider
+	if filename := k.String(keySecretKeyFile); filename != "" {
+		keyProvider = cmd.Must(secret.NewFileKeyProvider(fs, filename))
+	}
+
 	namespace := k.String(KeyRuntimeNamespace)
 	environment := k.StringMap(keyEnvironment)
 
@@ -177,7 +208,12 @@ func main() {
 		Environment: environment,
 		Agent:       agent,
 		Scheme:      sc,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
 		Hook:        hk,
+		Plugins:     pluginManager,
+		Admin:       k.String(keyAdminAddress),
+		AdminToken:  k.String(keyAdminToken),
 		SpecStore:   specStore,
 		ValueStore:  valueStore,
 		FS:          fs,
@@ -186,18 +222,62 @@ func main() {
 		Namespace:   namespace,
 		Environment: environment,
 		Runner:      runner,
+		Snapshot:    snapshot,
+		Corpus:      corpus,
+		Agent:       agent,
 		Scheme:      sc,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
 		Hook:        hk,
 		SpecStore:   specStore,
 		ValueStore:  valueStore,
 		FS:          fs,
 	}))
+	root.AddCommand(cmd.NewInvokeCommand(cmd.InvokeConfig{
+		Namespace:   namespace,
+		Environment: environment,
+		Scheme:      sc,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
+		SpecStore:   specStore,
+		ValueStore:  valueStore,
+		FS:          fs,
+	}))
+	root.AddCommand(cmd.NewBenchCommand(cmd.BenchConfig{
+		Namespace:   namespace,
+		Environment: environment,
+		Scheme:      sc,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
+		SpecStore:   specStore,
+		ValueStore:  valueStore,
+		FS:          fs,
+	}))
+	root.AddCommand(cmd.NewReplCommand(cmd.ReplConfig{
+		Namespace:   namespace,
+		Environment: environment,
+		Scheme:      sc,
+		Language:    languageRegistry,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
+		SpecStore:   specStore,
+		ValueStore:  valueStore,
+		FS:          fs,
+	}))
 	root.AddCommand(cmd.NewApplyCommand(cmd.ApplyConfig{
+		Scheme:      sc,
+		KeyProvider: keyProvider,
+		Resolver:    resolverRegistry,
+		SpecStore:   specStore,
+		ValueStore:  valueStore,
+		FS:          fs,
+	}))
+	root.AddCommand(cmd.NewDeleteCommand(cmd.DeleteConfig{
 		SpecStore:  specStore,
 		ValueStore: valueStore,
 		FS:         fs,
 	}))
-	root.AddCommand(cmd.NewDeleteCommand(cmd.DeleteConfig{
+	root.AddCommand(cmd.NewDiffCommand(cmd.DiffConfig{
 		SpecStore:  specStore,
 		ValueStore: valueStore,
 		FS:         fs,
@@ -206,6 +286,25 @@ func main() {
 		SpecStore:  specStore,
 		ValueStore: valueStore,
 	}))
+	root.AddCommand(cmd.NewInitCommand(cmd.InitConfig{
+		FS: fs,
+	}))
+	root.AddCommand(cmd.NewPluginCommand(cmd.PluginConfig{
+		Admin:      k.String(keyAdminAddress),
+		AdminToken: k.String(keyAdminToken),
+		FS:         fs,
+	}))
+	root.AddCommand(cmd.NewGraphCommand(cmd.GraphConfig{
+		SpecStore: specStore,
+		FS:        fs,
+	}))
+	root.AddCommand(cmd.NewRenderCommand(cmd.RenderConfig{
+		FS: fs,
+	}))
+	root.AddCommand(cmd.NewValidateCommand(cmd.ValidateConfig{
+		Scheme: sc,
+		FS:     fs,
+	}))
 
 	cmd.Fatal(root.Execute())
 }
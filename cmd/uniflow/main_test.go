@@ -32,15 +32,39 @@ func TestCommand(t *testing.T) {
 		{
 			args: []string{"test", "-h"},
 		},
+		{
+			args: []string{"invoke", "-h"},
+		},
+		{
+			args: []string{"repl", "-h"},
+		},
 		{
 			args: []string{"apply", "-h"},
 		},
 		{
 			args: []string{"delete", "-h"},
 		},
+		{
+			args: []string{"diff", "-h"},
+		},
 		{
 			args: []string{"get", "-h"},
 		},
+		{
+			args: []string{"init", "-h"},
+		},
+		{
+			args: []string{"plugin", "new", "-h"},
+		},
+		{
+			args: []string{"graph", "-h"},
+		},
+		{
+			args: []string{"render", "-h"},
+		},
+		{
+			args: []string{"validate", "-h"},
+		},
 	}
 
 	for _, tt := range tests {
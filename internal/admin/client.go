@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Client calls the admin API of a running engine.
+type Client struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewClient creates a new Client for the admin API listening on the address, given as host:port or as a URL.
+// The token, if any, is sent as a bearer token.
+func NewClient(address, token string) *Client {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	return &Client{address: strings.TrimSuffix(address, "/"), token: token, client: http.DefaultClient}
+}
+
+// Plugins returns the plugins registered to the engine.
+func (c *Client) Plugins(ctx context.Context) ([]Plugin, error) {
+	var plugins []Plugin
+	if err := c.do(ctx, http.MethodGet, "/plugins", nil, &plugins); err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// Load loads a plugin into the engine.
+func (c *Client) Load(ctx context.Context, req LoadRequest) (*Plugin, error) {
+	var p Plugin
+	if err := c.do(ctx, http.MethodPost, "/plugins", req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Upgrade replaces the plugin with the given name in the engine.
+func (c *Client) Upgrade(ctx context.Context, name string, req LoadRequest) (*Plugin, error) {
+	var p Plugin
+	if err := c.do(ctx, http.MethodPut, "/plugins/"+url.PathEscape(name), req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Unload unloads the plugin with the given name from the engine.
+func (c *Client) Unload(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/plugins/"+url.PathEscape(name), nil, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, value any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.address+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var e errorResponse
+		if err := json.NewDecoder(res.Body).Decode(&e); err != nil || e.Error == "" {
+			return errors.New(res.Status)
+		}
+		return errors.New(e.Error)
+	}
+
+	if value == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(value)
+}
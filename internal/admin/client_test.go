@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	require.Equal(t, "http://localhost:8081", NewClient("localhost:8081", "").address)
+	require.Equal(t, "https://example.com", NewClient("https://example.com/", "").address)
+}
+
+func TestClient_Error(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(strings.TrimPrefix(server.URL, "http://"), "")
+
+	_, err := client.Plugins(ctx)
+	require.ErrorContains(t, err, "502 Bad Gateway")
+}
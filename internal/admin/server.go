@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/plugin"
+)
+
+// Server serves the admin API of a running engine.
+type Server struct {
+	plugins *plugin.Manager
+	token   string
+	mux     *http.ServeMux
+}
+
+// Plugin describes a plugin registered to the engine.
+type Plugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// LoadRequest describes a plugin to load or to upgrade to.
+type LoadRequest struct {
+	Path   string `json:"path"`
+	GoPath string `json:"gopath,omitempty"`
+	Config any    `json:"config,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+var (
+	// ErrInsecureAddress is returned when the admin API would be reachable from other hosts without a token.
+	ErrInsecureAddress = errors.New("insecure admin address")
+
+	errInvalidRequest = errors.New("invalid request")
+	errUnauthorized   = errors.New("unauthorized")
+)
+
+var _ http.Handler = (*Server)(nil)
+
+// NewServer creates a new Server managing plugins with the given manager.
+// When a token is given, requests must carry it as a bearer token.
+func NewServer(plugins *plugin.Manager, token string) *Server {
+	s := &Server{plugins: plugins, token: token, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /plugins", s.listPlugins)
+	s.mux.HandleFunc("POST /plugins", s.loadPlugin)
+	s.mux.HandleFunc("PUT /plugins/{name}", s.upgradePlugin)
+	s.mux.HandleFunc("DELETE /plugins/{name}", s.unloadPlugin)
+
+	return s
+}
+
+// Listen listens on the address for the admin API. Since the API loads arbitrary code, it listens only on the
+// loopback interface unless a token is given: an address without a host binds to 127.0.0.1, and other hosts are rejected.
+func Listen(address, token string) (net.Listener, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		if host == "" {
+			host = "127.0.0.1"
+		} else if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, errors.WithMessagef(ErrInsecureAddress, "%s requires a token", address)
+		}
+	}
+	return net.Listen("tcp", net.JoinHostPort(host, port))
+}
+
+// ServeHTTP dispatches the request to the matching handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			s.fail(w, errUnauthorized)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) listPlugins(w http.ResponseWriter, _ *http.Request) {
+	plugins := make([]Plugin, 0)
+	for _, p := range s.plugins.Plugins() {
+		plugins = append(plugins, Plugin{Name: p.Name(), Version: p.Version()})
+	}
+	s.write(w, http.StatusOK, plugins)
+}
+
+func (s *Server) loadPlugin(w http.ResponseWriter, r *http.Request) {
+	req, err := s.read(r)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+
+	p, err := s.plugins.Load(r.Context(), req.Path, plugin.LoadOptions{GoPath: req.GoPath, Config: req.Config})
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.write(w, http.StatusCreated, Plugin{Name: p.Name(), Version: p.Version()})
+}
+
+func (s *Server) upgradePlugin(w http.ResponseWriter, r *http.Request) {
+	req, err := s.read(r)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+
+	p, err := s.plugins.Upgrade(r.Context(), r.PathValue("name"), req.Path, plugin.LoadOptions{GoPath: req.GoPath, Config: req.Config})
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.write(w, http.StatusOK, Plugin{Name: p.Name(), Version: p.Version()})
+}
+
+func (s *Server) unloadPlugin(w http.ResponseWriter, r *http.Request) {
+	if err := s.plugins.Unload(r.Context(), r.PathValue("name")); err != nil {
+		s.fail(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) read(r *http.Request) (*LoadRequest, error) {
+	var req LoadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.WithMessage(errInvalidRequest, err.Error())
+	}
+	if req.Path == "" {
+		return nil, errors.WithMessage(errInvalidRequest, "path is required")
+	}
+	return &req, nil
+}
+
+func (s *Server) write(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, errUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, plugin.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, plugin.ErrConflict),
+		errors.Is(err, plugin.ErrIncompatibleBuild),
+		errors.Is(err, plugin.ErrMissingDependency),
+		errors.Is(err, plugin.ErrIncompatibleDependency),
+		errors.Is(err, plugin.ErrCyclicDependency):
+		status = http.StatusConflict
+	}
+	s.write(w, status, errorResponse{Error: err.Error()})
+}
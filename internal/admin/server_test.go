@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/plugin"
+)
+
+func TestServer_Plugins(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	fs := afero.NewMemMapFs()
+	writePlugin(t, fs, "v1", "test", "v1.0.0")
+	writePlugin(t, fs, "v2", "test", "v2.0.0")
+
+	token := faker.UUIDHyphenated()
+
+	server := httptest.NewServer(NewServer(plugin.NewManager(plugin.ManagerConfig{
+		Loader: plugin.NewLoader(fs),
+	}), token))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "").Plugins(ctx)
+	require.ErrorContains(t, err, errUnauthorized.Error())
+
+	_, err = NewClient(server.URL, faker.UUIDHyphenated()).Plugins(ctx)
+	require.ErrorContains(t, err, errUnauthorized.Error())
+
+	client := NewClient(server.URL, token)
+
+	p, err := client.Load(ctx, LoadRequest{Path: "v1"})
+	require.NoError(t, err)
+	require.Equal(t, &Plugin{Name: "test", Version: "v1.0.0"}, p)
+
+	_, err = client.Load(ctx, LoadRequest{Path: "v1"})
+	require.ErrorContains(t, err, "plugin conflict occurred")
+
+	plugins, err := client.Plugins(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Plugin{{Name: "test", Version: "v1.0.0"}}, plugins)
+
+	p, err = client.Upgrade(ctx, "test", LoadRequest{Path: "v2"})
+	require.NoError(t, err)
+	require.Equal(t, &Plugin{Name: "test", Version: "v2.0.0"}, p)
+
+	err = client.Unload(ctx, "test")
+	require.NoError(t, err)
+
+	plugins, err = client.Plugins(ctx)
+	require.NoError(t, err)
+	require.Empty(t, plugins)
+
+	err = client.Unload(ctx, "test")
+	require.ErrorContains(t, err, "plugin not found")
+}
+
+func TestServer_InvalidRequest(t *testing.T) {
+	s := NewServer(plugin.NewManager(plugin.ManagerConfig{}), "")
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/plugins", strings.NewReader(`{}`)))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "path is required")
+}
+
+func TestListen(t *testing.T) {
+	listener, err := Listen(":0", "")
+	require.NoError(t, err)
+	require.True(t, listener.Addr().(*net.TCPAddr).IP.IsLoopback())
+	_ = listener.Close()
+
+	listener, err = Listen("localhost:0", "")
+	require.NoError(t, err)
+	_ = listener.Close()
+
+	_, err = Listen("0.0.0.0:0", "")
+	require.ErrorIs(t, err, ErrInsecureAddress)
+
+	listener, err = Listen("0.0.0.0:0", faker.UUIDHyphenated())
+	require.NoError(t, err)
+	_ = listener.Close()
+}
+
+func writePlugin(t *testing.T, fs afero.Fs, dir, name, version string, kinds ...string) {
+	tmpl, err := template.ParseGlob(filepath.Join("..", "..", "pkg", "plugin", "testdata", "plugin", "*.tmpl"))
+	require.NoError(t, err)
+
+	data := map[string]any{"Module": filepath.Base(dir), "Name": name, "Version": version, "Kinds": kinds}
+	for _, file := range []string{"main.go", "go.mod"} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, tmpl.ExecuteTemplate(buf, file+".tmpl", data))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, file), buf.Bytes(), 0644))
+	}
+}
@@ -1,24 +1,51 @@
 package cmd
 
 import (
+	"context"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/siyul-park/uniflow/internal/compose"
 	"github.com/siyul-park/uniflow/internal/fmt"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/siyul-park/uniflow/pkg/validation"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
 
 // ApplyConfig represents the configuration for the apply command.
 type ApplyConfig struct {
-	SpecStore  driver.Store
-	ValueStore driver.Store
-	FS         afero.Fs
+	Scheme      *scheme.Scheme
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
+	SpecStore   driver.Store
+	ValueStore  driver.Store
+	FS          afero.Fs
+}
+
+// applyOptions holds the steps run around storing the resources read by runApplyCommand. Unset steps are skipped.
+type applyOptions[T meta.Meta] struct {
+	admit func(cmd *cobra.Command, metas []T) error
+	own   func(cmd *cobra.Command, metas []T) ([]T, error)
+	plan  func(cmd *cobra.Command, metas, pruned []T) error
+	alias []func(map[string]string)
 }
 
+// annotationOwner records the owner of applied resources so that resources removed from the owner can be pruned.
+const annotationOwner = "uniflow.io/owner"
+
 // NewApplyCommand creates a new cobra.Command for the apply command.
 func NewApplyCommand(config ApplyConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -27,23 +54,35 @@ func NewApplyCommand(config ApplyConfig) *cobra.Command {
 		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 		ValidArgs: []string{specs, values},
 		RunE: runs(map[string]func(cmd *cobra.Command) error{
-			specs:  runApplyCommand[spec.Spec](config.SpecStore, config.FS),
-			values: runApplyCommand[*value.Value](config.ValueStore, config.FS),
+			specs: runApplyCommand(config.SpecStore, config.FS, applyOptions[spec.Spec]{
+				admit: admitSpecs(config.Scheme, config.SpecStore),
+				own:   own[spec.Spec](config.SpecStore),
+				plan:  planSpecs(config),
+			}),
+			values: runApplyCommand(config.ValueStore, config.FS, applyOptions[*value.Value]{
+				admit: sealValues(config.KeyProvider),
+				own:   own[*value.Value](config.ValueStore),
+				plan:  planValues(config),
+			}),
 		}),
 	}
 
 	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use the default namespace")
 	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be applied")
+	cmd.PersistentFlags().Bool(flagValidate, true, "Validate specs against the stored specs before applying them")
+	cmd.PersistentFlags().String(flagDryRun, dryRunNone, "Preview the changes without persisting them (none, server)")
+	cmd.PersistentFlags().Bool(flagPrune, false, "Delete resources of the same owner that are no longer in the file")
+	cmd.PersistentFlags().String(flagOwner, "", "Set the owner recorded on applied resources. If not set, use the absolute file path when pruning")
 
 	return cmd
 }
 
-func runApplyCommand[T meta.Meta](st driver.Store, fs afero.Fs, alias ...func(map[string]string)) func(cmd *cobra.Command) error {
+func runApplyCommand[T meta.Meta](st driver.Store, fs afero.Fs, opts applyOptions[T]) func(cmd *cobra.Command) error {
 	flags := map[string]string{
 		flagNamespace: flagNamespace,
 		flagFilename:  flagFilename,
 	}
-	for _, init := range alias {
+	for _, init := range opts.alias {
 		init(flags)
 	}
 
@@ -62,22 +101,15 @@ func runApplyCommand[T meta.Meta](st driver.Store, fs afero.Fs, alias ...func(ma
 			return nil
 		}
 
-		file, err := fs.Open(filename)
-		if err != nil {
-			return err
-		}
-
-		defer file.Close()
-
-		reader := fmt.NewReader(file)
+		reader := compose.NewComposer(fs)
 		writer := fmt.NewWriter(cmd.OutOrStdout())
 
 		var metas []T
-		if err := reader.Read(&metas); err != nil {
+		if err := reader.Read(filename, &metas); err != nil {
 			return err
 		}
 
-		if len(metas) == 0 {
+		if len(metas) == 0 && opts.own == nil {
 			return nil
 		}
 
@@ -85,40 +117,276 @@ func runApplyCommand[T meta.Meta](st driver.Store, fs afero.Fs, alias ...func(ma
 			if m.GetNamespace() == "" {
 				m.SetNamespace(namespace)
 			}
+		}
 
-			filter := map[string]any{}
-			if m.GetID() != uuid.Nil {
-				filter[meta.KeyID] = m.GetID()
+		if opts.admit != nil && len(metas) > 0 {
+			if err := opts.admit(cmd, metas); err != nil {
+				return err
 			}
-			if m.GetName() != "" {
-				filter[meta.KeyName] = m.GetName()
+		}
+
+		var pruned []T
+		if opts.own != nil {
+			if pruned, err = opts.own(cmd, metas); err != nil {
+				return err
 			}
+		}
 
-			cursor, err := st.Find(ctx, filter, driver.FindOptions{Limit: 1})
+		if opts.plan != nil {
+			dryRun, err := cmd.Flags().GetString(flagDryRun)
 			if err != nil {
 				return err
 			}
 
-			ok := cursor.Next(ctx)
-			_ = cursor.Close(ctx)
+			switch dryRun {
+			case dryRunNone:
+			case dryRunServer:
+				return opts.plan(cmd, metas, pruned)
+			default:
+				return errors.WithMessagef(errInvalidDryRun, "%q", dryRun)
+			}
+		}
 
-			if ok {
-				_, err := st.Update(ctx, filter, map[string]any{"$set": m})
-				if err != nil {
-					return err
-				}
-			} else {
-				if m.GetID() == uuid.Nil {
-					m.SetID(uuid.Must(uuid.NewV7()))
-				}
+		if err := upsert(ctx, st, metas); err != nil {
+			return err
+		}
+		if err := remove(ctx, st, pruned); err != nil {
+			return err
+		}
 
-				err := st.Insert(ctx, []any{m})
-				if err != nil {
-					return err
-				}
+		if len(metas) > 0 {
+			if err := writer.Write(metas); err != nil {
+				return err
 			}
 		}
+		for _, m := range pruned {
+			if _, err := io.WriteString(cmd.OutOrStdout(), meta.NamespacedName(m)+" pruned\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func upsert[T meta.Meta](ctx context.Context, st driver.Store, metas []T) error {
+	for _, m := range metas {
+		filter := map[string]any{}
+		if m.GetID() != uuid.Nil {
+			filter[meta.KeyID] = m.GetID()
+		}
+		if m.GetName() != "" {
+			filter[meta.KeyName] = m.GetName()
+		}
+
+		cursor, err := st.Find(ctx, filter, driver.FindOptions{Limit: 1})
+		if err != nil {
+			return err
+		}
 
-		return writer.Write(metas)
+		var stored []T
+		if err := cursor.All(ctx, &stored); err != nil {
+			return err
+		}
+
+		if len(stored) > 0 {
+			if m.GetID() == uuid.Nil {
+				m.SetID(stored[0].GetID())
+			}
+
+			// Unchanged resources are skipped so that watchers are not notified.
+			if ok, err := isEqual(stored[0], m); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+
+			_, err := st.Update(ctx, filter, map[string]any{"$set": m})
+			if err != nil {
+				return err
+			}
+		} else {
+			if m.GetID() == uuid.Nil {
+				m.SetID(uuid.Must(uuid.NewV7()))
+			}
+
+			err := st.Insert(ctx, []any{m})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isEqual(x, y any) (bool, error) {
+	v1, err := types.Marshal(x)
+	if err != nil {
+		return false, err
+	}
+	v2, err := types.Marshal(y)
+	if err != nil {
+		return false, err
+	}
+	return types.Equal(v1, v2), nil
+}
+
+func remove[T meta.Meta](ctx context.Context, st driver.Store, metas []T) error {
+	if len(metas) == 0 {
+		return nil
+	}
+
+	filters := make([]any, 0, len(metas))
+	for _, m := range metas {
+		filters = append(filters, map[string]any{meta.KeyID: m.GetID()})
+	}
+
+	_, err := st.Delete(ctx, map[string]any{"$or": filters})
+	return err
+}
+
+func admitSpecs(sc *scheme.Scheme, st driver.Store) func(cmd *cobra.Command, specs []spec.Spec) error {
+	return func(cmd *cobra.Command, specs []spec.Spec) error {
+		if sc == nil {
+			return nil
+		}
+
+		ok, err := cmd.Flags().GetBool(flagValidate)
+		if err != nil || !ok {
+			return err
+		}
+
+		ctx := cmd.Context()
+
+		var filters []any
+		for _, sp := range specs {
+			filters = append(filters, map[string]any{spec.KeyNamespace: sp.GetNamespace()})
+		}
+
+		cursor, err := st.Find(ctx, map[string]any{"$or": filters})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+
+		var stored []spec.Spec
+		if err := cursor.All(ctx, &stored); err != nil {
+			return err
+		}
+
+		applied := make(map[spec.Spec]bool, len(specs))
+		merged := append([]spec.Spec(nil), specs...)
+		for _, sp := range specs {
+			applied[sp] = true
+		}
+		for _, sp := range stored {
+			if !slices.ContainsFunc(specs, func(other spec.Spec) bool {
+				return (other.GetID() != uuid.Nil && other.GetID() == sp.GetID()) ||
+					(other.GetName() != "" && other.GetNamespace() == sp.GetNamespace() && other.GetName() == sp.GetName())
+			}) {
+				merged = append(merged, sp)
+			}
+		}
+
+		v := validation.NewValidator(validation.Config{Scheme: sc})
+
+		var issues []*validation.Issue
+		for _, issue := range v.Validate(merged...) {
+			if applied[issue.Spec] {
+				issues = append(issues, issue)
+			}
+		}
+		return validation.Errors(issues)
+	}
+}
+
+func sealValues(provider secret.KeyProvider) func(cmd *cobra.Command, values []*value.Value) error {
+	return func(cmd *cobra.Command, values []*value.Value) error {
+		for _, val := range values {
+			if err := secret.Encrypt(cmd.Context(), provider, val); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func own[T meta.Meta](st driver.Store, alias ...func(map[string]string)) func(cmd *cobra.Command, metas []T) ([]T, error) {
+	flags := map[string]string{
+		flagNamespace: flagNamespace,
+		flagFilename:  flagFilename,
+		flagOwner:     flagOwner,
+		flagPrune:     flagPrune,
+	}
+	for _, init := range alias {
+		init(flags)
+	}
+
+	return func(cmd *cobra.Command, metas []T) ([]T, error) {
+		namespace, err := cmd.Flags().GetString(flags[flagNamespace])
+		if err != nil {
+			return nil, err
+		}
+		filename, err := cmd.Flags().GetString(flags[flagFilename])
+		if err != nil {
+			return nil, err
+		}
+		prune, err := cmd.Flags().GetBool(flags[flagPrune])
+		if err != nil {
+			return nil, err
+		}
+
+		var owner string
+		if cmd.Flags().Lookup(flags[flagOwner]) != nil {
+			if owner, err = cmd.Flags().GetString(flags[flagOwner]); err != nil {
+				return nil, err
+			}
+		}
+
+		if owner == "" && !prune {
+			return nil, nil
+		}
+		// The file path is made absolute, so that the same file is owned alike wherever it is applied from.
+		if owner == "" {
+			if owner, err = filepath.Abs(filename); err != nil {
+				return nil, err
+			}
+		}
+
+		namespaces := []string{namespace}
+		for _, m := range metas {
+			annotations := maps.Clone(m.GetAnnotations())
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[annotationOwner] = owner
+			m.SetAnnotations(annotations)
+
+			namespaces = append(namespaces, m.GetNamespace())
+		}
+
+		if !prune {
+			return nil, nil
+		}
+
+		slices.Sort(namespaces)
+		namespaces = slices.Compact(namespaces)
+
+		filters := make([]any, 0, len(namespaces))
+		for _, ns := range namespaces {
+			filters = append(filters, map[string]any{meta.KeyNamespace: ns})
+		}
+
+		owned, err := find[T](cmd, st, map[string]any{"$or": filters}, selector{{key: annotationOwner, value: owner, equal: true}}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var pruned []T
+		for _, m := range owned {
+			if !slices.ContainsFunc(metas, func(other T) bool { return isSame(other, m) }) {
+				pruned = append(pruned, m)
+			}
+		}
+		return pruned, nil
 	}
 }
@@ -5,16 +5,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-faker/faker/v4"
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/validation"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
 
@@ -205,4 +211,433 @@ func TestApplyCommand_Execute(t *testing.T) {
 		require.True(t, cursor.Next(ctx))
 		require.Contains(t, output.String(), val.Name)
 	})
+
+	t.Run("InsertSecretValue", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		filename := "secrets.json"
+
+		password := faker.Password()
+		val := &value.Value{
+			ID:        uuid.Must(uuid.NewV7()),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Secret:    true,
+			Data:      password,
+		}
+
+		data, err := json.Marshal(val)
+		require.NoError(t, err)
+
+		file, err := fs.Create(filename)
+		require.NoError(t, err)
+		defer file.Close()
+
+		_, err = file.Write(data)
+		require.NoError(t, err)
+
+		keyProvider, err := secret.NewLocalKeyProvider(make([]byte, secret.KeySize))
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewApplyCommand(ApplyConfig{
+			KeyProvider: keyProvider,
+			SpecStore:   specStore,
+			ValueStore:  valueStore,
+			FS:          fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{values, fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.NotContains(t, output.String(), password)
+
+		cursor, err := valueStore.Find(ctx, map[string]any{value.KeyID: val.ID})
+		require.NoError(t, err)
+		defer cursor.Close(ctx)
+
+		var stored []*value.Value
+		err = cursor.All(ctx, &stored)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.NotNil(t, secret.EnvelopeOf(stored[0].Data))
+
+		err = secret.Decrypt(ctx, keyProvider, stored[0])
+		require.NoError(t, err)
+		require.Equal(t, password, stored[0].Data)
+	})
+
+	t.Run("RejectInvalidSpec", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		filename := "specs.json"
+
+		kind := faker.UUIDHyphenated()
+
+		s := scheme.New()
+		s.AddKnownType(kind, &spec.Meta{})
+		s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+			return node.NewOneToOneNode(nil), nil
+		}))
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Ports: map[string][]spec.Port{
+				node.PortOut: {{Name: faker.UUIDHyphenated(), Port: node.PortIn}},
+			},
+		}
+
+		data, err := json.Marshal(meta)
+		require.NoError(t, err)
+
+		file, err := fs.Create(filename)
+		require.NoError(t, err)
+		defer file.Close()
+
+		_, err = file.Write(data)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewApplyCommand(ApplyConfig{
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, validation.ErrMissingTarget)
+
+		cursor, err := specStore.Find(ctx, map[string]any{spec.KeyID: meta.GetID()})
+		require.NoError(t, err)
+		require.False(t, cursor.Next(ctx))
+
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename, fmt.Sprintf("--%s=false", flagValidate)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+	})
+	t.Run("DryRunServer", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		specStore := driver.NewStore()
+		valueStore := driver.NewStore()
+
+		kind := faker.UUIDHyphenated()
+
+		s := scheme.New()
+		s.AddKnownType(kind, &spec.Meta{})
+		s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+			return node.NewOneToOneNode(nil), nil
+		}))
+
+		val := &value.Value{
+			ID:        uuid.Must(uuid.NewV7()),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Data:      faker.UUIDHyphenated(),
+		}
+		stored := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		bound := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Env: map[string]spec.Value{
+				"key": {Name: val.Name, Data: "{{ . }}"},
+			},
+		}
+
+		err := specStore.Insert(ctx, []any{stored, bound})
+		require.NoError(t, err)
+		err = valueStore.Insert(ctx, []any{val})
+		require.NoError(t, err)
+
+		created := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		stored.Annotations = map[string]string{"team": faker.Word()}
+
+		data, err := json.Marshal([]any{stored, created})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, "specs.json", data, 0644)
+		require.NoError(t, err)
+
+		val.Data = faker.UUIDHyphenated()
+
+		data, err = json.Marshal(val)
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, "values.json", data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewApplyCommand(ApplyConfig{
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), "specs.json", fmt.Sprintf("--%s=%s", flagDryRun, dryRunServer)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Regexp(t, actionUpdated+`.*`+stored.Name, output.String())
+		require.Regexp(t, actionCreated+`.*`+created.Name, output.String())
+		require.NotContains(t, output.String(), bound.Name)
+
+		cursor, err := specStore.Find(ctx, map[string]any{spec.KeyID: created.GetID()})
+		require.NoError(t, err)
+		require.False(t, cursor.Next(ctx))
+
+		output.Reset()
+		cmd.SetArgs([]string{values, fmt.Sprintf("--%s", flagFilename), "values.json", fmt.Sprintf("--%s=%s", flagDryRun, dryRunServer)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Regexp(t, actionReloaded+`.*`+bound.Name, output.String())
+		require.NotContains(t, output.String(), stored.Name)
+
+		cursor, err = valueStore.Find(ctx, map[string]any{value.KeyID: val.GetID()})
+		require.NoError(t, err)
+		defer cursor.Close(ctx)
+
+		var vals []*value.Value
+		err = cursor.All(ctx, &vals)
+		require.NoError(t, err)
+		require.Len(t, vals, 1)
+		require.NotEqual(t, val.Data, vals[0].Data)
+
+		cmd.SetArgs([]string{values, fmt.Sprintf("--%s", flagFilename), "values.json", fmt.Sprintf("--%s=%s", flagDryRun, faker.Word())})
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, errInvalidDryRun)
+	})
+	t.Run("DryRunServerCompile", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		specStore := driver.NewStore()
+
+		kind := faker.UUIDHyphenated()
+
+		var compiled, closed int
+		s := scheme.New()
+		s.AddKnownType(kind, &spec.Meta{})
+		s.AddCodec(kind, scheme.CodecFunc(func(sp spec.Spec) (node.Node, error) {
+			if sp.GetAnnotations()["invalid"] != "" {
+				return nil, errors.New(faker.Sentence())
+			}
+			compiled++
+			n := node.NewOneToOneNode(nil)
+			return &closingNode{Node: n, close: func() { closed++ }}, nil
+		}))
+
+		stored := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{stored})
+		require.NoError(t, err)
+
+		created := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		data, err := json.Marshal([]any{created})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, "specs.json", data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewApplyCommand(ApplyConfig{
+			Scheme:    s,
+			SpecStore: specStore,
+			FS:        fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), "specs.json", fmt.Sprintf("--%s=%s", flagDryRun, dryRunServer), fmt.Sprintf("--%s=false", flagValidate)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Regexp(t, actionCreated+`.*`+created.Name, output.String())
+		require.Equal(t, 1, compiled)
+		require.Equal(t, compiled, closed)
+
+		stored.Annotations = map[string]string{"invalid": faker.Word()}
+
+		data, err = json.Marshal([]any{stored})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, "specs.json", data, 0644)
+		require.NoError(t, err)
+
+		err = cmd.Execute()
+		require.Error(t, err)
+	})
+	t.Run("Prune", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		specStore := driver.NewStore()
+		valueStore := driver.NewStore()
+
+		filename := "specs.json"
+
+		kind := faker.UUIDHyphenated()
+
+		s := scheme.New()
+		s.AddKnownType(kind, &spec.Meta{})
+		s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+			return node.NewOneToOneNode(nil), nil
+		}))
+
+		kept := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		removed := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		unowned := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{unowned})
+		require.NoError(t, err)
+
+		data, err := json.Marshal([]any{kept, removed})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewApplyCommand(ApplyConfig{
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		cursor, err := specStore.Find(ctx, map[string]any{spec.KeyID: removed.GetID()})
+		require.NoError(t, err)
+
+		var stored []*spec.Meta
+		err = cursor.All(ctx, &stored)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.NotContains(t, stored[0].GetAnnotations(), annotationOwner)
+
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename, fmt.Sprintf("--%s", flagPrune)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		owner, err := filepath.Abs(filename)
+		require.NoError(t, err)
+
+		cursor, err = specStore.Find(ctx, map[string]any{spec.KeyID: removed.GetID()})
+		require.NoError(t, err)
+
+		stored = nil
+		err = cursor.All(ctx, &stored)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, owner, stored[0].GetAnnotations()[annotationOwner])
+
+		data, err = json.Marshal([]any{kept})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output.Reset()
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename, fmt.Sprintf("--%s", flagPrune), fmt.Sprintf("--%s=%s", flagDryRun, dryRunServer)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Regexp(t, actionDeleted+`.*`+removed.Name, output.String())
+		require.NotContains(t, output.String(), unowned.Name)
+
+		cursor, err = specStore.Find(ctx, map[string]any{spec.KeyID: removed.GetID()})
+		require.NoError(t, err)
+		require.True(t, cursor.Next(ctx))
+		_ = cursor.Close(ctx)
+
+		output.Reset()
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename, fmt.Sprintf("--%s", flagPrune), fmt.Sprintf("--%s=%s", flagDryRun, dryRunNone)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), fmt.Sprintf("%s/%s pruned", meta.DefaultNamespace, removed.Name))
+
+		cursor, err = specStore.Find(ctx, nil)
+		require.NoError(t, err)
+
+		stored = nil
+		err = cursor.All(ctx, &stored)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.NotContains(t, []uuid.UUID{stored[0].GetID(), stored[1].GetID()}, removed.GetID())
+	})
+}
+
+type closingNode struct {
+	node.Node
+	close func()
+}
+
+func (n *closingNode) Close() error {
+	n.close()
+	return n.Node.Close()
 }
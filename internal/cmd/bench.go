@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	runtime2 "github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// BenchConfig holds the configuration for the bench command.
+type BenchConfig struct {
+	Namespace   string
+	Environment map[string]string
+	Scheme      *scheme.Scheme
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
+	SpecStore   driver.Store
+	ValueStore  driver.Store
+	FS          afero.Fs
+}
+
+// BenchResult holds the measurements of a single benchmark run.
+type BenchResult struct {
+	Name        string        `json:"name"`
+	Concurrency int           `json:"concurrency"`
+	Iterations  int           `json:"iterations"`
+	Errors      int           `json:"errors"`
+	Duration    time.Duration `json:"duration"`
+	NsPerOp     float64       `json:"ns_per_op"`
+	OpsPerSec   float64       `json:"ops_per_sec"`
+	P50         time.Duration `json:"p50"`
+	P95         time.Duration `json:"p95"`
+	P99         time.Duration `json:"p99"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	ErrorRate   float64       `json:"error_rate"`
+}
+
+type benchOptions struct {
+	concurrency int
+	iterations  int
+	duration    time.Duration
+}
+
+const formatText = "text"
+
+const defaultBenchDuration = time.Second
+
+// NewBenchCommand creates a new cobra.Command for the bench command.
+func NewBenchCommand(config BenchConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench <name>",
+		Short: "Repeatedly send payloads to a node in the specified namespace and report its throughput and latency",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runBenchCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), config.Namespace, "Inject the namespace for running the workflow")
+	cmd.PersistentFlags().StringToStringP(flagEnvironment, toShorthand(flagEnvironment), config.Environment, "Inject environment variables for the workflow execution")
+	cmd.PersistentFlags().String(flagPort, node.PortIn, "Set the input port to write the payloads to")
+	cmd.PersistentFlags().String(flagPayload, "", "Set the payload as YAML or JSON, or @file to read it from a file")
+	cmd.PersistentFlags().String(flagPayloads, "", "Read a list of payloads from a YAML or JSON file and send them in turn")
+	cmd.PersistentFlags().Int(flagConcurrency, 1, "Set the number of payloads sent at once")
+	cmd.PersistentFlags().Int(flagIterations, 0, "Stop after sending the given number of payloads (0 for no limit)")
+	cmd.PersistentFlags().Duration(flagDuration, 0, "Stop after the given duration (defaults to 1s without an iteration limit)")
+	cmd.PersistentFlags().Int(flagCount, 1, "Run the benchmark the given number of times")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), formatText, "Set the output format (text, json, yaml, jsonpath=<expr>)")
+
+	return cmd
+}
+
+func runBenchCommand(config BenchConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		environment, err := cmd.Flags().GetStringToString(flagEnvironment)
+		if err != nil {
+			return err
+		}
+		name, err := cmd.Flags().GetString(flagPort)
+		if err != nil {
+			return err
+		}
+		data, err := cmd.Flags().GetString(flagPayload)
+		if err != nil {
+			return err
+		}
+		filename, err := cmd.Flags().GetString(flagPayloads)
+		if err != nil {
+			return err
+		}
+		concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+		if err != nil {
+			return err
+		}
+		iterations, err := cmd.Flags().GetInt(flagIterations)
+		if err != nil {
+			return err
+		}
+		duration, err := cmd.Flags().GetDuration(flagDuration)
+		if err != nil {
+			return err
+		}
+		count, err := cmd.Flags().GetInt(flagCount)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+
+		var printer fmt2.Printer
+		if output != formatText {
+			printer, err = fmt2.NewPrinter(cmd.OutOrStdout(), output)
+			if err != nil {
+				return err
+			}
+		}
+
+		payloads, err := readPayloads(config.FS, data, filename)
+		if err != nil {
+			return err
+		}
+
+		if duration <= 0 && iterations <= 0 {
+			duration = defaultBenchDuration
+		}
+
+		r := runtime2.New(runtime2.Config{
+			Namespace:   namespace,
+			Environment: environment,
+			Scheme:      config.Scheme,
+			SpecStore:   config.SpecStore,
+			ValueStore:  config.ValueStore,
+			KeyProvider: config.KeyProvider,
+			Resolver:    config.Resolver,
+			Warn:        warn(cmd),
+		})
+		defer r.Close(ctx)
+
+		if err := r.Load(ctx, nil); err != nil {
+			return err
+		}
+
+		sb := findSymbol(r.Symbols(), args[0])
+		if sb == nil {
+			return errors.WithMessagef(errSymbolNotFound, "%s/%s", namespace, args[0])
+		}
+		if sb.In(name) == nil {
+			return errors.WithMessagef(errPortNotFound, "%s: %s", sb.NamespacedName(), name)
+		}
+
+		if printer == nil {
+			if err := printBenchHeader(cmd.OutOrStdout(), namespace); err != nil {
+				return err
+			}
+		}
+
+		var results []*BenchResult
+		for range max(count, 1) {
+			result, err := bench(ctx, sb, name, payloads, benchOptions{
+				concurrency: concurrency,
+				iterations:  iterations,
+				duration:    duration,
+			})
+			if err != nil {
+				return err
+			}
+
+			if printer == nil {
+				if err := printBenchResult(cmd.OutOrStdout(), result); err != nil {
+					return err
+				}
+			}
+			results = append(results, result)
+		}
+
+		if printer != nil {
+			return printer.Write(results)
+		}
+		return nil
+	}
+}
+
+// bench sends the payloads to the in-port of the symbol in turn until the iterations or the duration run out.
+func bench(ctx context.Context, sb *symbol.Symbol, name string, payloads []types.Value, opts benchOptions) (*BenchResult, error) {
+	concurrency := max(opts.concurrency, 1)
+	if len(payloads) == 0 {
+		payloads = []types.Value{nil}
+	}
+
+	if opts.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.duration)
+		defer cancel()
+	}
+
+	var next, failed atomic.Int64
+	latencies := make([][]time.Duration, concurrency)
+
+	var before runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for w := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				i := next.Add(1) - 1
+				if opts.iterations > 0 && i >= int64(opts.iterations) {
+					return
+				}
+
+				t := time.Now()
+				if _, err := invoke(sb, name, payloads[i%int64(len(payloads))]); err != nil {
+					failed.Add(1)
+				}
+				latencies[w] = append(latencies[w], time.Since(t))
+			}
+		}()
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	samples := slices.Concat(latencies...)
+	slices.Sort(samples)
+
+	result := &BenchResult{
+		Name:        sb.Name(),
+		Concurrency: concurrency,
+		Iterations:  len(samples),
+		Errors:      int(failed.Load()),
+		Duration:    elapsed,
+	}
+	if n := len(samples); n > 0 {
+		result.NsPerOp = float64(elapsed.Nanoseconds()) / float64(n)
+		result.OpsPerSec = float64(n) / elapsed.Seconds()
+		result.P50 = percentile(samples, 0.50)
+		result.P95 = percentile(samples, 0.95)
+		result.P99 = percentile(samples, 0.99)
+		result.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(n)
+		result.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(n)
+		result.ErrorRate = float64(result.Errors) / float64(n)
+	}
+	return result, nil
+}
+
+func percentile(samples []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p*float64(len(samples)))) - 1
+	return samples[max(i, 0)]
+}
+
+// printBenchHeader writes the configuration lines that benchstat uses to tell runs apart.
+func printBenchHeader(w io.Writer, namespace string) error {
+	_, err := io.WriteString(w, "goos: "+runtime.GOOS+"\ngoarch: "+runtime.GOARCH+"\nnamespace: "+namespace+"\n")
+	return err
+}
+
+// printBenchResult writes the result as a line of the Go benchmark format, so that runs can be compared with benchstat.
+func printBenchResult(w io.Writer, result *BenchResult) error {
+	name := result.Name
+	if r, size := utf8.DecodeRuneInString(name); r != utf8.RuneError {
+		name = string(unicode.ToUpper(r)) + name[size:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	_, err := fmt.Fprintf(w, "Benchmark%s-%d\t%8d\t%12.1f ns/op\t%12.1f ops/s\t%10d p50-ns\t%10d p95-ns\t%10d p99-ns\t%8d B/op\t%6d allocs/op\t%.4f errors/op\n",
+		name, result.Concurrency, result.Iterations, result.NsPerOp, result.OpsPerSec,
+		result.P50.Nanoseconds(), result.P95.Nanoseconds(), result.P99.Nanoseconds(),
+		result.BytesPerOp, result.AllocsPerOp, result.ErrorRate)
+	return err
+}
+
+func readPayloads(fs afero.Fs, data, filename string) ([]types.Value, error) {
+	if filename == "" {
+		payload, err := readPayload(fs, data)
+		if err != nil {
+			return nil, err
+		}
+		return []types.Value{payload}, nil
+	}
+
+	file, err := fs.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var payloads []any
+	if err := fmt2.NewReader(file).Read(&payloads); err != nil {
+		return nil, err
+	}
+
+	values := make([]types.Value, 0, len(payloads))
+	for _, payload := range payloads {
+		v, err := types.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestBenchCommand_Execute(t *testing.T) {
+	s := scheme.New()
+
+	specStore := driver.NewStore()
+	valueStore := driver.NewStore()
+
+	fs := afero.NewMemMapFs()
+
+	echo := faker.UUIDHyphenated()
+	fail := faker.UUIDHyphenated()
+
+	s.AddKnownType(echo, &spec.Meta{})
+	s.AddCodec(echo, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		}), nil
+	}))
+
+	s.AddKnownType(fail, &spec.Meta{})
+	s.AddCodec(fail, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return nil, packet.New(types.NewError(errors.New(faker.Sentence())))
+		}), nil
+	}))
+
+	t.Run("Iterations", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      echo,
+			Namespace: meta.DefaultNamespace,
+			Name:      "echo",
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewBenchCommand(BenchConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagPayload), `{"key": "value"}`, fmt.Sprintf("--%s", flagIterations), "100", fmt.Sprintf("--%s", flagCount), "2"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), "goos: ")
+		require.Equal(t, 2, bytes.Count(output.Bytes(), []byte("BenchmarkEcho-1\t     100\t")))
+		require.Contains(t, output.String(), "0.0000 errors/op")
+	})
+
+	t.Run("Payloads", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      echo,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		filename := "payloads.json"
+
+		err = afero.WriteFile(fs, filename, []byte(`[1, "two", {"three": 3}]`), 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewBenchCommand(BenchConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagPayloads), filename, fmt.Sprintf("--%s", flagIterations), "30", fmt.Sprintf("--%s", flagConcurrency), "3", fmt.Sprintf("--%s", flagOutput), "json"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		var results []map[string]any
+		err = json.Unmarshal(output.Bytes(), &results)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, float64(30), results[0]["iterations"])
+		require.Equal(t, float64(3), results[0]["concurrency"])
+		require.Equal(t, float64(0), results[0]["errors"])
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      echo,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewBenchCommand(BenchConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagDuration), "50ms", fmt.Sprintf("--%s", flagOutput), "json"})
+
+		start := time.Now()
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), time.Second)
+
+		var results []map[string]any
+		err = json.Unmarshal(output.Bytes(), &results)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Greater(t, results[0]["iterations"], float64(0))
+		require.Greater(t, results[0]["ops_per_sec"], float64(0))
+	})
+
+	t.Run("ErrorRate", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      fail,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewBenchCommand(BenchConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagIterations), "10", fmt.Sprintf("--%s", flagOutput), "json"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		var results []map[string]any
+		err = json.Unmarshal(output.Bytes(), &results)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, float64(10), results[0]["errors"])
+		require.Equal(t, float64(1), results[0]["error_rate"])
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		cmd := NewBenchCommand(BenchConfig{
+			Namespace:  meta.DefaultNamespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{faker.UUIDHyphenated()})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, errSymbolNotFound)
+	})
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"runtime"
 	"runtime/debug"
 	"runtime/pprof"
@@ -86,3 +87,10 @@ func NewCommand(config Config) *cobra.Command {
 
 	return cmd
 }
+
+// warn returns a function printing problems that do not stop the command to its error output.
+func warn(cmd *cobra.Command) func(error) {
+	return func(err error) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+
+	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+)
+
+// printCoverage writes a table of the coverage of each symbol followed by a summary line.
+func printCoverage(w io.Writer, report *runtime.CoverageReport) error {
+	rows := make([]map[string]any, 0, len(report.Details))
+	for _, detail := range report.Details {
+		name := detail.Name
+		if name == "" {
+			name = detail.ID.String()
+		}
+
+		ports, branches := 0, 0
+		for _, fired := range detail.Ports {
+			if fired {
+				ports++
+			}
+		}
+		for _, fired := range detail.Branches {
+			if fired {
+				branches++
+			}
+		}
+
+		row := map[string]any{
+			"name":     detail.Namespace + "/" + name,
+			"kind":     detail.Kind,
+			"entered":  detail.Entered,
+			"ports":    fmt.Sprintf("%d/%d", ports, len(detail.Ports)),
+			"branches": "-",
+		}
+		if len(detail.Branches) > 0 {
+			row["branches"] = fmt.Sprintf("%d/%d", branches, len(detail.Branches))
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) > 0 {
+		if err := fmt2.NewWriter(w).Write(rows); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "coverage: %.1f%% (symbols %d/%d, ports %d/%d, branches %d/%d)\n",
+		report.Percent,
+		report.Symbols.Covered, report.Symbols.Total,
+		report.Ports.Covered, report.Ports.Total,
+		report.Branches.Covered, report.Branches.Total,
+	)
+	return err
+}
+
+// writeCoverage writes the coverage report to the file as indented JSON.
+func writeCoverage(fs afero.Fs, filename string, report *runtime.CoverageReport) error {
+	f, err := fs.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -12,10 +12,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gofrs/uuid"
 
+	"github.com/siyul-park/uniflow/internal/diagram"
 	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
 	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
 	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/symbol"
 )
 
@@ -23,6 +25,7 @@ import (
 type Debugger struct {
 	agent    *runtime.Agent
 	debugger *runtime.Debugger
+	counter  *runtime.Counter
 	program  *tea.Program
 }
 
@@ -32,6 +35,7 @@ type debugModel struct {
 	input    textinput.Model
 	agent    *runtime.Agent
 	debugger *runtime.Debugger
+	counter  *runtime.Counter
 }
 
 // debugView defines an interface for different debug view types.
@@ -50,6 +54,10 @@ type (
 	symbolsDebugView     struct{ symbols []*symbol.Symbol }
 	processDebugView     struct{ process *process.Process }
 	processesDebugView   struct{ processes []*process.Process }
+	graphDebugView       struct {
+		diagram *diagram.Diagram
+		format  string
+	}
 )
 
 var (
@@ -62,6 +70,7 @@ var (
 	_ debugView = (*symbolsDebugView)(nil)
 	_ debugView = (*processDebugView)(nil)
 	_ debugView = (*processesDebugView)(nil)
+	_ debugView = (*graphDebugView)(nil)
 )
 
 // NewDebugger initializes a new Debugger with an input model and UI.
@@ -71,16 +80,21 @@ func NewDebugger(agent *runtime.Agent, options ...tea.ProgramOption) *Debugger {
 	ti.Focus()
 
 	debugger := runtime.NewDebugger(agent)
+	counter := runtime.NewCounter()
+	agent.Watch(counter)
+
 	model := &debugModel{
 		input:    ti,
 		agent:    agent,
 		debugger: debugger,
+		counter:  counter,
 	}
 	program := tea.NewProgram(model, options...)
 
 	return &Debugger{
 		agent:    agent,
 		debugger: debugger,
+		counter:  counter,
 		program:  program,
 	}
 }
@@ -92,6 +106,7 @@ func (d *Debugger) Run() error {
 	go func() {
 		d.program.Wait()
 		d.debugger.Close()
+		d.agent.Unwatch(d.counter)
 	}()
 
 	return err
@@ -298,6 +313,27 @@ func (m *debugModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.view = &framesDebugView{frames: frames}
 				return m, nil
+			case "graph", "gr":
+				sbs := m.agent.Symbols()
+				slices.SortFunc(sbs, func(x, y *symbol.Symbol) int {
+					return strings.Compare(x.ID().String(), y.ID().String())
+				})
+
+				specs := make([]spec.Spec, 0, len(sbs))
+				for _, sb := range sbs {
+					specs = append(specs, sb.Spec)
+				}
+
+				d := diagram.New(specs...)
+				d.Overlay(m.counter.Counts())
+
+				format := diagram.FormatMermaid
+				if len(args) > 1 {
+					format = args[1]
+				}
+
+				m.view = &graphDebugView{diagram: d, format: format}
+				return m, nil
 			}
 		}
 	case *runtime.Frame:
@@ -443,3 +479,11 @@ func (v *processesDebugView) View() string {
 	_ = writer.Write(procs)
 	return buffer.String()
 }
+
+func (v *graphDebugView) View() string {
+	buffer := bytes.NewBuffer(nil)
+	if err := diagram.Render(buffer, v.diagram, v.format); err != nil {
+		return (&errDebugView{err: err}).View()
+	}
+	return buffer.String()
+}
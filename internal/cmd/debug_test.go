@@ -800,4 +800,57 @@ func TestDebugModel_Update(t *testing.T) {
 
 		d.RemoveBreakpoint(d.Breakpoint())
 	})
+
+	t.Run("graph", func(t *testing.T) {
+		a := runtime.NewAgent()
+		defer a.Close()
+
+		d := runtime.NewDebugger(a)
+		defer d.Close()
+
+		m := &debugModel{
+			input:    textinput.New(),
+			agent:    a,
+			debugger: d,
+			counter:  runtime.NewCounter(),
+		}
+
+		sb1 := &symbol.Symbol{
+			Spec: &spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      faker.UUIDHyphenated(),
+				Namespace: meta.DefaultNamespace,
+				Name:      faker.UUIDHyphenated(),
+			},
+			Node: node.NewOneToOneNode(nil),
+		}
+		defer sb1.Close()
+
+		sb2 := &symbol.Symbol{
+			Spec: &spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      faker.UUIDHyphenated(),
+				Namespace: meta.DefaultNamespace,
+				Name:      faker.UUIDHyphenated(),
+				Ports: map[string][]spec.Port{
+					node.PortOut: {{ID: sb1.ID(), Port: node.PortIn}},
+				},
+			},
+			Node: node.NewOneToOneNode(nil),
+		}
+		defer sb2.Close()
+
+		m.agent.Load(sb1)
+		defer m.agent.Unload(sb1)
+
+		m.agent.Load(sb2)
+		defer m.agent.Unload(sb2)
+
+		m.input.SetValue("graph dot")
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		require.Contains(t, m.View(), "digraph")
+		require.Contains(t, m.View(), sb1.Name())
+		require.Contains(t, m.View(), "out -> in [0]")
+	})
 }
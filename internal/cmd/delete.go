@@ -34,6 +34,8 @@ func NewDeleteCommand(config DeleteConfig) *cobra.Command {
 
 	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use the default namespace")
 	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be deleted")
+	cmd.PersistentFlags().StringP(flagLabel, toShorthand(flagLabel), "", "Delete only resources whose annotations match (e.g. team=payments,env!=dev)")
+	cmd.PersistentFlags().String(flagField, "", "Delete only resources whose fields match (e.g. kind=listener)")
 
 	return cmd
 }
@@ -58,41 +60,67 @@ func runDeleteCommand[T meta.Meta](store driver.Store, fs afero.Fs, alias ...fun
 		if err != nil {
 			return err
 		}
-		if filename == "" {
-			return nil
-		}
-
-		file, err := fs.Open(filename)
+		labels, fields, err := selectors(cmd)
 		if err != nil {
 			return err
 		}
-		defer file.Close()
+		if filename == "" && len(labels) == 0 && len(fields) == 0 {
+			return nil
+		}
 
-		reader := fmt.NewReader(file)
+		filter := map[string]any{meta.KeyNamespace: namespace}
 
-		var metas []T
-		if err := reader.Read(&metas); err != nil {
-			return err
+		if filename != "" {
+			file, err := fs.Open(filename)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			reader := fmt.NewReader(file)
+
+			var metas []T
+			if err := reader.Read(&metas); err != nil {
+				return err
+			}
+
+			filters := make([]any, 0, len(metas))
+			for _, m := range metas {
+				filter := map[string]any{}
+				if m.GetID() != uuid.Nil {
+					filter[meta.KeyID] = m.GetID()
+				}
+				if m.GetName() != "" {
+					filter[meta.KeyName] = m.GetName()
+				}
+				filters = append(filters, filter)
+			}
+
+			filter = map[string]any{
+				"$and": []any{
+					map[string]any{meta.KeyNamespace: namespace},
+					map[string]any{"$or": filters},
+				},
+			}
 		}
 
-		filters := make([]any, 0, len(metas))
-		for _, m := range metas {
-			filter := map[string]any{}
-			if m.GetID() != uuid.Nil {
-				filter[meta.KeyID] = m.GetID()
+		if len(labels) > 0 || len(fields) > 0 {
+			metas, err := find[T](cmd, store, filter, labels, fields)
+			if err != nil {
+				return err
 			}
-			if m.GetName() != "" {
-				filter[meta.KeyName] = m.GetName()
+			if len(metas) == 0 {
+				return nil
+			}
+
+			filters := make([]any, 0, len(metas))
+			for _, m := range metas {
+				filters = append(filters, map[string]any{meta.KeyID: m.GetID()})
 			}
-			filters = append(filters, filter)
+			filter = map[string]any{"$or": filters}
 		}
 
-		_, err = store.Delete(ctx, map[string]any{
-			"$and": []any{
-				map[string]any{meta.KeyNamespace: namespace},
-				map[string]any{"$or": filters},
-			},
-		})
+		_, err = store.Delete(ctx, filter)
 		return err
 	}
 }
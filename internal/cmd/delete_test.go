@@ -108,4 +108,47 @@ func TestDeleteCommand_Execute(t *testing.T) {
 		require.NoError(t, err)
 		require.False(t, cursor.Next(ctx))
 	})
+
+	t.Run("DeleteSpecWithSelector", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		kind := faker.UUIDHyphenated()
+
+		matched := &spec.Meta{
+			ID:          uuid.Must(uuid.NewV7()),
+			Kind:        kind,
+			Namespace:   meta.DefaultNamespace,
+			Name:        faker.UUIDHyphenated(),
+			Annotations: map[string]string{"team": "payments"},
+		}
+		unmatched := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{matched, unmatched})
+		require.NoError(t, err)
+
+		cmd := NewDeleteCommand(DeleteConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagLabel), "team=payments", fmt.Sprintf("--%s", flagField), "kind=" + kind})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		cursor, err := specStore.Find(ctx, map[string]any{spec.KeyID: matched.ID})
+		require.NoError(t, err)
+		require.False(t, cursor.Next(ctx))
+
+		cursor, err = specStore.Find(ctx, map[string]any{spec.KeyID: unmatched.ID})
+		require.NoError(t, err)
+		require.True(t, cursor.Next(ctx))
+	})
 }
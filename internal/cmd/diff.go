@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+// DiffConfig represents the configuration for the diff command.
+type DiffConfig struct {
+	SpecStore  driver.Store
+	ValueStore driver.Store
+	FS         afero.Fs
+}
+
+// NewDiffCommand creates a new cobra.Command for the diff command.
+func NewDiffCommand(config DiffConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "diff",
+		Short:     "Show differences between a file and the stored resources",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{specs, values},
+		RunE: runs(map[string]func(cmd *cobra.Command) error{
+			specs:  runDiffCommand[spec.Spec](config.SpecStore, config.FS),
+			values: runDiffCommand[*value.Value](config.ValueStore, config.FS),
+		}),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use the default namespace")
+	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be compared")
+
+	return cmd
+}
+
+func runDiffCommand[T meta.Meta](store driver.Store, fs afero.Fs) func(cmd *cobra.Command) error {
+	return func(cmd *cobra.Command) error {
+		ctx := cmd.Context()
+
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		filename, err := cmd.Flags().GetString(flagFilename)
+		if err != nil {
+			return err
+		}
+		if filename == "" {
+			return nil
+		}
+
+		reader := compose.NewComposer(fs)
+
+		var metas []T
+		if err := reader.Read(filename, &metas); err != nil {
+			return err
+		}
+
+		for _, m := range metas {
+			if m.GetNamespace() == "" {
+				m.SetNamespace(namespace)
+			}
+
+			filter := map[string]any{meta.KeyNamespace: m.GetNamespace()}
+			if m.GetID() != uuid.Nil {
+				filter[meta.KeyID] = m.GetID()
+			}
+			if m.GetName() != "" {
+				filter[meta.KeyName] = m.GetName()
+			}
+
+			cursor, err := store.Find(ctx, filter, driver.FindOptions{Limit: 1})
+			if err != nil {
+				return err
+			}
+
+			var stored []T
+			err = cursor.All(ctx, &stored)
+			_ = cursor.Close(ctx)
+			if err != nil {
+				return err
+			}
+
+			name := m.GetName()
+			if name == "" {
+				name = m.GetID().String()
+			}
+			name = m.GetNamespace() + "/" + name
+
+			var from any
+			fromName := "/dev/null"
+			if len(stored) > 0 {
+				if m.GetID() == uuid.Nil {
+					m.SetID(stored[0].GetID())
+				}
+				from = redact(stored)[0]
+				fromName = "stored/" + name
+			}
+			to := redact([]T{m})[0]
+
+			if _, err := fmt.Diff(cmd.OutOrStdout(), from, to, fromName, "local/"+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+func TestDiffCommand_Execute(t *testing.T) {
+	specStore := driver.NewStore()
+	valueStore := driver.NewStore()
+
+	fs := afero.NewMemMapFs()
+
+	t.Run("DiffSpec", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		filename := "specs.json"
+
+		stored := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{stored})
+		require.NoError(t, err)
+
+		updated := &spec.Meta{
+			Kind:        stored.Kind,
+			Name:        stored.Name,
+			Annotations: map[string]string{"team": "payments"},
+		}
+		created := &spec.Meta{
+			Kind: faker.UUIDHyphenated(),
+			Name: faker.UUIDHyphenated(),
+		}
+
+		data, err := json.Marshal([]any{updated, created})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewDiffCommand(DiffConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), fmt.Sprintf("--- stored/%s/%s\n", meta.DefaultNamespace, stored.Name))
+		require.Contains(t, output.String(), "+annotations.team: \"payments\"\n")
+		require.NotContains(t, output.String(), "-id: ")
+		require.Contains(t, output.String(), fmt.Sprintf("--- /dev/null\n+++ local/%s/%s\n", meta.DefaultNamespace, created.Name))
+	})
+
+	t.Run("DiffSecretValue", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		filename := "values.json"
+
+		stored := &value.Value{
+			ID:        uuid.Must(uuid.NewV7()),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Secret:    true,
+			Data:      faker.Password(),
+		}
+
+		err := valueStore.Insert(ctx, []any{stored})
+		require.NoError(t, err)
+
+		password := faker.Password()
+		updated := &value.Value{
+			Name:   stored.Name,
+			Secret: true,
+			Data:   password,
+		}
+
+		data, err := json.Marshal(updated)
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewDiffCommand(DiffConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{values, fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.NotContains(t, output.String(), password)
+		require.NotContains(t, output.String(), stored.Data)
+	})
+}
@@ -3,13 +3,50 @@ package cmd
 const (
 	flagNamespace = "namespace"
 	flagFilename  = "filename"
+	flagOutput    = "output"
+	flagValidate  = "validate"
+	flagDryRun    = "dry-run"
+	flagPrune     = "prune"
+	flagOwner     = "owner"
+
+	flagLabel         = "label"
+	flagField         = "field"
+	flagWatch         = "watch"
+	flagAllNamespaces = "all-namespaces"
 
 	flagFromSpecs  = "from-specs"
 	flagFromValues = "from-values"
 
+	flagPort    = "port"
+	flagPayload = "payload"
+
+	flagPayloads   = "payloads"
+	flagIterations = "iterations"
+	flagDuration   = "duration"
+
+	flagModule = "module"
+	flagAdmin  = "admin"
+	flagToken  = "admin-token"
+	flagGoPath = "gopath"
+	flagConfig = "config"
+
+	flagReporter          = "reporter"
+	flagCoverage          = "coverage"
+	flagCoverageThreshold = "coverage-threshold"
+	flagConcurrency       = "concurrency"
+	flagIsolate           = "isolate"
+	flagCount             = "count"
+	flagRetries           = "retries"
+	flagTimeout           = "timeout"
+	flagSuiteTimeout      = "suite-timeout"
+	flagUpdateSnapshots   = "update-snapshots"
+
 	flagDebug       = "debug"
 	flagEnvironment = "environment"
 
+	flagStrictSchema   = "strict-schema"
+	flagValidateSchema = "validate-schema"
+
 	flagCPUProfile = "cpuprofile"
 	flagMemProfile = "memprofile"
 )
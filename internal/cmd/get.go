@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"github.com/gofrs/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/siyul-park/uniflow/internal/fmt"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
@@ -30,6 +32,11 @@ func NewGetCommand(config GetConfig) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use all namespace")
+	cmd.PersistentFlags().BoolP(flagAllNamespaces, toShorthand(flagAllNamespaces), false, "Lookup resources across all namespaces")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), fmt.FormatTable, "Set the output format (table, json, yaml, name, jsonpath=<expr>)")
+	cmd.PersistentFlags().StringP(flagLabel, toShorthand(flagLabel), "", "Filter resources by annotations (e.g. team=payments,env!=dev)")
+	cmd.PersistentFlags().String(flagField, "", "Filter resources by fields (e.g. kind=listener)")
+	cmd.PersistentFlags().BoolP(flagWatch, toShorthand(flagWatch), false, "Watch for changes after listing the resources")
 
 	return cmd
 }
@@ -49,19 +56,144 @@ func runGetCommand[T meta.Meta](store driver.Store, alias ...func(map[string]str
 		if err != nil {
 			return err
 		}
+		allNamespaces, err := cmd.Flags().GetBool(flagAllNamespaces)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+		watch, err := cmd.Flags().GetBool(flagWatch)
+		if err != nil {
+			return err
+		}
+		labels, fields, err := selectors(cmd)
+		if err != nil {
+			return err
+		}
 
-		writer := fmt.NewWriter(cmd.OutOrStdout())
-
-		cursor, err := store.Find(ctx, map[string]any{meta.KeyNamespace: namespace})
+		writer, err := fmt.NewPrinter(cmd.OutOrStdout(), output)
 		if err != nil {
 			return err
 		}
-		defer cursor.Close(ctx)
 
-		var metas []T
-		if err := cursor.All(ctx, &metas); err != nil {
+		filter := map[string]any{}
+		if !allNamespaces {
+			filter[meta.KeyNamespace] = namespace
+		}
+
+		var stream driver.Stream
+		if watch {
+			if stream, err = store.Watch(ctx, filter); err != nil {
+				return err
+			}
+			defer stream.Close(ctx)
+		}
+
+		metas, err := find[T](cmd, store, filter, labels, fields)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(redact(metas)); err != nil {
 			return err
 		}
-		return writer.Write(metas)
+
+		if stream == nil {
+			return nil
+		}
+
+		seen := make(map[uuid.UUID]T, len(metas))
+		for _, m := range metas {
+			seen[m.GetID()] = m
+		}
+
+		for stream.Next(ctx) {
+			var event driver.Event
+			if err := stream.Decode(&event); err != nil {
+				return err
+			}
+
+			metas, err := find[T](cmd, store, map[string]any{meta.KeyID: event.ID}, labels, fields)
+			if err != nil {
+				return err
+			}
+
+			if len(metas) == 0 {
+				m, ok := seen[event.ID]
+				if !ok {
+					continue
+				}
+				delete(seen, event.ID)
+				metas = append(metas, m)
+			} else {
+				seen[event.ID] = metas[0]
+			}
+
+			if err := writer.Write(redact(metas)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func find[T meta.Meta](cmd *cobra.Command, store driver.Store, filter map[string]any, labels, fields selector) ([]T, error) {
+	ctx := cmd.Context()
+
+	cursor, err := store.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var metas []T
+	if err := cursor.All(ctx, &metas); err != nil {
+		return nil, err
+	}
+
+	matches := make([]T, 0, len(metas))
+	for _, m := range metas {
+		if !labels.matchLabels(m) {
+			continue
+		}
+		if ok, err := fields.matchFields(m); err != nil {
+			return nil, err
+		} else if ok {
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+func selectors(cmd *cobra.Command) (selector, selector, error) {
+	label, err := cmd.Flags().GetString(flagLabel)
+	if err != nil {
+		return nil, nil, err
+	}
+	field, err := cmd.Flags().GetString(flagField)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels, err := parseSelector(label)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := parseSelector(field)
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels, fields, nil
+}
+
+func redact[T meta.Meta](metas []T) []T {
+	redacted := make([]T, len(metas))
+	for i, m := range metas {
+		if val, ok := any(m).(*value.Value); ok {
+			m = any(secret.Redact(val)).(T)
+		}
+		redacted[i] = m
 	}
+	return redacted
 }
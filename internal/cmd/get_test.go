@@ -3,7 +3,11 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-faker/faker/v4"
 	"github.com/gofrs/uuid"
@@ -11,6 +15,7 @@ import (
 
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
@@ -78,4 +83,157 @@ func TestGetCommand_Execute(t *testing.T) {
 		require.NoError(t, err)
 		require.Contains(t, output.String(), val.Name)
 	})
+
+	t.Run("GetSecretValue", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		password := faker.Password()
+		val := &value.Value{
+			ID:        uuid.Must(uuid.NewV7()),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Secret:    true,
+			Data:      password,
+		}
+
+		err := valueStore.Insert(ctx, []any{val})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewGetCommand(GetConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{values})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), val.Name)
+		require.Contains(t, output.String(), secret.Redacted)
+		require.NotContains(t, output.String(), password)
+	})
+
+	t.Run("GetSpecWithSelector", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		kind := faker.UUIDHyphenated()
+
+		matched := &spec.Meta{
+			ID:          uuid.Must(uuid.NewV7()),
+			Kind:        kind,
+			Namespace:   meta.DefaultNamespace,
+			Name:        faker.UUIDHyphenated(),
+			Annotations: map[string]string{"team": "payments"},
+		}
+		unmatched := &spec.Meta{
+			ID:          uuid.Must(uuid.NewV7()),
+			Kind:        kind,
+			Namespace:   meta.DefaultNamespace,
+			Name:        faker.UUIDHyphenated(),
+			Annotations: map[string]string{"team": "search"},
+		}
+
+		err := specStore.Insert(ctx, []any{matched, unmatched})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewGetCommand(GetConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagLabel), "team=payments", fmt.Sprintf("--%s", flagField), "kind=" + kind, fmt.Sprintf("--%s", flagOutput), "name"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, meta.DefaultNamespace+"/"+matched.Name+"\n", output.String())
+	})
+
+	t.Run("GetSpecAllNamespaces", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: faker.UUIDHyphenated(),
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewGetCommand(GetConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagAllNamespaces), fmt.Sprintf("--%s", flagOutput), "jsonpath={.name}"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), meta.Name)
+	})
+
+	t.Run("WatchSpec", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		output := &syncBuffer{}
+
+		cmd := NewGetCommand(GetConfig{
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{specs, fmt.Sprintf("--%s", flagWatch), fmt.Sprintf("--%s", flagOutput), "name"})
+
+		done := make(chan error)
+		go func() {
+			done <- cmd.ExecuteContext(ctx)
+		}()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		require.Eventually(t, func() bool {
+			_ = specStore.Insert(ctx, []any{meta})
+			return strings.Contains(output.String(), meta.Name)
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
 }
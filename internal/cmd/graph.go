@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/diagram"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+// GraphConfig represents the configuration for the graph command.
+type GraphConfig struct {
+	SpecStore driver.Store
+	FS        afero.Fs
+}
+
+// NewGraphCommand creates a new cobra.Command for the graph command.
+func NewGraphCommand(config GraphConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Render the port graph of specs as DOT, Mermaid or JSON",
+		RunE:  runGraphCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use the default namespace")
+	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be rendered. If not set, render the specs in the namespace")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), diagram.FormatDOT, "Set the output format (dot, mermaid, json)")
+
+	return cmd
+}
+
+func runGraphCommand(config GraphConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		filename, err := cmd.Flags().GetString(flagFilename)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+
+		var specs []spec.Spec
+		if filename != "" {
+			reader := compose.NewComposer(config.FS)
+			if err := reader.Read(filename, &specs); err != nil {
+				return err
+			}
+
+			for _, sp := range specs {
+				if sp.GetNamespace() == "" {
+					sp.SetNamespace(namespace)
+				}
+			}
+		} else {
+			cursor, err := config.SpecStore.Find(ctx, map[string]any{spec.KeyNamespace: namespace})
+			if err != nil {
+				return err
+			}
+
+			defer cursor.Close(ctx)
+
+			if err := cursor.All(ctx, &specs); err != nil {
+				return err
+			}
+		}
+
+		return diagram.Render(cmd.OutOrStdout(), diagram.New(specs...), output)
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/internal/diagram"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestGraphCommand_Execute(t *testing.T) {
+	specStore := driver.NewStore()
+	fs := afero.NewMemMapFs()
+
+	t.Run("File", func(t *testing.T) {
+		filename := "specs.json"
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		data, err := json.Marshal([]spec.Spec{meta})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewGraphCommand(GraphConfig{
+			SpecStore: specStore,
+			FS:        fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFilename), filename, fmt.Sprintf("--%s", flagOutput), diagram.FormatMermaid})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), meta.Name)
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewGraphCommand(GraphConfig{
+			SpecStore: specStore,
+			FS:        fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), "digraph")
+		require.Contains(t, output.String(), meta.Name)
+	})
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/scaffold"
+)
+
+// InitConfig holds the configuration for the init command.
+type InitConfig struct {
+	FS afero.Fs
+}
+
+// NewInitCommand creates a new cobra.Command for the init command.
+func NewInitCommand(config InitConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "init [dir]",
+		Short: "Create a new project with a configuration, example specs and tests",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runInitCommand(config),
+	}
+}
+
+func runInitCommand(config InitConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		names, err := scaffold.NewScaffolder(config.FS).Generate(scaffold.LayoutProject, dir, scaffold.Data{})
+		if err != nil {
+			return err
+		}
+		return created(cmd.OutOrStdout(), names)
+	}
+}
+
+func created(w io.Writer, names []string) error {
+	for _, name := range names {
+		if _, err := io.WriteString(w, name+" created\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/scaffold"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestInitCommand_Execute(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		output := new(bytes.Buffer)
+
+		cmd := NewInitCommand(InitConfig{FS: fs})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{"app"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), filepath.Join("app", ".uniflow.toml")+" created\n")
+
+		var specs []*spec.Unstructured
+		err = compose.NewComposer(fs).Read(filepath.Join("app", "specs"), &specs)
+		require.NoError(t, err)
+		require.NotEmpty(t, specs)
+
+		var tests []*spec.Unstructured
+		err = compose.NewComposer(fs).Read(filepath.Join("app", "tests"), &tests)
+		require.NoError(t, err)
+		require.NotEmpty(t, tests)
+	})
+
+	t.Run("FileExists", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := afero.WriteFile(fs, ".uniflow.toml", nil, 0644)
+		require.NoError(t, err)
+
+		cmd := NewInitCommand(InitConfig{FS: fs})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, scaffold.ErrFileExists)
+	})
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// InvokeConfig holds the configuration for the invoke command.
+type InvokeConfig struct {
+	Namespace   string
+	Environment map[string]string
+	Scheme      *scheme.Scheme
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
+	SpecStore   driver.Store
+	ValueStore  driver.Store
+	FS          afero.Fs
+}
+
+var (
+	errSymbolNotFound = errors.New("symbol not found")
+	errPortNotFound   = errors.New("port not found")
+)
+
+// NewInvokeCommand creates a new cobra.Command for the invoke command.
+func NewInvokeCommand(config InvokeConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invoke <name>",
+		Short: "Send a payload to a node in the specified namespace and print the response",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runInvokeCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), config.Namespace, "Inject the namespace for running the workflow")
+	cmd.PersistentFlags().StringToStringP(flagEnvironment, toShorthand(flagEnvironment), config.Environment, "Inject environment variables for the workflow execution")
+	cmd.PersistentFlags().String(flagPort, node.PortIn, "Set the input port to write the payload to")
+	cmd.PersistentFlags().String(flagPayload, "", "Set the payload as YAML or JSON, or @file to read it from a file")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), fmt.FormatJSON, "Set the output format (json, yaml, jsonpath=<expr>)")
+
+	return cmd
+}
+
+func runInvokeCommand(config InvokeConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		environment, err := cmd.Flags().GetStringToString(flagEnvironment)
+		if err != nil {
+			return err
+		}
+		name, err := cmd.Flags().GetString(flagPort)
+		if err != nil {
+			return err
+		}
+		data, err := cmd.Flags().GetString(flagPayload)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+
+		writer, err := fmt.NewPrinter(cmd.OutOrStdout(), output)
+		if err != nil {
+			return err
+		}
+
+		payload, err := readPayload(config.FS, data)
+		if err != nil {
+			return err
+		}
+
+		r := runtime.New(runtime.Config{
+			Namespace:   namespace,
+			Environment: environment,
+			Scheme:      config.Scheme,
+			SpecStore:   config.SpecStore,
+			ValueStore:  config.ValueStore,
+			KeyProvider: config.KeyProvider,
+			Resolver:    config.Resolver,
+			Warn:        warn(cmd),
+		})
+		defer r.Close(ctx)
+
+		if err := r.Load(ctx, nil); err != nil {
+			return err
+		}
+
+		sb := findSymbol(r.Symbols(), args[0])
+		if sb == nil {
+			return errors.WithMessagef(errSymbolNotFound, "%s/%s", namespace, args[0])
+		}
+
+		backPck, err := invoke(sb, name, payload)
+		if err != nil {
+			return err
+		}
+		if backPck == packet.None {
+			return nil
+		}
+		return writer.Write(types.InterfaceOf(backPck.Payload()))
+	}
+}
+
+// invoke writes the payload to the in-port of the symbol and waits for the back packet.
+func invoke(sb *symbol.Symbol, name string, payload types.Value) (*packet.Packet, error) {
+	in := sb.In(name)
+	if in == nil {
+		return nil, errors.WithMessagef(errPortNotFound, "%s: %s", sb.NamespacedName(), name)
+	}
+
+	proc := process.New()
+
+	out := port.NewOut()
+	defer out.Close()
+
+	out.Link(in)
+
+	backPck := packet.Send(out.Open(proc), packet.New(payload))
+
+	var err error
+	if e, ok := backPck.Payload().(types.Error); ok {
+		err = e
+	}
+
+	proc.Join()
+	proc.Exit(err)
+
+	if err != nil {
+		return nil, err
+	}
+	return backPck, nil
+}
+
+func findSymbol(symbols []*symbol.Symbol, key string) *symbol.Symbol {
+	for _, sb := range symbols {
+		if sb.Name() == key || sb.ID().String() == key {
+			return sb
+		}
+	}
+	return nil
+}
+
+func readPayload(fs afero.Fs, data string) (types.Value, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var reader io.Reader = strings.NewReader(data)
+	if filename, ok := strings.CutPrefix(data, "@"); ok {
+		file, err := fs.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	var payload any
+	if err := fmt.NewReader(reader).Read(&payload); err != nil {
+		return nil, err
+	}
+	return types.Marshal(payload)
+}
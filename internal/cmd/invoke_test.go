@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestInvokeCommand_Execute(t *testing.T) {
+	s := scheme.New()
+
+	specStore := driver.NewStore()
+	valueStore := driver.NewStore()
+
+	fs := afero.NewMemMapFs()
+
+	echo := faker.UUIDHyphenated()
+	fail := faker.UUIDHyphenated()
+
+	s.AddKnownType(echo, &spec.Meta{})
+	s.AddCodec(echo, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		}), nil
+	}))
+
+	s.AddKnownType(fail, &spec.Meta{})
+	s.AddCodec(fail, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return nil, packet.New(types.NewError(errors.New(faker.Sentence())))
+		}), nil
+	}))
+
+	t.Run("Payload", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      echo,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewInvokeCommand(InvokeConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagPayload), `{"key": "value"}`})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"key\": \"value\"\n}\n", output.String())
+	})
+
+	t.Run("PayloadFromFile", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      echo,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		filename := "payload.json"
+
+		err = afero.WriteFile(fs, filename, []byte(`[1, 2, 3]`), 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewInvokeCommand(InvokeConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name, fmt.Sprintf("--%s", flagPayload), "@" + filename, fmt.Sprintf("--%s", flagOutput), "yaml"})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, "- 1\n- 2\n- 3\n", output.String())
+	})
+
+	t.Run("ErrorPayload", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      fail,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		cmd := NewInvokeCommand(InvokeConfig{
+			Namespace:  meta.Namespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{meta.Name})
+
+		err = cmd.Execute()
+
+		var e types.Error
+		require.ErrorAs(t, err, &e)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		cmd := NewInvokeCommand(InvokeConfig{
+			Namespace:  meta.DefaultNamespace,
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+			FS:         fs,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{faker.UUIDHyphenated()})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, errSymbolNotFound)
+	})
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/testing"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+// runIsolated runs each suite matching the filter in a runtime of its own, loaded from a copy of the namespace in
+// fresh in-memory stores, and reports the results under their original names. The suites are found in the first
+// copy, and the copies run one after another, so that nodes holding fixed resources, such as listener ports, never
+// collide. Hooks belong to the runtime of each copy, so before-all and after-all hooks run once per suite. The
+// coverage of the runtimes is merged into a single report when coverage is given.
+func runIsolated(ctx context.Context, runner *testing.Runner, reporter testing.Reporter, match func(string) bool, config runtime.Config, opts testing.RunOptions, coverage *runtime.Coverage) (*runtime.CoverageReport, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, fmt.Errorf("%w after %v", testing.ErrTimeout, opts.Timeout))
+		defer cancel()
+	}
+
+	rename := testing.ReportFunc(func(ctx context.Context, result *testing.Result) error {
+		renamed := *result
+		if _, name, ok := strings.Cut(result.Name, "/"); ok {
+			renamed.Name = config.Namespace + "/" + name
+		}
+		return reporter.Report(ctx, &renamed)
+	})
+
+	runner.AddReporter(rename)
+	defer runner.RemoveReporter(rename)
+
+	report := &runtime.CoverageReport{}
+	var errs []error
+
+	var names []string
+	for i := 0; i == 0 || i < len(names); i++ {
+		namespace := fmt.Sprintf("%s-%d", config.Namespace, i)
+
+		err := func() error {
+			specStore, valueStore, ids, err := isolate(ctx, config.SpecStore, config.ValueStore, config.Namespace, namespace)
+			if err != nil {
+				return err
+			}
+
+			cfg := config
+			cfg.Namespace = namespace
+			cfg.SpecStore = specStore
+			cfg.ValueStore = valueStore
+
+			r := runtime.New(cfg)
+			defer r.Close(ctx)
+
+			if err := r.Load(ctx, nil); err != nil {
+				return err
+			}
+
+			if i == 0 {
+				for _, name := range runner.Suites() {
+					if local, ok := strings.CutPrefix(name, namespace+"/"); ok && match(config.Namespace+"/"+local) {
+						names = append(names, local)
+					}
+				}
+				if len(names) == 0 {
+					return nil
+				}
+			}
+
+			err = runner.Run(ctx, func(n string) bool { return n == namespace+"/"+names[i] }, testing.RunOptions{
+				Count:        opts.Count,
+				Retries:      opts.Retries,
+				SuiteTimeout: opts.SuiteTimeout,
+			})
+
+			if coverage != nil {
+				rp := coverage.Report(r.Symbols())
+				for _, detail := range rp.Details {
+					detail.ID = ids[detail.ID]
+					detail.Namespace = config.Namespace
+				}
+				report.Merge(rp)
+			}
+			return err
+		}()
+		errs = append(errs, err)
+	}
+
+	if flusher, ok := reporter.(testing.Flusher); ok {
+		errs = append(errs, flusher.Flush())
+	}
+	return report, errors.Join(errs...)
+}
+
+// isolate copies the specs and values of the namespace into fresh in-memory stores under another namespace.
+// Every copy gets a new ID, with references rewritten to match, so that the copies can be loaded next to
+// each other. It returns the stores along with the new IDs mapped to the original ones.
+func isolate(ctx context.Context, specStore, valueStore driver.Store, namespace, isolated string) (driver.Store, driver.Store, map[uuid.UUID]uuid.UUID, error) {
+	filter := map[string]any{meta.KeyNamespace: namespace}
+
+	cursor, err := specStore.Find(ctx, filter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var specs []*spec.Unstructured
+	if err := cursor.All(ctx, &specs); err != nil {
+		return nil, nil, nil, err
+	}
+
+	cursor, err = valueStore.Find(ctx, filter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var values []*value.Value
+	if err := cursor.All(ctx, &values); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ids := make(map[uuid.UUID]uuid.UUID)
+	var replacements []string
+	for _, m := range specs {
+		id := uuid.Must(uuid.NewV7())
+		ids[id] = m.GetID()
+		replacements = append(replacements, m.GetID().String(), id.String())
+	}
+	for _, v := range values {
+		id := uuid.Must(uuid.NewV7())
+		ids[id] = v.GetID()
+		replacements = append(replacements, v.GetID().String(), id.String())
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	docs := make([]any, 0, len(specs))
+	for _, sp := range specs {
+		data, err := json.Marshal(sp)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		copied := &spec.Unstructured{}
+		if err := json.Unmarshal([]byte(replacer.Replace(string(data))), copied); err != nil {
+			return nil, nil, nil, err
+		}
+		copied.SetNamespace(isolated)
+
+		docs = append(docs, copied)
+	}
+
+	specCopies := driver.NewStore()
+	if len(docs) > 0 {
+		if err := specCopies.Insert(ctx, docs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	docs = make([]any, 0, len(values))
+	for _, v := range values {
+		copied := *v
+		copied.SetID(uuid.FromStringOrNil(replacer.Replace(v.GetID().String())))
+		copied.SetNamespace(isolated)
+
+		docs = append(docs, &copied)
+	}
+
+	valueCopies := driver.NewStore()
+	if len(docs) > 0 {
+		if err := valueCopies.Insert(ctx, docs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return specCopies, valueCopies, ids, nil
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+// change describes how a symbol loaded by the runtime would be affected by applying resources.
+type change struct {
+	Action    string    `json:"action"`
+	ID        uuid.UUID `json:"id"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name,omitempty"`
+}
+
+const (
+	dryRunNone   = "none"
+	dryRunServer = "server"
+)
+
+const (
+	actionCreated  = "created"
+	actionUpdated  = "updated"
+	actionReloaded = "reloaded"
+	actionDeleted  = "deleted"
+)
+
+// resources groups the specs and values taking part in a plan.
+type resources struct {
+	specs  []spec.Spec
+	values []*value.Value
+}
+
+var errInvalidDryRun = errors.New("invalid dry run strategy")
+
+func planSpecs(config ApplyConfig) func(cmd *cobra.Command, specs, pruned []spec.Spec) error {
+	return func(cmd *cobra.Command, specs, pruned []spec.Spec) error {
+		return runPlan(cmd, config, resources{specs: specs}, resources{specs: pruned})
+	}
+}
+
+func planValues(config ApplyConfig) func(cmd *cobra.Command, values, pruned []*value.Value) error {
+	return func(cmd *cobra.Command, values, pruned []*value.Value) error {
+		return runPlan(cmd, config, resources{values: values}, resources{values: pruned})
+	}
+}
+
+// runPlan compares the applied and pruned resources with the stored ones and prints the symbols that would be
+// created, updated, reloaded, or deleted. Changed specs are decoded and compiled to validate them. Nothing is persisted.
+func runPlan(cmd *cobra.Command, config ApplyConfig, applied, pruned resources) error {
+	ctx := cmd.Context()
+
+	namespaces := append(applied.namespaces(), pruned.namespaces()...)
+	slices.Sort(namespaces)
+	namespaces = slices.Compact(namespaces)
+
+	var changes []change
+	for _, namespace := range namespaces {
+		c, err := plan(ctx, config, namespace, applied.in(namespace), pruned.in(namespace))
+		if err != nil {
+			return err
+		}
+		changes = append(changes, c...)
+	}
+
+	writer := fmt.NewWriter(cmd.OutOrStdout())
+	return writer.Write(changes)
+}
+
+func plan(ctx context.Context, config ApplyConfig, namespace string, applied, pruned resources) ([]change, error) {
+	specs, err := fetch[spec.Spec](ctx, config.SpecStore, map[string]any{spec.KeyNamespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	values, err := fetch[*value.Value](ctx, config.ValueStore, map[string]any{value.KeyNamespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []*value.Value
+	next := slices.Clone(values)
+	for _, val := range applied.values {
+		i := slices.IndexFunc(next, func(other *value.Value) bool { return isSame(val, other) })
+		if i < 0 {
+			next = append(next, val)
+			changed = append(changed, val)
+			continue
+		}
+		if ok, err := isEqual(next[i], val); err != nil {
+			return nil, err
+		} else if !ok {
+			next[i] = val
+			changed = append(changed, val)
+		}
+	}
+	for _, val := range pruned.values {
+		next = slices.DeleteFunc(next, func(other *value.Value) bool { return isSame(val, other) })
+		changed = append(changed, val)
+	}
+
+	var changes []change
+	var checked []spec.Spec
+	for _, sp := range applied.specs {
+		i := slices.IndexFunc(specs, func(other spec.Spec) bool { return isSame(sp, other) })
+		if i < 0 {
+			changes = append(changes, changeOf(actionCreated, sp))
+			checked = append(checked, sp)
+			continue
+		}
+
+		stored := specs[i]
+		specs = slices.Delete(specs, i, i+1)
+
+		if sp.GetID() == uuid.Nil {
+			sp.SetID(stored.GetID())
+		}
+		if ok, err := isEqual(stored, sp); err != nil {
+			return nil, err
+		} else if !ok {
+			changes = append(changes, changeOf(actionUpdated, sp))
+			checked = append(checked, sp)
+		} else if isBound(sp, changed) {
+			changes = append(changes, changeOf(actionReloaded, sp))
+			checked = append(checked, sp)
+		}
+	}
+	for _, sp := range pruned.specs {
+		specs = slices.DeleteFunc(specs, func(other spec.Spec) bool { return isSame(sp, other) })
+		changes = append(changes, changeOf(actionDeleted, sp))
+	}
+	for _, sp := range specs {
+		if isBound(sp, changed) {
+			changes = append(changes, changeOf(actionReloaded, sp))
+			checked = append(checked, sp)
+		}
+	}
+
+	for _, sp := range checked {
+		if err := check(ctx, config, sp, next); err != nil {
+			return nil, errors.WithMessage(err, meta.NamespacedName(sp))
+		}
+	}
+
+	slices.SortFunc(changes, func(x, y change) int {
+		if c := strings.Compare(x.Name, y.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(x.ID.String(), y.ID.String())
+	})
+	return changes, nil
+}
+
+// check binds the values to the spec, then decodes and compiles it as the runtime would. The compiled node is
+// closed right away, so that no ports are opened and no workflows run.
+func check(ctx context.Context, config ApplyConfig, sp spec.Spec, values []*value.Value) error {
+	if config.Scheme == nil {
+		return nil
+	}
+
+	unstructured := &spec.Unstructured{}
+	if err := spec.As(sp, unstructured); err != nil {
+		return err
+	}
+
+	var bound []*value.Value
+	for _, val := range unstructured.GetEnv() {
+		if config.Resolver == nil || val.ID != uuid.Nil || !resolver.IsURI(val.Name) {
+			continue
+		}
+		data, err := config.Resolver.Resolve(ctx, val.Name)
+		if err != nil {
+			return err
+		}
+		bound = append(bound, &value.Value{Namespace: sp.GetNamespace(), Name: val.Name, Data: data})
+	}
+	for _, val := range values {
+		if !unstructured.IsBound(val) {
+			continue
+		}
+		if val.IsSecret() {
+			clone := *val
+			if err := secret.Decrypt(ctx, config.KeyProvider, &clone); err != nil {
+				return err
+			}
+			val = &clone
+		}
+		bound = append(bound, val)
+	}
+
+	if err := unstructured.Bind(bound...); err != nil {
+		return err
+	}
+	if err := unstructured.Build(); err != nil {
+		return err
+	}
+
+	decode, err := config.Scheme.Decode(unstructured)
+	if err != nil {
+		return err
+	}
+	if decode == spec.Spec(unstructured) {
+		return nil
+	}
+
+	n, err := config.Scheme.Compile(decode)
+	if err != nil {
+		return err
+	}
+	return n.Close()
+}
+
+func fetch[T any](ctx context.Context, st driver.Store, filter map[string]any) ([]T, error) {
+	if st == nil {
+		return nil, nil
+	}
+
+	cursor, err := st.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []T
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func isSame(x, y meta.Meta) bool {
+	if x.GetID() != uuid.Nil && x.GetID() == y.GetID() {
+		return true
+	}
+	return x.GetName() != "" && x.GetNamespace() == y.GetNamespace() && x.GetName() == y.GetName()
+}
+
+func isBound(sp spec.Spec, values []*value.Value) bool {
+	if len(values) == 0 {
+		return false
+	}
+	unstructured := &spec.Unstructured{}
+	if err := spec.As(sp, unstructured); err != nil {
+		return false
+	}
+	return unstructured.IsBound(values...)
+}
+
+func changeOf(action string, sp spec.Spec) change {
+	return change{
+		Action:    action,
+		ID:        sp.GetID(),
+		Kind:      sp.GetKind(),
+		Namespace: sp.GetNamespace(),
+		Name:      sp.GetName(),
+	}
+}
+
+func (r resources) namespaces() []string {
+	var namespaces []string
+	for _, sp := range r.specs {
+		namespaces = append(namespaces, sp.GetNamespace())
+	}
+	for _, val := range r.values {
+		namespaces = append(namespaces, val.GetNamespace())
+	}
+	return namespaces
+}
+
+func (r resources) in(namespace string) resources {
+	var in resources
+	for _, sp := range r.specs {
+		if sp.GetNamespace() == namespace {
+			in.specs = append(in.specs, sp)
+		}
+	}
+	for _, val := range r.values {
+		if val.GetNamespace() == namespace {
+			in.values = append(in.values, val)
+		}
+	}
+	return in
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/siyul-park/uniflow/internal/admin"
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/internal/scaffold"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// PluginConfig holds the configuration for the plugin command.
+type PluginConfig struct {
+	Admin      string
+	AdminToken string
+	FS         afero.Fs
+}
+
+const module = "github.com/siyul-park/uniflow"
+
+var (
+	errInvalidPluginName  = errors.New("invalid plugin name")
+	errAdminNotConfigured = errors.New("admin address is not configured")
+)
+
+var pluginName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// NewPluginCommand creates a new cobra.Command for the plugin command.
+func NewPluginCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage plugins for the workflow engine",
+	}
+
+	cmd.AddCommand(newPluginNewCommand(config))
+	cmd.AddCommand(newPluginListCommand(config))
+	cmd.AddCommand(newPluginLoadCommand(config))
+	cmd.AddCommand(newPluginUnloadCommand(config))
+	cmd.AddCommand(newPluginUpgradeCommand(config))
+
+	return cmd
+}
+
+func newPluginNewCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new <name> [dir]",
+		Short: "Create a new plugin with a sample node, codec and tests",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  runPluginNewCommand(config),
+	}
+
+	cmd.PersistentFlags().String(flagModule, "", "Set the Go module path of the plugin (defaults to the name)")
+
+	return cmd
+}
+
+func runPluginNewCommand(config PluginConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if !pluginName.MatchString(name) {
+			return errors.WithMessagef(errInvalidPluginName, "%s", name)
+		}
+
+		dir := name
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		mod, err := cmd.Flags().GetString(flagModule)
+		if err != nil {
+			return err
+		}
+		if mod == "" {
+			mod = name
+		}
+
+		names, err := scaffold.NewScaffolder(config.FS).Generate(scaffold.LayoutPlugin, dir, scaffold.Data{
+			Name:      name,
+			Module:    mod,
+			Kind:      name,
+			Type:      strcase.ToCamel(name),
+			GoVersion: strings.TrimPrefix(runtime.Version(), "go"),
+			Version:   version(),
+		})
+		if err != nil {
+			return err
+		}
+		return created(cmd.OutOrStdout(), names)
+	}
+}
+
+func newPluginListCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plugins of a running engine",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, printer, err := adminClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			plugins, err := client.Plugins(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return printer.Write(plugins)
+		},
+	}
+
+	cmd.PersistentFlags().String(flagAdmin, config.Admin, "Set the address of the admin API of the running engine")
+	cmd.PersistentFlags().String(flagToken, config.AdminToken, "Set the token sent to the admin API of the running engine")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), "", "Set the output format (table, json, yaml, name, jsonpath=<expr>)")
+
+	return cmd
+}
+
+func newPluginLoadCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load <path>",
+		Short: "Load a plugin into a running engine",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, printer, err := adminClient(cmd)
+			if err != nil {
+				return err
+			}
+			req, err := loadRequest(cmd, config.FS, args[0])
+			if err != nil {
+				return err
+			}
+
+			p, err := client.Load(cmd.Context(), *req)
+			if err != nil {
+				return err
+			}
+			return printer.Write(p)
+		},
+	}
+
+	cmd.PersistentFlags().String(flagAdmin, config.Admin, "Set the address of the admin API of the running engine")
+	cmd.PersistentFlags().String(flagToken, config.AdminToken, "Set the token sent to the admin API of the running engine")
+	cmd.PersistentFlags().String(flagGoPath, "", "Set the go binary used to resolve the dependencies of a source plugin")
+	cmd.PersistentFlags().String(flagConfig, "", "Set the plugin configuration as YAML or JSON, or @file to read it from a file")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), "", "Set the output format (table, json, yaml, name, jsonpath=<expr>)")
+
+	return cmd
+}
+
+func newPluginUnloadCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unload <name>",
+		Short: "Unload a plugin from a running engine",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, _, err := adminClient(cmd)
+			if err != nil {
+				return err
+			}
+			return client.Unload(cmd.Context(), args[0])
+		},
+	}
+
+	cmd.PersistentFlags().String(flagAdmin, config.Admin, "Set the address of the admin API of the running engine")
+	cmd.PersistentFlags().String(flagToken, config.AdminToken, "Set the token sent to the admin API of the running engine")
+
+	return cmd
+}
+
+func newPluginUpgradeCommand(config PluginConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade <name> <path>",
+		Short: "Replace a plugin of a running engine with another build",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, printer, err := adminClient(cmd)
+			if err != nil {
+				return err
+			}
+			req, err := loadRequest(cmd, config.FS, args[1])
+			if err != nil {
+				return err
+			}
+
+			p, err := client.Upgrade(cmd.Context(), args[0], *req)
+			if err != nil {
+				return err
+			}
+			return printer.Write(p)
+		},
+	}
+
+	cmd.PersistentFlags().String(flagAdmin, config.Admin, "Set the address of the admin API of the running engine")
+	cmd.PersistentFlags().String(flagToken, config.AdminToken, "Set the token sent to the admin API of the running engine")
+	cmd.PersistentFlags().String(flagGoPath, "", "Set the go binary used to resolve the dependencies of a source plugin")
+	cmd.PersistentFlags().String(flagConfig, "", "Set the plugin configuration as YAML or JSON, or @file to read it from a file")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), "", "Set the output format (table, json, yaml, name, jsonpath=<expr>)")
+
+	return cmd
+}
+
+func adminClient(cmd *cobra.Command) (*admin.Client, fmt.Printer, error) {
+	address, err := cmd.Flags().GetString(flagAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+	if address == "" {
+		return nil, nil, errors.WithStack(errAdminNotConfigured)
+	}
+	token, err := cmd.Flags().GetString(flagToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var output string
+	if cmd.Flags().Lookup(flagOutput) != nil {
+		if output, err = cmd.Flags().GetString(flagOutput); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	printer, err := fmt.NewPrinter(cmd.OutOrStdout(), output)
+	if err != nil {
+		return nil, nil, err
+	}
+	return admin.NewClient(address, token), printer, nil
+}
+
+func loadRequest(cmd *cobra.Command, fs afero.Fs, path string) (*admin.LoadRequest, error) {
+	gopath, err := cmd.Flags().GetString(flagGoPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := cmd.Flags().GetString(flagConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := readPayload(fs, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return &admin.LoadRequest{Path: path, GoPath: gopath, Config: types.InterfaceOf(config)}, nil
+}
+
+// version returns the released version of the uniflow module this binary was built from, if any.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	v := info.Main.Version
+	if info.Main.Path != module {
+		v = ""
+		for _, dep := range info.Deps {
+			if dep.Path == module {
+				v = dep.Version
+			}
+		}
+	}
+
+	if !semver.IsValid(v) || semver.Build(v) != "" {
+		return ""
+	}
+	return v
+}
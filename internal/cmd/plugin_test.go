@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/internal/admin"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/plugin"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestPluginCommand_Execute(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+
+		output := new(bytes.Buffer)
+
+		cmd := NewPluginCommand(PluginConfig{FS: fs})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{"new", "hello-world", fmt.Sprintf("--%s", flagModule), "example.com/hello-world"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), filepath.Join("hello-world", "go.mod")+" created\n")
+
+		p, err := plugin.NewLoader(fs).Open(filepath.Join("hello-world", "cmd", "hello-world"))
+		require.NoError(t, err)
+
+		builder := scheme.NewBuilder()
+
+		r := plugin.NewRegistry()
+		require.NoError(t, r.Register(p))
+
+		count, err := r.Inject(builder)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		err = r.Load(ctx)
+		require.NoError(t, err)
+		defer r.Unload(ctx)
+
+		s, err := builder.Build()
+		require.NoError(t, err)
+		require.NotNil(t, s.KnownType("hello-world"))
+		require.NotNil(t, s.Codec("hello-world"))
+
+		sp, err := s.Decode(&spec.Unstructured{
+			Meta:   spec.Meta{Kind: "hello-world", Namespace: meta.DefaultNamespace},
+			Fields: map[string]any{"message": "hello"},
+		})
+		require.NoError(t, err)
+
+		n, err := s.Compile(sp)
+		require.NoError(t, err)
+		defer n.Close()
+
+		backPck, err := invoke(&symbol.Symbol{Spec: sp, Node: n}, node.PortIn, nil)
+		require.NoError(t, err)
+		require.Equal(t, types.NewString("hello"), backPck.Payload())
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		cmd := NewPluginCommand(PluginConfig{FS: afero.NewMemMapFs()})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"new", "Hello World"})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, errInvalidPluginName)
+	})
+	t.Run("Admin", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+
+		v1, err := filepath.Abs("v1")
+		require.NoError(t, err)
+		v2, err := filepath.Abs("v2")
+		require.NoError(t, err)
+
+		writeTestPlugin(t, fs, v1, "test", "v1.0.0", "foo")
+		writeTestPlugin(t, fs, v2, "test", "v2.0.0", "foo")
+
+		token := faker.UUIDHyphenated()
+
+		server := httptest.NewServer(admin.NewServer(plugin.NewManager(plugin.ManagerConfig{
+			Loader: plugin.NewLoader(fs),
+		}), token))
+		defer server.Close()
+
+		execute := func(args ...string) (string, error) {
+			output := new(bytes.Buffer)
+
+			cmd := NewPluginCommand(PluginConfig{Admin: server.URL, AdminToken: token, FS: fs})
+			cmd.SetOut(output)
+			cmd.SetErr(output)
+			cmd.SetContext(ctx)
+			cmd.SetArgs(args)
+
+			err := cmd.Execute()
+			return output.String(), err
+		}
+
+		output, err := execute("load", "v1", fmt.Sprintf("--%s", flagOutput), "json")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name": "test", "version": "v1.0.0"}`, output)
+
+		output, err = execute("upgrade", "test", "v2", fmt.Sprintf("--%s", flagConfig), `{"key": "value"}`, fmt.Sprintf("--%s", flagOutput), "json")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name": "test", "version": "v2.0.0"}`, output)
+
+		output, err = execute("list", fmt.Sprintf("--%s", flagOutput), "json")
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"name": "test", "version": "v2.0.0"}]`, output)
+
+		_, err = execute("unload", "test")
+		require.NoError(t, err)
+
+		_, err = execute("unload", "test")
+		require.ErrorContains(t, err, "plugin not found")
+	})
+
+	t.Run("NoAdmin", func(t *testing.T) {
+		cmd := NewPluginCommand(PluginConfig{FS: afero.NewMemMapFs()})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"list"})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, errAdminNotConfigured)
+	})
+}
+
+func writeTestPlugin(t *testing.T, fs afero.Fs, dir, name, version string, kinds ...string) {
+	tmpl, err := template.ParseGlob(filepath.Join("..", "..", "pkg", "plugin", "testdata", "plugin", "*.tmpl"))
+	require.NoError(t, err)
+
+	data := map[string]any{"Module": filepath.Base(dir), "Name": name, "Version": version, "Kinds": kinds}
+	for _, file := range []string{"main.go", "go.mod"} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, tmpl.ExecuteTemplate(buf, file+".tmpl", data))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, file), buf.Bytes(), 0644))
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/encoding"
+)
+
+// RenderConfig represents the configuration for the render command.
+type RenderConfig struct {
+	FS afero.Fs
+}
+
+// NewRenderCommand creates a new cobra.Command for the render command.
+func NewRenderCommand(config RenderConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render composed resources after applying includes and overlays",
+		RunE:  runRenderCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be rendered")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), fmt.FormatYAML, "Set the output format (yaml, json)")
+
+	return cmd
+}
+
+func runRenderCommand(config RenderConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		filename, err := cmd.Flags().GetString(flagFilename)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+		if filename == "" {
+			return nil
+		}
+
+		docs, err := compose.NewComposer(config.FS).Compose(filename)
+		if err != nil {
+			return err
+		}
+
+		if output != fmt.FormatYAML && output != fmt.FormatJSON {
+			return errors.WithStack(encoding.ErrUnsupportedValue)
+		}
+
+		writer, err := fmt.NewPrinter(cmd.OutOrStdout(), output)
+		if err != nil {
+			return err
+		}
+		return writer.Write(docs)
+	}
+}
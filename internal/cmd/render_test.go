@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCommand_Execute(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "base.yaml", []byte(`
+- kind: snippet
+  name: echo
+`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "overlay.yaml", []byte(`
+include: [base.yaml]
+nameSuffix: -v1
+`), 0644))
+
+	t.Run("YAML", func(t *testing.T) {
+		output := new(bytes.Buffer)
+
+		cmd := NewRenderCommand(RenderConfig{FS: fs})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFilename), "overlay.yaml"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), "name: echo-v1")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		output := new(bytes.Buffer)
+
+		cmd := NewRenderCommand(RenderConfig{FS: fs})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFilename), "overlay.yaml", fmt.Sprintf("--%s", flagOutput), "json"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), `"name": "echo-v1"`)
+	})
+}
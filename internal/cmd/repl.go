@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/language"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+// ReplConfig holds the configuration for the repl command.
+type ReplConfig struct {
+	Namespace   string
+	Environment map[string]string
+	Scheme      *scheme.Scheme
+	Language    *language.Registry
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
+	SpecStore   driver.Store
+	ValueStore  driver.Store
+	FS          afero.Fs
+}
+
+// replModel represents the state and logic for the interactive shell.
+type replModel struct {
+	ctx       context.Context
+	input     textinput.Model
+	output    string
+	history   []string
+	cursor    int
+	payload   types.Value
+	namespace string
+	runtime   *runtime.Runtime
+	config    ReplConfig
+}
+
+var _ tea.Model = (*replModel)(nil)
+
+var errUnknownCommand = errors.New("unknown command")
+
+var replCommands = []string{"get", "apply", "payload", "eval", "invoke", "symbols", "help", "quit"}
+
+const replUsage = `get <specs|values> [filter]       Find resources matching a filter such as {kind: listener}
+apply <specs|values> <document>   Apply resources written in YAML or JSON
+payload [document]                Set or show the sample payload
+eval <language> <expression>      Evaluate an expression against the sample payload
+invoke <name> [port]              Send the sample payload to a node and show the response
+symbols                           List the loaded symbols
+help                              Show this message
+quit                              Exit the shell`
+
+// NewReplCommand creates a new cobra.Command for the repl command.
+func NewReplCommand(config ReplConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repl",
+		Short: "Start an interactive shell for the specified namespace",
+		RunE:  runReplCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), config.Namespace, "Inject the namespace for running the workflow")
+	cmd.PersistentFlags().StringToStringP(flagEnvironment, toShorthand(flagEnvironment), config.Environment, "Inject environment variables for the workflow execution")
+
+	return cmd
+}
+
+func runReplCommand(config ReplConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		environment, err := cmd.Flags().GetStringToString(flagEnvironment)
+		if err != nil {
+			return err
+		}
+
+		r := runtime.New(runtime.Config{
+			Namespace:   namespace,
+			Environment: environment,
+			Scheme:      config.Scheme,
+			SpecStore:   config.SpecStore,
+			ValueStore:  config.ValueStore,
+			KeyProvider: config.KeyProvider,
+			Resolver:    config.Resolver,
+		})
+		defer r.Close(ctx)
+
+		m := newReplModel(ctx, namespace, r, config)
+		if err := r.Load(ctx, nil); err != nil {
+			m.output = (&errDebugView{err: err}).View()
+		}
+
+		program := tea.NewProgram(
+			m,
+			tea.WithContext(ctx),
+			tea.WithInput(cmd.InOrStdin()),
+			tea.WithOutput(cmd.OutOrStdout()),
+		)
+		_, err = program.Run()
+		return err
+	}
+}
+
+func newReplModel(ctx context.Context, namespace string, r *runtime.Runtime, config ReplConfig) *replModel {
+	ti := textinput.New()
+	ti.Prompt = "(uniflow) "
+	ti.Focus()
+
+	if config.FS == nil {
+		config.FS = afero.NewMemMapFs()
+	}
+
+	return &replModel{
+		ctx:       ctx,
+		input:     ti,
+		namespace: namespace,
+		runtime:   r,
+		config:    config,
+	}
+}
+
+// View renders the prompt and the output of the last command.
+func (m *replModel) View() string {
+	message := m.input.View() + "\n"
+	if m.output != "" {
+		message += m.output + "\n"
+	}
+	return message
+}
+
+// Init initializes the text input model.
+func (m *replModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update processes user inputs.
+func (m *replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyCtrlD, tea.KeyEsc:
+			return m, tea.Quit
+		case tea.KeyUp:
+			if m.cursor > 0 {
+				m.cursor--
+				m.input.SetValue(m.history[m.cursor])
+				m.input.CursorEnd()
+			}
+			return m, nil
+		case tea.KeyDown:
+			if m.cursor < len(m.history) {
+				m.cursor++
+			}
+			if m.cursor < len(m.history) {
+				m.input.SetValue(m.history[m.cursor])
+			} else {
+				m.input.SetValue("")
+			}
+			m.input.CursorEnd()
+			return m, nil
+		case tea.KeyTab:
+			m.complete()
+			return m, nil
+		case tea.KeyEnter:
+			line := strings.TrimSpace(m.input.Value())
+			if line == "" {
+				return m, nil
+			}
+
+			m.input.SetValue("")
+			if len(m.history) == 0 || m.history[len(m.history)-1] != line {
+				m.history = append(m.history, line)
+			}
+			m.cursor = len(m.history)
+
+			name, _, _ := strings.Cut(line, " ")
+			if name == "quit" || name == "q" || name == "exit" {
+				return m, tea.Quit
+			}
+
+			output, err := m.exec(line)
+			if err != nil {
+				output = (&errDebugView{err: err}).View()
+			}
+			m.output = output
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *replModel) exec(line string) (string, error) {
+	name, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+
+	switch name {
+	case "help", "h":
+		return replUsage, nil
+	case "get":
+		resource, filter, _ := strings.Cut(rest, " ")
+		switch resource {
+		case specs:
+			return replGet[*spec.Unstructured](m.ctx, m.config.SpecStore, m.namespace, filter)
+		case values:
+			return replGet[*value.Value](m.ctx, m.config.ValueStore, m.namespace, filter)
+		}
+		return "", errors.WithMessagef(errUnknownCommand, "get %s", resource)
+	case "apply":
+		resource, doc, _ := strings.Cut(rest, " ")
+
+		var output string
+		var err error
+		switch resource {
+		case specs:
+			output, err = replApply[spec.Spec](m.ctx, m.config.SpecStore, m.namespace, doc, nil)
+		case values:
+			output, err = replApply[*value.Value](m.ctx, m.config.ValueStore, m.namespace, doc, func(val *value.Value) error {
+				return secret.Encrypt(m.ctx, m.config.KeyProvider, val)
+			})
+		default:
+			return "", errors.WithMessagef(errUnknownCommand, "apply %s", resource)
+		}
+		if err != nil {
+			return "", err
+		}
+		if err := m.runtime.Load(m.ctx, nil); err != nil {
+			return output, err
+		}
+		return output, nil
+	case "payload":
+		if rest != "" {
+			payload, err := readPayload(m.config.FS, rest)
+			if err != nil {
+				return "", err
+			}
+			m.payload = payload
+		}
+		return marshal(types.InterfaceOf(m.payload))
+	case "eval":
+		lang, expr, _ := strings.Cut(rest, " ")
+		if m.config.Language == nil {
+			return "", errors.WithStack(language.ErrNotFound)
+		}
+
+		compiler, err := m.config.Language.Lookup(lang)
+		if err != nil {
+			return "", err
+		}
+		program, err := compiler.Compile(expr)
+		if err != nil {
+			return "", err
+		}
+		result, err := program.Run(m.ctx, types.InterfaceOf(m.payload))
+		if err != nil {
+			return "", err
+		}
+		return marshal(result)
+	case "invoke":
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return "", errors.WithStack(errSymbolNotFound)
+		}
+
+		sb := findSymbol(m.runtime.Symbols(), fields[0])
+		if sb == nil {
+			return "", errors.WithMessagef(errSymbolNotFound, "%s/%s", m.namespace, fields[0])
+		}
+
+		name := node.PortIn
+		if len(fields) > 1 {
+			name = fields[1]
+		}
+
+		backPck, err := invoke(sb, name, m.payload)
+		if err != nil {
+			return "", err
+		}
+		if backPck == packet.None {
+			return "", nil
+		}
+		return marshal(types.InterfaceOf(backPck.Payload()))
+	case "symbols":
+		symbols := m.runtime.Symbols()
+		slices.SortFunc(symbols, func(x, y *symbol.Symbol) int {
+			return strings.Compare(x.NamespacedName(), y.NamespacedName())
+		})
+		return (&symbolsDebugView{symbols: symbols}).View(), nil
+	}
+	return "", errors.WithMessage(errUnknownCommand, name)
+}
+
+// complete completes the word under the cursor with commands, kinds, names, or port names.
+func (m *replModel) complete() {
+	line := m.input.Value()
+
+	start := strings.LastIndexAny(line, " ,:{}[]") + 1
+	word := line[start:]
+	fields := strings.Fields(line[:start])
+
+	var candidates []string
+	switch {
+	case len(fields) == 0:
+		candidates = replCommands
+	case (fields[0] == "get" || fields[0] == "apply") && len(fields) == 1:
+		candidates = []string{specs, values}
+	case fields[0] == "invoke" && len(fields) == 1:
+		for _, sb := range m.runtime.Symbols() {
+			candidates = append(candidates, sb.Name())
+		}
+	case fields[0] == "invoke" && len(fields) == 2:
+		if sb := findSymbol(m.runtime.Symbols(), fields[1]); sb != nil {
+			for _, name := range []string{node.PortInit, node.PortIO, node.PortIn} {
+				if sb.In(name) != nil {
+					candidates = append(candidates, name)
+				}
+			}
+			for name := range sb.Ins() {
+				candidates = append(candidates, name)
+			}
+			if schemas := sb.Schemas(); schemas != nil {
+				for name := range schemas.Ins {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+	case fields[0] == "get" || fields[0] == "apply":
+		if m.config.Scheme != nil {
+			candidates = append(candidates, m.config.Scheme.Kinds()...)
+		}
+		for _, sb := range m.runtime.Symbols() {
+			candidates = append(candidates, sb.Name())
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if candidate != "" && strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	slices.Sort(matches)
+	matches = slices.Compact(matches)
+
+	if len(matches) == 0 {
+		return
+	}
+
+	prefix := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(matches) == 1 {
+		prefix += " "
+		m.output = ""
+	} else {
+		m.output = strings.Join(matches, "  ")
+	}
+
+	m.input.SetValue(line[:start] + prefix)
+	m.input.CursorEnd()
+}
+
+func replGet[T meta.Meta](ctx context.Context, store driver.Store, namespace, expr string) (string, error) {
+	filter := map[string]any{}
+	if expr != "" {
+		if err := fmt2.NewReader(strings.NewReader(expr)).Read(&filter); err != nil {
+			return "", err
+		}
+	}
+	if _, ok := filter[meta.KeyNamespace]; !ok {
+		filter[meta.KeyNamespace] = namespace
+	}
+
+	cursor, err := store.Find(ctx, filter)
+	if err != nil {
+		return "", err
+	}
+	defer cursor.Close(ctx)
+
+	var metas []T
+	if err := cursor.All(ctx, &metas); err != nil {
+		return "", err
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := fmt2.NewWriter(buffer).Write(redact(metas)); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func replApply[T meta.Meta](ctx context.Context, store driver.Store, namespace, expr string, admit func(T) error) (string, error) {
+	var doc any
+	if err := fmt2.NewReader(strings.NewReader(expr)).Read(&doc); err != nil {
+		return "", err
+	}
+	if _, ok := doc.([]any); !ok {
+		doc = []any{doc}
+	}
+
+	encoded, err := types.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var metas []T
+	if err := types.Unmarshal(encoded, &metas); err != nil {
+		return "", err
+	}
+
+	for _, m := range metas {
+		if m.GetNamespace() == "" {
+			m.SetNamespace(namespace)
+		}
+		if admit != nil {
+			if err := admit(m); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := upsert(ctx, store, metas); err != nil {
+		return "", err
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := fmt2.NewWriter(buffer).Write(redact(metas)); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func marshal(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
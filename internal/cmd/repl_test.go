@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/language"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+func TestReplModel_Update(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Meta{})
+	s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		}), nil
+	}))
+
+	l := language.NewRegistry()
+	err := l.Register("echo", language.CompileFunc(func(code string) (language.Program, error) {
+		return language.RunFunc(func(_ context.Context, args ...any) (any, error) {
+			return map[string]any{"code": code, "payload": args[0]}, nil
+		}), nil
+	}))
+	require.NoError(t, err)
+
+	newModel := func(ctx context.Context) *replModel {
+		config := ReplConfig{
+			Scheme:     s,
+			Language:   l,
+			SpecStore:  driver.NewStore(),
+			ValueStore: driver.NewStore(),
+		}
+
+		r := runtime.New(runtime.Config{
+			Namespace:  meta.DefaultNamespace,
+			Scheme:     config.Scheme,
+			SpecStore:  config.SpecStore,
+			ValueStore: config.ValueStore,
+		})
+		return newReplModel(ctx, meta.DefaultNamespace, r, config)
+	}
+
+	enter := func(m *replModel, line string) {
+		m.input.SetValue(line)
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	t.Run("apply and get", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		name := faker.UUIDHyphenated()
+
+		enter(m, fmt.Sprintf("apply specs {kind: %s, name: %s}", kind, name))
+		require.Contains(t, m.output, name)
+		require.Len(t, m.runtime.Symbols(), 1)
+
+		enter(m, fmt.Sprintf("get specs {name: %s}", name))
+		require.Contains(t, m.output, name)
+
+		enter(m, "get specs {name: unknown}")
+		require.NotContains(t, m.output, name)
+
+		enter(m, fmt.Sprintf("apply values {name: %s, data: foo}", name))
+		require.Contains(t, m.output, name)
+
+		cursor, err := m.config.ValueStore.Find(ctx, map[string]any{value.KeyName: name})
+		require.NoError(t, err)
+		require.True(t, cursor.Next(ctx))
+	})
+
+	t.Run("payload and eval", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		enter(m, "payload {key: value}")
+		require.Contains(t, m.output, `"key": "value"`)
+
+		enter(m, "eval echo $.key")
+		require.Contains(t, m.output, `"code": "$.key"`)
+		require.Contains(t, m.output, `"key": "value"`)
+
+		enter(m, "eval unknown $.key")
+		require.Contains(t, m.output, "Error:")
+	})
+
+	t.Run("invoke", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := m.config.SpecStore.Insert(ctx, []any{sp})
+		require.NoError(t, err)
+		err = m.runtime.Load(ctx, nil)
+		require.NoError(t, err)
+
+		enter(m, "payload [1, 2]")
+		enter(m, fmt.Sprintf("invoke %s %s", sp.Name, node.PortIn))
+		require.Equal(t, "[\n    1,\n    2\n]", m.output)
+
+		enter(m, fmt.Sprintf("invoke %s unknown", sp.Name))
+		require.Contains(t, m.output, errPortNotFound.Error())
+	})
+
+	t.Run("history", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		enter(m, "help")
+		enter(m, "symbols")
+
+		m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		require.Equal(t, "symbols", m.input.Value())
+
+		m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		require.Equal(t, "help", m.input.Value())
+
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		require.Equal(t, "symbols", m.input.Value())
+
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		require.Equal(t, "", m.input.Value())
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      "router",
+		}
+
+		err := m.config.SpecStore.Insert(ctx, []any{sp})
+		require.NoError(t, err)
+		err = m.runtime.Load(ctx, nil)
+		require.NoError(t, err)
+
+		m.input.SetValue("inv")
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		require.Equal(t, "invoke ", m.input.Value())
+
+		m.input.SetValue("invoke ro")
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		require.Equal(t, "invoke router ", m.input.Value())
+
+		m.input.SetValue("invoke router i")
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		require.Equal(t, "invoke router in ", m.input.Value())
+
+		m.input.SetValue("get specs {kind: " + kind[:4])
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		require.Equal(t, "get specs {kind: "+kind+" ", m.input.Value())
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		m := newModel(ctx)
+		defer m.runtime.Close(ctx)
+
+		m.input.SetValue("quit")
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		require.IsType(t, tea.QuitMsg{}, cmd())
+	})
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// selector matches resources by a comma-separated list of requirements such as team=payments, env!=dev, owner or !legacy.
+type selector []requirement
+
+type requirement struct {
+	key    string
+	value  string
+	equal  bool
+	exists bool
+	negate bool
+}
+
+var errInvalidSelector = errors.New("invalid selector")
+
+func parseSelector(expr string) (selector, error) {
+	var s selector
+	for _, token := range strings.Split(expr, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		var r requirement
+		switch {
+		case strings.Contains(token, "!="):
+			r.key, r.value, _ = strings.Cut(token, "!=")
+			r.equal, r.negate = true, true
+		case strings.Contains(token, "=="):
+			r.key, r.value, _ = strings.Cut(token, "==")
+			r.equal = true
+		case strings.Contains(token, "="):
+			r.key, r.value, _ = strings.Cut(token, "=")
+			r.equal = true
+		case strings.HasPrefix(token, "!"):
+			r.key = token[1:]
+			r.exists, r.negate = true, true
+		default:
+			r.key = token
+			r.exists = true
+		}
+
+		r.key = strings.TrimSpace(r.key)
+		r.value = strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, errors.WithMessagef(errInvalidSelector, "%q", expr)
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+// match reports whether the lookup satisfies every requirement of the selector.
+func (s selector) match(lookup func(key string) (string, bool)) bool {
+	for _, r := range s {
+		val, ok := lookup(r.key)
+		switch {
+		case r.exists && ok == r.negate:
+			return false
+		case r.equal && (ok && val == r.value) == r.negate:
+			return false
+		}
+	}
+	return true
+}
+
+// matchLabels reports whether the annotations of the resource satisfy the selector.
+func (s selector) matchLabels(m meta.Meta) bool {
+	annotations := m.GetAnnotations()
+	return s.match(func(key string) (string, bool) {
+		val, ok := annotations[key]
+		return val, ok
+	})
+}
+
+// matchFields reports whether the fields of the resource, addressed by dot-separated paths, satisfy the selector.
+func (s selector) matchFields(m meta.Meta) (bool, error) {
+	if len(s) == 0 {
+		return true, nil
+	}
+
+	doc, err := types.Marshal(m)
+	if err != nil {
+		return false, err
+	}
+	fields := types.InterfaceOf(doc)
+
+	return s.match(func(key string) (string, bool) {
+		var cur any = fields
+		for _, part := range strings.Split(key, ".") {
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return "", false
+			}
+			if cur, ok = m[part]; !ok {
+				return "", false
+			}
+		}
+		if cur == nil {
+			return "", false
+		}
+		return fmt.Sprint(cur), true
+	}), nil
+}
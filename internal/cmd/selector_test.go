@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestParseSelector(t *testing.T) {
+	s, err := parseSelector("team=payments, env!=dev,owner,!legacy")
+	require.NoError(t, err)
+	require.Len(t, s, 4)
+
+	_, err = parseSelector("=payments")
+	require.ErrorIs(t, err, errInvalidSelector)
+}
+
+func TestSelector_MatchLabels(t *testing.T) {
+	sp := &spec.Meta{
+		ID:          uuid.Must(uuid.NewV7()),
+		Kind:        faker.UUIDHyphenated(),
+		Namespace:   meta.DefaultNamespace,
+		Annotations: map[string]string{"team": "payments", "owner": "alice"},
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{expr: "", expected: true},
+		{expr: "team=payments", expected: true},
+		{expr: "team==payments", expected: true},
+		{expr: "team=search", expected: false},
+		{expr: "team!=search", expected: true},
+		{expr: "team!=payments", expected: false},
+		{expr: "owner", expected: true},
+		{expr: "!owner", expected: false},
+		{expr: "!legacy", expected: true},
+		{expr: "team=payments,legacy", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := parseSelector(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, s.matchLabels(sp))
+		})
+	}
+}
+
+func TestSelector_MatchFields(t *testing.T) {
+	sp := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      "listener",
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Ports: map[string][]spec.Port{
+			"out": {{Name: "router", Port: "in"}},
+		},
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{expr: "kind=listener", expected: true},
+		{expr: "kind!=listener", expected: false},
+		{expr: "kind=listener,namespace=" + meta.DefaultNamespace, expected: true},
+		{expr: "ports", expected: true},
+		{expr: "schemas", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := parseSelector(tt.expr)
+			require.NoError(t, err)
+
+			ok, err := s.matchFields(sp)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ok)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,10 +13,14 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/siyul-park/uniflow/internal/admin"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/hook"
+	"github.com/siyul-park/uniflow/pkg/plugin"
+	"github.com/siyul-park/uniflow/pkg/resolver"
 	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
@@ -24,7 +31,12 @@ type StartConfig struct {
 	Environment map[string]string
 	Agent       *runtime.Agent
 	Scheme      *scheme.Scheme
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
 	Hook        *hook.Hook
+	Plugins     *plugin.Manager
+	Admin       string
+	AdminToken  string
 	SpecStore   driver.Store
 	ValueStore  driver.Store
 	FS          afero.Fs
@@ -41,24 +53,52 @@ func NewStartCommand(config StartConfig) *cobra.Command {
 	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), config.Namespace, "Inject the namespace for running the workflow")
 	cmd.PersistentFlags().String(flagFromSpecs, "", "Specify the file path containing workflow specifications")
 	cmd.PersistentFlags().String(flagFromValues, "", "Specify the file path containing values for the workflow")
+	cmd.PersistentFlags().Bool(flagWatch, false, "Watch the spec and value files and apply their changes while running")
 	cmd.PersistentFlags().Bool(flagDebug, false, "Enable debug mode for detailed output during execution")
 	cmd.PersistentFlags().StringToStringP(flagEnvironment, toShorthand(flagEnvironment), config.Environment, "Inject environment variables for the workflow execution")
+	cmd.PersistentFlags().Bool(flagStrictSchema, false, "Reject nodes whose port schemas are incompatible with their links")
+	cmd.PersistentFlags().Bool(flagValidateSchema, false, "Validate packets against the schemas of the input ports receiving them")
+	cmd.PersistentFlags().String(flagAdmin, config.Admin, "Serve the admin API on the given address to load, unload and upgrade plugins")
+	cmd.PersistentFlags().String(flagToken, config.AdminToken, "Require the token on admin API requests. Without it, the admin API listens only on loopback addresses")
 
 	return cmd
 }
 
 // runStartCommand runs the start command with the given configuration.
 func runStartCommand(config StartConfig) func(cmd *cobra.Command, args []string) error {
-	applySpecs := runApplyCommand[spec.Spec](config.SpecStore, config.FS, alias(flagFilename, flagFromSpecs))
-	applyValues := runApplyCommand[*value.Value](config.ValueStore, config.FS, alias(flagFilename, flagFromValues))
+	ownSpecs := own[spec.Spec](config.SpecStore, alias(flagFilename, flagFromSpecs), alias(flagPrune, flagWatch))
+	ownValues := own[*value.Value](config.ValueStore, alias(flagFilename, flagFromValues), alias(flagPrune, flagWatch))
+
+	applySpecs := runApplyCommand(config.SpecStore, config.FS, applyOptions[spec.Spec]{
+		own:   ownSpecs,
+		alias: []func(map[string]string){alias(flagFilename, flagFromSpecs)},
+	})
+	applyValues := runApplyCommand(config.ValueStore, config.FS, applyOptions[*value.Value]{
+		admit: sealValues(config.KeyProvider),
+		own:   ownValues,
+		alias: []func(map[string]string){alias(flagFilename, flagFromValues)},
+	})
 
 	return func(cmd *cobra.Command, _ []string) error {
-		ctx := cmd.Context()
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
 
 		namespace, err := cmd.Flags().GetString(flagNamespace)
 		if err != nil {
 			return err
 		}
+		fromSpecs, err := cmd.Flags().GetString(flagFromSpecs)
+		if err != nil {
+			return err
+		}
+		fromValues, err := cmd.Flags().GetString(flagFromValues)
+		if err != nil {
+			return err
+		}
+		watching, err := cmd.Flags().GetBool(flagWatch)
+		if err != nil {
+			return err
+		}
 		enableDebug, err := cmd.Flags().GetBool(flagDebug)
 		if err != nil {
 			return err
@@ -67,6 +107,22 @@ func runStartCommand(config StartConfig) func(cmd *cobra.Command, args []string)
 		if err != nil {
 			return err
 		}
+		strictSchema, err := cmd.Flags().GetBool(flagStrictSchema)
+		if err != nil {
+			return err
+		}
+		validateSchema, err := cmd.Flags().GetBool(flagValidateSchema)
+		if err != nil {
+			return err
+		}
+		address, err := cmd.Flags().GetString(flagAdmin)
+		if err != nil {
+			return err
+		}
+		token, err := cmd.Flags().GetString(flagToken)
+		if err != nil {
+			return err
+		}
 
 		out := cmd.OutOrStdout()
 		if out == os.Stdout {
@@ -84,21 +140,76 @@ func runStartCommand(config StartConfig) func(cmd *cobra.Command, args []string)
 
 		cmd.SetOut(out)
 
-		h := config.Hook
-		if h == nil {
-			h = hook.New()
+		// Plugin hooks are nested so that they can be swapped when plugins change.
+		h := hook.New()
+		current := config.Hook
+		if current != nil {
+			h.AddLoadHook(current)
+			h.AddUnloadHook(current)
 		}
 
 		r := runtime.New(runtime.Config{
-			Namespace:   namespace,
-			Environment: environment,
-			Scheme:      config.Scheme,
-			Hook:        h,
-			SpecStore:   config.SpecStore,
-			ValueStore:  config.ValueStore,
+			Namespace:      namespace,
+			Environment:    environment,
+			Scheme:         config.Scheme,
+			Hook:           h,
+			SpecStore:      config.SpecStore,
+			ValueStore:     config.ValueStore,
+			KeyProvider:    config.KeyProvider,
+			Resolver:       config.Resolver,
+			StrictSchema:   strictSchema,
+			ValidateSchema: validateSchema,
+			Warn:           warn(cmd),
 		})
 		defer r.Close(ctx)
 
+		if err := r.Watch(ctx); err != nil {
+			return err
+		}
+
+		if config.Plugins != nil {
+			listener := plugin.ListenerFunc(func(ctx context.Context, change plugin.Change) {
+				if current != nil {
+					h.RemoveLoadHook(current)
+					h.RemoveUnloadHook(current)
+				}
+				current = change.Hook
+				h.AddLoadHook(current)
+				h.AddUnloadHook(current)
+
+				if err := r.Recompile(ctx, change.Scheme, change.Kinds...); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), err)
+				}
+			})
+
+			config.Plugins.AddListener(listener)
+			defer config.Plugins.RemoveListener(listener)
+
+			if address != "" {
+				listener, err := admin.Listen(address, token)
+				if err != nil {
+					return err
+				}
+
+				server := &http.Server{Handler: admin.NewServer(config.Plugins, token)}
+				defer server.Close()
+
+				go server.Serve(listener)
+			}
+		}
+
+		reload := func() {
+			cmd.SetOut(io.Discard)
+			defer cmd.SetOut(out)
+
+			if err := applySpecs(cmd); err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), err)
+			}
+			if err := applyValues(cmd); err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), err)
+			}
+		}
+
 		sigs := make(chan os.Signal, 1)
 		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 
@@ -127,9 +238,10 @@ func runStartCommand(config StartConfig) func(cmd *cobra.Command, args []string)
 				d.Kill()
 			}()
 
-			if err := r.Watch(ctx); err != nil {
-				return err
+			if watching {
+				watch(ctx, config.FS, []string{fromSpecs, fromValues}, reload)
 			}
+
 			_ = r.Load(ctx, nil)
 			go r.Reconcile(ctx)
 			return d.Run()
@@ -140,10 +252,13 @@ func runStartCommand(config StartConfig) func(cmd *cobra.Command, args []string)
 			r.Close(ctx)
 		}()
 
-		if err := r.Watch(ctx); err != nil {
-			return err
+		if watching {
+			watch(ctx, config.FS, []string{fromSpecs, fromValues}, reload)
+		}
+
+		if err := r.Load(ctx, nil); err != nil {
+			fmt.Fprintln(cmd.ErrOrStderr(), err)
 		}
-		_ = r.Load(ctx, nil)
 		return r.Reconcile(ctx)
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,10 +16,12 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
+	"github.com/siyul-park/uniflow/internal/admin"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/hook"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/plugin"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/symbol"
@@ -237,4 +241,169 @@ func TestStartCommand_Execute(t *testing.T) {
 
 		require.Eventually(t, func() bool { return count.Load() == 1 }, time.Second, 10*time.Millisecond)
 	})
+
+	t.Run(flagWatch, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+		defer cancel()
+
+		specStore := driver.NewStore()
+
+		filename := "watch.json"
+
+		changed := &spec.Meta{
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		unchanged := &spec.Meta{
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		removed := &spec.Meta{
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		data, _ := json.Marshal([]any{changed, unchanged, removed})
+		err := afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		h := hook.New()
+
+		var loads sync.Map
+		h.AddLoadHook(symbol.LoadFunc(func(sb *symbol.Symbol) error {
+			count, _ := loads.LoadOrStore(sb.Name(), new(atomic.Int32))
+			count.(*atomic.Int32).Add(1)
+			return nil
+		}))
+
+		loaded := func(name string) int32 {
+			count, ok := loads.Load(name)
+			if !ok {
+				return 0
+			}
+			return count.(*atomic.Int32).Load()
+		}
+
+		output := new(bytes.Buffer)
+
+		cmd := NewStartCommand(StartConfig{
+			Scheme:     s,
+			Hook:       h,
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFromSpecs), filename, fmt.Sprintf("--%s", flagWatch)})
+
+		go func() {
+			_ = cmd.Execute()
+		}()
+
+		require.Eventually(t, func() bool {
+			return loaded(changed.Name) == 1 && loaded(unchanged.Name) == 1 && loaded(removed.Name) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		changed.Annotations = map[string]string{"version": "2"}
+
+		data, _ = json.Marshal([]any{changed, unchanged})
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			cursor, err := specStore.Find(ctx, map[string]any{spec.KeyName: removed.Name})
+			if err != nil {
+				return false
+			}
+			defer cursor.Close(ctx)
+			return !cursor.Next(ctx) && loaded(changed.Name) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, int32(1), loaded(unchanged.Name))
+		require.Equal(t, int32(1), loaded(removed.Name))
+	})
+	t.Run(flagAdmin, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+		defer cancel()
+
+		specStore := driver.NewStore()
+
+		kind := faker.UUIDHyphenated()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		fs := afero.NewMemMapFs()
+		writeTestPlugin(t, fs, "plugin", "test", "v1.0.0", kind)
+
+		var loaded atomic.Bool
+		hookBuilder := hook.NewBuilder(hook.RegisterFunc(func(h *hook.Hook) error {
+			h.AddLoadHook(symbol.LoadFunc(func(sb *symbol.Symbol) error {
+				if sb.ID() == meta.GetID() && sb.Node != nil {
+					loaded.Store(true)
+				}
+				return nil
+			}))
+			return nil
+		}))
+		schemeBuilder := scheme.NewBuilder()
+
+		s, err := schemeBuilder.Build()
+		require.NoError(t, err)
+		h, err := hookBuilder.Build()
+		require.NoError(t, err)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		_ = listener.Close()
+
+		output := new(bytes.Buffer)
+
+		cmd := NewStartCommand(StartConfig{
+			Scheme: s,
+			Hook:   h,
+			Plugins: plugin.NewManager(plugin.ManagerConfig{
+				Loader:        plugin.NewLoader(fs),
+				SchemeBuilder: schemeBuilder,
+				HookBuilder:   hookBuilder,
+				Dependencies:  []any{schemeBuilder},
+			}),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagAdmin), address})
+
+		go func() {
+			_ = cmd.Execute()
+		}()
+
+		client := admin.NewClient(address, "")
+
+		require.Eventually(t, func() bool {
+			_, err := client.Load(ctx, admin.LoadRequest{Path: "plugin"})
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Eventually(t, loaded.Load, 5*time.Second, 10*time.Millisecond)
+
+		err = client.Unload(ctx, "test")
+		require.NoError(t, err)
+	})
 }
@@ -3,15 +3,19 @@ package cmd
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/hook"
+	"github.com/siyul-park/uniflow/pkg/resolver"
 	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/value"
@@ -22,13 +26,30 @@ type TestConfig struct {
 	Namespace   string
 	Environment map[string]string
 	Runner      *testing.Runner
+	Snapshot    *testing.Snapshot
+	Corpus      *testing.Corpus
+	Agent       *runtime.Agent
 	Scheme      *scheme.Scheme
+	KeyProvider secret.KeyProvider
+	Resolver    *resolver.Registry
 	Hook        *hook.Hook
 	SpecStore   driver.Store
 	ValueStore  driver.Store
 	FS          afero.Fs
 }
 
+const (
+	reporterText  = "text"
+	reporterJUnit = "junit"
+	reporterTAP   = "tap"
+	reporterJSON  = "json"
+)
+
+var (
+	errUnsupportedReporter    = errors.New("unsupported reporter")
+	errCoverageBelowThreshold = errors.New("coverage below threshold")
+)
+
 // NewTestCommand creates a new cobra.Command for the start command.
 func NewTestCommand(config TestConfig) *cobra.Command {
 	cmd := &cobra.Command{
@@ -42,14 +63,34 @@ func NewTestCommand(config TestConfig) *cobra.Command {
 	cmd.PersistentFlags().String(flagFromSpecs, "", "Specify the file path containing workflow specifications")
 	cmd.PersistentFlags().String(flagFromValues, "", "Specify the file path containing values for the workflow")
 	cmd.PersistentFlags().StringToStringP(flagEnvironment, toShorthand(flagEnvironment), config.Environment, "Inject environment variables for the workflow execution")
+	cmd.PersistentFlags().Bool(flagStrictSchema, false, "Reject nodes whose port schemas are incompatible with their links")
+	cmd.PersistentFlags().Bool(flagValidateSchema, false, "Validate packets against the schemas of the input ports receiving them")
+	cmd.PersistentFlags().StringP(flagReporter, toShorthand(flagReporter), reporterText, "Set the report format (text, junit, tap, json)")
+	cmd.PersistentFlags().StringP(flagOutput, toShorthand(flagOutput), "", "Write the report to the given file instead of stdout")
+	cmd.PersistentFlags().String(flagCoverage, "", "Collect flow coverage and write it to the given file as JSON")
+	cmd.PersistentFlags().Float64(flagCoverageThreshold, 0, "Fail when the flow coverage percentage is below the threshold")
+	cmd.PersistentFlags().Int(flagConcurrency, 0, "Limit the number of test suites running at once (0 for no limit)")
+	cmd.PersistentFlags().Bool(flagIsolate, false, "Run each test suite one after another in its own namespace with fresh in-memory stores")
+	cmd.PersistentFlags().Int(flagCount, 1, "Run each test suite the given number of times")
+	cmd.PersistentFlags().Int(flagRetries, 0, "Retry a failing test suite up to the given number of times, reporting it as flaky if a retry passes")
+	cmd.PersistentFlags().Duration(flagTimeout, 0, "Fail the tests still running after the given duration (0 for no limit)")
+	cmd.PersistentFlags().Duration(flagSuiteTimeout, 0, "Fail a test suite that runs longer than the given duration (0 for no limit)")
+	cmd.PersistentFlags().Bool(flagUpdateSnapshots, false, "Rewrite the golden files of snapshot assertions with the current payloads")
+
+	cmd.MarkFlagsMutuallyExclusive(flagConcurrency, flagIsolate)
 
 	return cmd
 }
 
 // runTestCommand runs the start command with the given configuration.
 func runTestCommand(config TestConfig) func(cmd *cobra.Command, args []string) error {
-	applySpecs := runApplyCommand[spec.Spec](config.SpecStore, config.FS, alias(flagFilename, flagFromSpecs))
-	applyValues := runApplyCommand[*value.Value](config.ValueStore, config.FS, alias(flagFilename, flagFromValues))
+	applySpecs := runApplyCommand(config.SpecStore, config.FS, applyOptions[spec.Spec]{
+		alias: []func(map[string]string){alias(flagFilename, flagFromSpecs)},
+	})
+	applyValues := runApplyCommand(config.ValueStore, config.FS, applyOptions[*value.Value]{
+		admit: sealValues(config.KeyProvider),
+		alias: []func(map[string]string){alias(flagFilename, flagFromValues)},
+	})
 
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
@@ -62,6 +103,62 @@ func runTestCommand(config TestConfig) func(cmd *cobra.Command, args []string) e
 		if err != nil {
 			return err
 		}
+		strictSchema, err := cmd.Flags().GetBool(flagStrictSchema)
+		if err != nil {
+			return err
+		}
+		validateSchema, err := cmd.Flags().GetBool(flagValidateSchema)
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString(flagReporter)
+		if err != nil {
+			return err
+		}
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			return err
+		}
+		coverageFile, err := cmd.Flags().GetString(flagCoverage)
+		if err != nil {
+			return err
+		}
+		threshold, err := cmd.Flags().GetFloat64(flagCoverageThreshold)
+		if err != nil {
+			return err
+		}
+		concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+		if err != nil {
+			return err
+		}
+		isolated, err := cmd.Flags().GetBool(flagIsolate)
+		if err != nil {
+			return err
+		}
+		count, err := cmd.Flags().GetInt(flagCount)
+		if err != nil {
+			return err
+		}
+		retries, err := cmd.Flags().GetInt(flagRetries)
+		if err != nil {
+			return err
+		}
+		timeout, err := cmd.Flags().GetDuration(flagTimeout)
+		if err != nil {
+			return err
+		}
+		suiteTimeout, err := cmd.Flags().GetDuration(flagSuiteTimeout)
+		if err != nil {
+			return err
+		}
+		updateSnapshots, err := cmd.Flags().GetBool(flagUpdateSnapshots)
+		if err != nil {
+			return err
+		}
+		fromSpecs, err := cmd.Flags().GetString(flagFromSpecs)
+		if err != nil {
+			return err
+		}
 
 		match := func(string) bool { return true }
 		if len(args) > 0 {
@@ -74,10 +171,30 @@ func runTestCommand(config TestConfig) func(cmd *cobra.Command, args []string) e
 			}
 		}
 
-		reporter := testing.NewTextReporter(cmd.OutOrStdout())
+		dir := snapshotDir(config.FS, fromSpecs)
+		if config.Snapshot != nil {
+			config.Snapshot.SetDir(dir)
+			config.Snapshot.SetUpdate(updateSnapshots)
+			defer config.Snapshot.SetUpdate(false)
+		}
+		if config.Corpus != nil {
+			config.Corpus.SetDir(dir)
+		}
+
+		w := cmd.OutOrStdout()
+		if output != "" {
+			f, err := config.FS.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
 
-		config.Runner.AddReporter(reporter)
-		defer config.Runner.RemoveReporter(reporter)
+		reporter, err := newReporter(format, w)
+		if err != nil {
+			return err
+		}
 
 		out := cmd.OutOrStdout()
 		if out == os.Stdout {
@@ -100,20 +217,114 @@ func runTestCommand(config TestConfig) func(cmd *cobra.Command, args []string) e
 			h = hook.New()
 		}
 
-		r := runtime.New(runtime.Config{
-			Namespace:   namespace,
-			Environment: environment,
-			Scheme:      config.Scheme,
-			Hook:        h,
-			SpecStore:   config.SpecStore,
-			ValueStore:  config.ValueStore,
-		})
-		defer r.Close(ctx)
+		var coverage *runtime.Coverage
+		if coverageFile != "" || threshold > 0 {
+			agent := config.Agent
+			if agent == nil {
+				agent = runtime.NewAgent()
+				defer agent.Close()
+			}
+
+			h.AddLoadHook(agent)
+			h.AddUnloadHook(agent)
+
+			coverage = runtime.NewCoverage()
+
+			agent.Watch(coverage)
+			defer agent.Unwatch(coverage)
+		}
+
+		rc := runtime.Config{
+			Namespace:      namespace,
+			Environment:    environment,
+			Scheme:         config.Scheme,
+			Hook:           h,
+			SpecStore:      config.SpecStore,
+			ValueStore:     config.ValueStore,
+			KeyProvider:    config.KeyProvider,
+			Resolver:       config.Resolver,
+			StrictSchema:   strictSchema,
+			ValidateSchema: validateSchema,
+			Warn:           warn(cmd),
+		}
+
+		opts := testing.RunOptions{
+			Concurrency:  concurrency,
+			Count:        count,
+			Retries:      retries,
+			Timeout:      timeout,
+			SuiteTimeout: suiteTimeout,
+		}
+
+		var report *runtime.CoverageReport
+		if isolated {
+			report, err = runIsolated(ctx, config.Runner, reporter, match, rc, opts, coverage)
+		} else {
+			r := runtime.New(rc)
+			defer r.Close(ctx)
+
+			if err := r.Load(ctx, nil); err != nil {
+				return err
+			}
+
+			config.Runner.AddReporter(reporter)
+			defer config.Runner.RemoveReporter(reporter)
+
+			err = config.Runner.Run(ctx, match, opts)
+			if coverage != nil {
+				report = coverage.Report(r.Symbols())
+			}
+		}
+		if coverage == nil {
+			return err
+		}
+
+		if coverageFile != "" {
+			if err := writeCoverage(config.FS, coverageFile, report); err != nil {
+				return err
+			}
+		}
 
-		if err := r.Load(ctx, nil); err != nil {
+		summary := cmd.OutOrStdout()
+		if output == "" && format != "" && format != reporterText {
+			summary = cmd.ErrOrStderr()
+		}
+		if err := printCoverage(summary, report); err != nil {
 			return err
 		}
 
-		return config.Runner.Run(ctx, match)
+		if err != nil {
+			return err
+		}
+		if report.Percent < threshold {
+			return errors.WithMessagef(errCoverageBelowThreshold, "%.1f%% < %.1f%%", report.Percent, threshold)
+		}
+		return nil
+	}
+}
+
+func newReporter(format string, w io.Writer) (testing.Reporter, error) {
+	switch format {
+	case "", reporterText:
+		return testing.NewTextReporter(w), nil
+	case reporterJUnit:
+		return testing.NewJUnitReporter(w), nil
+	case reporterTAP:
+		return testing.NewTAPReporter(w), nil
+	case reporterJSON:
+		return testing.NewJSONReporter(w), nil
+	default:
+		return nil, errors.WithMessagef(errUnsupportedReporter, "%q", format)
+	}
+}
+
+// snapshotDir returns the directory holding the specs, next to which the golden files of snapshots and the corpora of fuzz tests are kept.
+func snapshotDir(fs afero.Fs, filename string) string {
+	if filename == "" {
+		return ""
+	}
+	if ok, _ := afero.IsDir(fs, filename); ok {
+		return filename
 	}
+	return filepath.Dir(filename)
 }
@@ -5,12 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-faker/faker/v4"
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
@@ -18,8 +21,10 @@ import (
 	"github.com/siyul-park/uniflow/pkg/hook"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
 	testingutil "github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
@@ -217,4 +222,388 @@ func TestTestCommand_Execute(t *testing.T) {
 
 		require.Eventually(t, func() bool { return count.Load() == 1 }, time.Second, 10*time.Millisecond)
 	})
+
+	t.Run(flagReporter, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		filename := "report.xml"
+
+		r := testingutil.NewRunner()
+		r.Register("default/foo", testingutil.RunFunc(func(tester *testingutil.Tester) {}))
+
+		output := new(bytes.Buffer)
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       h,
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagReporter), reporterJUnit, fmt.Sprintf("--%s", flagOutput), filename})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Empty(t, output.String())
+
+		data, err := afero.ReadFile(fs, filename)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `<testcase name="foo" classname="default"`)
+	})
+
+	t.Run("UnsupportedReporter", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       h,
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagReporter), faker.Word()})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, errUnsupportedReporter)
+	})
+
+	t.Run(flagCoverage, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		filename := "coverage.json"
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     testingutil.NewRunner(),
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagCoverage), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), "coverage: 0.0%")
+
+		data, err := afero.ReadFile(fs, filename)
+		require.NoError(t, err)
+
+		var report runtime.CoverageReport
+		err = json.Unmarshal(data, &report)
+		require.NoError(t, err)
+		require.Contains(t, report.Details, &runtime.SymbolCoverage{ID: meta.ID, Namespace: meta.Namespace, Name: meta.Name, Kind: kind})
+	})
+
+	t.Run(flagCoverageThreshold, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+
+		err := specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     testingutil.NewRunner(),
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagCoverageThreshold), "50"})
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, errCoverageBelowThreshold)
+	})
+
+	t.Run(flagConcurrency, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		r := testingutil.NewRunner()
+
+		var running, peak atomic.Int32
+		for i := 0; i < 3; i++ {
+			r.Register(fmt.Sprintf("%s/%d", meta.DefaultNamespace, i), testingutil.RunFunc(func(tester *testingutil.Tester) {
+				n := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}))
+		}
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagConcurrency), "1"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, int32(1), peak.Load())
+	})
+
+	t.Run(flagRetries, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		r := testingutil.NewRunner()
+
+		var count atomic.Int32
+		r.Register(fmt.Sprintf("%s/%s", meta.DefaultNamespace, faker.Word()), testingutil.RunFunc(func(tester *testingutil.Tester) {
+			if count.Add(1) == 1 {
+				tester.Exit(errors.New(faker.Sentence()))
+			}
+		}))
+
+		output := new(bytes.Buffer)
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagRetries), "1", fmt.Sprintf("--%s", flagCount), "2"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, int32(3), count.Load())
+		require.Contains(t, output.String(), "flaky")
+	})
+
+	t.Run(flagSuiteTimeout, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		r := testingutil.NewRunner()
+		r.Register(fmt.Sprintf("%s/%s", meta.DefaultNamespace, faker.Word()), testingutil.RunFunc(func(tester *testingutil.Tester) {
+			<-tester.Done()
+		}))
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagSuiteTimeout), "10ms"})
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, testingutil.ErrTimeout)
+	})
+
+	t.Run(flagIsolate, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		namespace := faker.UUIDHyphenated()
+
+		specStore := driver.NewStore()
+		valueStore := driver.NewStore()
+
+		suites := []*spec.Meta{
+			{ID: uuid.Must(uuid.NewV7()), Kind: kind, Namespace: namespace, Name: "foo"},
+			{ID: uuid.Must(uuid.NewV7()), Kind: kind, Namespace: namespace, Name: "bar"},
+		}
+
+		err := specStore.Insert(ctx, []any{suites[0], suites[1]})
+		require.NoError(t, err)
+
+		err = valueStore.Insert(ctx, []any{&value.Value{ID: uuid.Must(uuid.NewV7()), Namespace: namespace, Name: faker.Word(), Data: faker.Word()}})
+		require.NoError(t, err)
+
+		r := testingutil.NewRunner()
+
+		var mu sync.Mutex
+		namespaces := map[string]string{}
+		loaded := map[string]bool{}
+		var running, overlapped atomic.Int32
+
+		h := hook.New()
+		h.AddLoadHook(symbol.LoadFunc(func(sb *symbol.Symbol) error {
+			mu.Lock()
+			loaded[sb.Namespace()] = true
+			mu.Unlock()
+
+			r.Register(sb.NamespacedName(), testingutil.RunFunc(func(tester *testingutil.Tester) {
+				if running.Add(1) > 1 {
+					overlapped.Add(1)
+				}
+				defer running.Add(-1)
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				defer mu.Unlock()
+				namespaces[sb.Name()] = sb.Namespace()
+			}))
+			return nil
+		}))
+		h.AddUnloadHook(symbol.UnloadFunc(func(sb *symbol.Symbol) error {
+			r.Unregister(sb.NamespacedName())
+			return nil
+		}))
+
+		output := new(bytes.Buffer)
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       h,
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagNamespace), namespace, fmt.Sprintf("--%s", flagIsolate)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, output.String(), namespace+"/foo")
+		require.Contains(t, output.String(), namespace+"/bar")
+
+		require.Len(t, namespaces, 2)
+		require.NotEqual(t, namespace, namespaces["foo"])
+		require.NotEqual(t, namespaces["foo"], namespaces["bar"])
+		require.NotContains(t, loaded, namespace)
+		require.Zero(t, overlapped.Load())
+		require.Empty(t, r.Suites())
+
+		cmd = NewTestCommand(TestConfig{
+			Runner:     r,
+			Scheme:     s,
+			Hook:       h,
+			FS:         fs,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagNamespace), namespace, fmt.Sprintf("--%s", flagIsolate), fmt.Sprintf("--%s", flagConcurrency), "2"})
+
+		err = cmd.Execute()
+		require.Error(t, err)
+	})
+
+	t.Run(flagUpdateSnapshots, func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+
+		filename := filepath.Join("tests", "specs.json")
+		err := afero.WriteFile(fs, filename, []byte("[]"), 0644)
+		require.NoError(t, err)
+
+		snapshot := testingutil.NewSnapshot(fs)
+		payload := map[string]any{"name": faker.Word()}
+
+		r := testingutil.NewRunner()
+		r.Register(meta.DefaultNamespace+"/"+faker.Word(), testingutil.RunFunc(func(tester *testingutil.Tester) {
+			tester.Exit(snapshot.Match("snapshot.json", payload))
+		}))
+
+		cmd := NewTestCommand(TestConfig{
+			Runner:     r,
+			Snapshot:   snapshot,
+			Scheme:     s,
+			Hook:       hook.New(),
+			FS:         fs,
+			SpecStore:  driver.NewStore(),
+			ValueStore: driver.NewStore(),
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetContext(ctx)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFromSpecs), filename})
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, testingutil.ErrSnapshotNotFound)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFromSpecs), filename, fmt.Sprintf("--%s", flagUpdateSnapshots)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+
+		ok, err := afero.Exists(fs, filepath.Join("tests", "snapshot.json"))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFromSpecs), filename, fmt.Sprintf("--%s=false", flagUpdateSnapshots)})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+	})
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/fmt"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/validation"
+)
+
+// ValidateConfig represents the configuration for the validate command.
+type ValidateConfig struct {
+	Scheme *scheme.Scheme
+	FS     afero.Fs
+}
+
+// NewValidateCommand creates a new cobra.Command for the validate command.
+func NewValidateCommand(config ValidateConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate specs without applying them",
+		RunE:  runValidateCommand(config),
+	}
+
+	cmd.PersistentFlags().StringP(flagNamespace, toShorthand(flagNamespace), meta.DefaultNamespace, "Inject the io's namespace. If not set, use the default namespace")
+	cmd.PersistentFlags().StringP(flagFilename, toShorthand(flagFilename), "", "Inject the file path to be validated")
+
+	return cmd
+}
+
+func runValidateCommand(config ValidateConfig) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		namespace, err := cmd.Flags().GetString(flagNamespace)
+		if err != nil {
+			return err
+		}
+		filename, err := cmd.Flags().GetString(flagFilename)
+		if err != nil {
+			return err
+		}
+		if filename == "" {
+			return nil
+		}
+
+		reader := compose.NewComposer(config.FS)
+		writer := fmt.NewWriter(cmd.OutOrStdout())
+
+		var specs []spec.Spec
+		if err := reader.Read(filename, &specs); err != nil {
+			return err
+		}
+
+		for _, sp := range specs {
+			if sp.GetNamespace() == "" {
+				sp.SetNamespace(namespace)
+			}
+		}
+
+		v := validation.NewValidator(validation.Config{Scheme: config.Scheme})
+		issues := v.Validate(specs...)
+
+		if len(issues) > 0 {
+			if err := writer.Write(issueRows(issues)); err != nil {
+				return err
+			}
+		}
+		return validation.Errors(issues)
+	}
+}
+
+func issueRows(issues []*validation.Issue) []map[string]any {
+	rows := make([]map[string]any, 0, len(issues))
+	for _, issue := range issues {
+		row := map[string]any{
+			spec.KeyNamespace: issue.Spec.GetNamespace(),
+			spec.KeyKind:      issue.Spec.GetKind(),
+			"severity":        issue.Severity,
+			"message":         issue.Err.Error(),
+		}
+		if issue.Spec.GetName() != "" {
+			row[spec.KeyName] = issue.Spec.GetName()
+		} else {
+			row[spec.KeyID] = issue.Spec.GetID()
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
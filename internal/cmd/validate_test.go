@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/validation"
+)
+
+func TestValidateCommand_Execute(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Meta{})
+	s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(nil), nil
+	}))
+
+	fs := afero.NewMemMapFs()
+
+	t.Run("Valid", func(t *testing.T) {
+		filename := "specs.json"
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		data, err := json.Marshal([]spec.Spec{meta})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewValidateCommand(ValidateConfig{
+			Scheme: s,
+			FS:     fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		filename := "specs.json"
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+
+		data, err := json.Marshal([]spec.Spec{meta})
+		require.NoError(t, err)
+
+		err = afero.WriteFile(fs, filename, data, 0644)
+		require.NoError(t, err)
+
+		output := new(bytes.Buffer)
+
+		cmd := NewValidateCommand(ValidateConfig{
+			Scheme: s,
+			FS:     fs,
+		})
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{fmt.Sprintf("--%s", flagFilename), filename})
+
+		err = cmd.Execute()
+		require.ErrorIs(t, err, validation.ErrUnknownKind)
+		require.Contains(t, output.String(), meta.Name)
+	})
+}
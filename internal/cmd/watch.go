@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/afero"
+
+	"github.com/siyul-park/uniflow/internal/compose"
+	"github.com/siyul-park/uniflow/internal/fswatch"
+)
+
+// watch starts watching the files composed from the filenames and calls changed whenever any of them is modified, added or removed.
+func watch(ctx context.Context, fs afero.Fs, filenames []string, changed func()) {
+	fswatch.Watch(ctx, fs, func() []string {
+		return sources(fs, filenames)
+	}, changed)
+}
+
+func sources(fs afero.Fs, filenames []string) []string {
+	composer := compose.NewComposer(fs)
+
+	var files []string
+	for _, filename := range filenames {
+		if filename == "" {
+			continue
+		}
+
+		names, err := composer.Sources(filename)
+		if err != nil {
+			names = []string{filename}
+		}
+		files = append(files, names...)
+	}
+	return files
+}
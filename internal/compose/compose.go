@@ -0,0 +1,317 @@
+package compose
+
+import (
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// Composition describes how a set of resources is assembled from other files.
+type Composition struct {
+	Include           []string          `json:"include,omitempty" yaml:"include,omitempty"`
+	Resources         []any             `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Patches           []Patch           `json:"patches,omitempty" yaml:"patches,omitempty"`
+	NamePrefix        string            `json:"namePrefix,omitempty" yaml:"namePrefix,omitempty"`
+	NameSuffix        string            `json:"nameSuffix,omitempty" yaml:"nameSuffix,omitempty"`
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty" yaml:"commonAnnotations,omitempty"`
+}
+
+// Patch modifies the resources matched by its target. A map patch is applied as a strategic merge
+// and a list patch as JSON Patch operations.
+type Patch struct {
+	Target *Target `json:"target,omitempty" yaml:"target,omitempty"`
+	Patch  any     `json:"patch" yaml:"patch"`
+}
+
+// Target selects resources by kind, namespace and name. Empty fields match any value.
+type Target struct {
+	Kind      string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// Composer reads resource files and resolves their compositions.
+type Composer struct {
+	fs afero.Fs
+}
+
+var (
+	ErrCyclicInclude = errors.New("cyclic include")
+	ErrNoTarget      = errors.New("patch matches no resource")
+)
+
+var keys = []string{"include", "resources", "patches", "namePrefix", "nameSuffix", "commonAnnotations"}
+
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// NewComposer creates a new Composer reading files from the given file system.
+func NewComposer(fs afero.Fs) *Composer {
+	return &Composer{fs: fs}
+}
+
+// Read composes the file and decodes the resulting resources into value.
+func (c *Composer) Read(filename string, value any) error {
+	docs, err := c.Compose(filename)
+	if err != nil {
+		return err
+	}
+
+	doc, err := types.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	return types.Unmarshal(doc, value)
+}
+
+// Compose reads the file or directory and returns the composed resources.
+func (c *Composer) Compose(filename string) ([]any, error) {
+	return c.compose(filepath.Clean(filename), nil, nil)
+}
+
+// Sources returns the files read when composing the file or directory, including those reached through includes.
+func (c *Composer) Sources(filename string) ([]string, error) {
+	var sources []string
+	if _, err := c.compose(filepath.Clean(filename), nil, &sources); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (c *Composer) compose(filename string, stack []string, sources *[]string) ([]any, error) {
+	if slices.Contains(stack, filename) {
+		return nil, errors.WithMessagef(ErrCyclicInclude, "%s", strings.Join(append(stack, filename), " -> "))
+	}
+	stack = append(stack, filename)
+
+	info, err := c.fs.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := afero.ReadDir(c.fs, filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var docs []any
+		for _, entry := range entries {
+			if entry.IsDir() || !slices.Contains(extensions, strings.ToLower(filepath.Ext(entry.Name()))) {
+				continue
+			}
+			resources, err := c.compose(filepath.Join(filename, entry.Name()), stack, sources)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, resources...)
+		}
+		return docs, nil
+	}
+
+	file, err := c.fs.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if sources != nil {
+		*sources = append(*sources, filename)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	switch v := doc.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		return v, nil
+	case map[string]any:
+		if !isComposition(v) {
+			return []any{v}, nil
+		}
+	default:
+		return []any{v}, nil
+	}
+
+	var composition Composition
+	if err := yaml.Unmarshal(data, &composition); err != nil {
+		return nil, err
+	}
+
+	var docs []any
+	for _, include := range composition.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(filename), include)
+		}
+		resources, err := c.compose(filepath.Clean(include), stack, sources)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, resources...)
+	}
+	docs = append(docs, composition.Resources...)
+
+	return composition.Apply(docs)
+}
+
+// Apply applies the patches, name transformations and common annotations of the composition to the resources.
+func (c *Composition) Apply(docs []any) ([]any, error) {
+	docs = clone(docs).([]any)
+
+	for _, p := range c.Patches {
+		target := p.Target
+		if target == nil {
+			if m, ok := p.Patch.(map[string]any); ok {
+				target = &Target{}
+				target.Kind, _ = m[spec.KeyKind].(string)
+				target.Namespace, _ = m[spec.KeyNamespace].(string)
+				target.Name, _ = m[spec.KeyName].(string)
+			} else {
+				return nil, errors.WithMessage(ErrInvalidPatch, "json patch requires a target")
+			}
+		}
+
+		matched := false
+		for i, doc := range docs {
+			if !target.Match(doc) {
+				continue
+			}
+			matched = true
+
+			switch patch := p.Patch.(type) {
+			case map[string]any:
+				docs[i] = MergePatch(doc, patch)
+			case []any:
+				var ops []Operation
+				if err := decode(patch, &ops); err != nil {
+					return nil, err
+				}
+				patched, err := JSONPatch(doc, ops)
+				if err != nil {
+					return nil, err
+				}
+				docs[i] = patched
+			default:
+				return nil, errors.WithStack(ErrInvalidPatch)
+			}
+		}
+		if !matched {
+			return nil, errors.WithMessagef(ErrNoTarget, "%s/%s/%s", target.Kind, target.Namespace, target.Name)
+		}
+	}
+
+	if c.NamePrefix != "" || c.NameSuffix != "" {
+		renames := map[[2]string]string{}
+		for _, doc := range docs {
+			m, ok := doc.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := m[spec.KeyName].(string)
+			if name == "" {
+				continue
+			}
+			namespace, _ := m[spec.KeyNamespace].(string)
+
+			renamed := c.NamePrefix + name + c.NameSuffix
+			renames[[2]string{namespace, name}] = renamed
+			m[spec.KeyName] = renamed
+		}
+
+		for _, doc := range docs {
+			m, ok := doc.(map[string]any)
+			if !ok {
+				continue
+			}
+			namespace, _ := m[spec.KeyNamespace].(string)
+
+			ports, _ := m[spec.KeyPorts].(map[string]any)
+			for _, refs := range ports {
+				refs, _ := refs.([]any)
+				for _, ref := range refs {
+					ref, ok := ref.(map[string]any)
+					if !ok {
+						continue
+					}
+					name, _ := ref[spec.KeyName].(string)
+					if renamed, ok := renames[[2]string{namespace, name}]; ok {
+						ref[spec.KeyName] = renamed
+					}
+				}
+			}
+		}
+	}
+
+	if len(c.CommonAnnotations) > 0 {
+		for _, doc := range docs {
+			m, ok := doc.(map[string]any)
+			if !ok {
+				continue
+			}
+			annotations, _ := m[spec.KeyAnnotations].(map[string]any)
+			if annotations == nil {
+				annotations = map[string]any{}
+			}
+			for key, val := range c.CommonAnnotations {
+				if _, ok := annotations[key]; !ok {
+					annotations[key] = val
+				}
+			}
+			m[spec.KeyAnnotations] = annotations
+		}
+	}
+
+	return docs, nil
+}
+
+// Match reports whether the resource is selected by the target.
+func (t *Target) Match(doc any) bool {
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, want := range map[string]string{spec.KeyKind: t.Kind, spec.KeyNamespace: t.Namespace, spec.KeyName: t.Name} {
+		if want == "" {
+			continue
+		}
+		if got, _ := m[key].(string); got != want {
+			return false
+		}
+	}
+	return true
+}
+
+func isComposition(doc map[string]any) bool {
+	if _, ok := doc[spec.KeyKind]; ok {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := doc[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func decode(src, dest any) error {
+	data, err := yaml.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, dest)
+}
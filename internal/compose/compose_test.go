@@ -0,0 +1,126 @@
+package compose
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestComposer_Compose(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "base/listener.yaml", []byte(`
+- kind: listener
+  name: server
+  protocol: http
+  port: 8000
+  ports:
+    out:
+      - name: router
+        port: in
+`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "base/router.yaml", []byte(`
+kind: router
+name: router
+`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "base/README.md", []byte(`ignored`), 0644))
+
+	t.Run("Flat", func(t *testing.T) {
+		docs, err := NewComposer(fs).Compose("base/listener.yaml")
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		docs, err := NewComposer(fs).Compose("base")
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+	})
+
+	t.Run("Overlay", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "dev/uniflow.yaml", []byte(`
+include:
+  - ../base
+resources:
+  - kind: snippet
+    name: extra
+namePrefix: dev-
+commonAnnotations:
+  env: dev
+patches:
+  - patch:
+      kind: listener
+      name: server
+      port: 8001
+  - target:
+      kind: router
+    patch:
+      - op: add
+        path: /routes
+        value: []
+`), 0644))
+
+		var specs []*spec.Unstructured
+		err := NewComposer(fs).Read("dev/uniflow.yaml", &specs)
+		require.NoError(t, err)
+		require.Len(t, specs, 3)
+
+		listener := specs[0]
+		require.Equal(t, "dev-server", listener.GetName())
+		require.Equal(t, "dev", listener.GetAnnotations()["env"])
+		require.Equal(t, "dev-router", listener.GetPorts()["out"][0].Name)
+
+		port, _ := listener.Get("port")
+		require.EqualValues(t, 8001, port)
+
+		router := specs[1]
+		require.Equal(t, "dev-router", router.GetName())
+		routes, ok := router.Get("routes")
+		require.True(t, ok)
+		require.Empty(t, routes)
+
+		require.Equal(t, "dev-extra", specs[2].GetName())
+	})
+
+	t.Run("NoTarget", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "invalid.yaml", []byte(`
+include: [base]
+patches:
+  - target:
+      name: unknown
+    patch:
+      port: 1
+`), 0644))
+
+		_, err := NewComposer(fs).Compose("invalid.yaml")
+		require.ErrorIs(t, err, ErrNoTarget)
+	})
+
+	t.Run("CyclicInclude", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "a.yaml", []byte(`include: [b.yaml]`), 0644))
+		require.NoError(t, afero.WriteFile(fs, "b.yaml", []byte(`include: [a.yaml]`), 0644))
+
+		_, err := NewComposer(fs).Compose("a.yaml")
+		require.ErrorIs(t, err, ErrCyclicInclude)
+	})
+}
+
+func TestComposer_Sources(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, afero.WriteFile(fs, "base/listener.yaml", []byte(`kind: listener`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "base/router.yaml", []byte(`kind: router`), 0644))
+	require.NoError(t, afero.WriteFile(fs, "dev/uniflow.yaml", []byte(`include: [../base]`), 0644))
+
+	sources, err := NewComposer(fs).Sources("dev/uniflow.yaml")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join("dev", "uniflow.yaml"),
+		filepath.Join("base", "listener.yaml"),
+		filepath.Join("base", "router.yaml"),
+	}, sources)
+}
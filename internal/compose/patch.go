@@ -0,0 +1,344 @@
+package compose
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Operation is a single JSON Patch (RFC 6902) operation.
+type Operation struct {
+	Op    string `json:"op" yaml:"op"`
+	Path  string `json:"path" yaml:"path"`
+	From  string `json:"from,omitempty" yaml:"from,omitempty"`
+	Value any    `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrTestFailed   = errors.New("test operation failed")
+)
+
+// MergePatch applies a strategic merge patch to the document. Maps are merged recursively,
+// null values remove keys and any other value replaces the original one. Lists of maps identified by
+// their id, name and port, such as port links, are merged by those keys: matching elements are merged,
+// others are appended, and elements marked with "$patch: delete" are removed. A list containing
+// "$patch: replace" replaces the original list.
+func MergePatch(doc, patch any) any {
+	if l, ok := patch.([]any); ok {
+		return mergeList(doc, l)
+	}
+
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return clone(patch)
+	}
+	d, ok := doc.(map[string]any)
+	if !ok {
+		d = map[string]any{}
+	} else {
+		d = clone(d).(map[string]any)
+	}
+
+	for key, val := range p {
+		if val == nil {
+			delete(d, key)
+		} else {
+			d[key] = MergePatch(d[key], val)
+		}
+	}
+	return d
+}
+
+// JSONPatch applies the JSON Patch operations to the document in order.
+func JSONPatch(doc any, ops []Operation) (any, error) {
+	doc = clone(doc)
+
+	for _, op := range ops {
+		var err error
+		switch op.Op {
+		case "add":
+			doc, err = add(doc, op.Path, clone(op.Value))
+		case "remove":
+			doc, _, err = remove(doc, op.Path)
+		case "replace":
+			if doc, _, err = remove(doc, op.Path); err == nil {
+				doc, err = add(doc, op.Path, clone(op.Value))
+			}
+		case "move":
+			var val any
+			if doc, val, err = remove(doc, op.From); err == nil {
+				doc, err = add(doc, op.Path, val)
+			}
+		case "copy":
+			var val any
+			if val, err = get(doc, op.From); err == nil {
+				doc, err = add(doc, op.Path, clone(val))
+			}
+		case "test":
+			var val any
+			if val, err = get(doc, op.Path); err == nil && !reflect.DeepEqual(val, op.Value) {
+				err = errors.WithMessage(ErrTestFailed, op.Path)
+			}
+		default:
+			err = errors.WithMessagef(ErrInvalidPatch, "unknown operation %q", op.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+const (
+	directive        = "$patch"
+	directiveDelete  = "delete"
+	directiveReplace = "replace"
+)
+
+var mergeKeys = []string{"id", "name", "port"}
+
+func mergeList(doc any, patch []any) any {
+	if len(patch) == 0 {
+		return []any{}
+	}
+
+	merged, ok := doc.([]any)
+	if !ok {
+		merged = nil
+	}
+	merged = clone(merged).([]any)
+
+	var elems []map[string]any
+	for _, val := range patch {
+		elem, ok := val.(map[string]any)
+		if ok && elem[directive] == directiveReplace {
+			merged = nil
+			continue
+		}
+		if !ok || identity(elem) == nil {
+			return strip(patch)
+		}
+		elems = append(elems, elem)
+	}
+
+	for _, elem := range elems {
+		id := identity(elem)
+
+		i := -1
+		for j, val := range merged {
+			if e, ok := val.(map[string]any); ok && reflect.DeepEqual(identity(e), id) {
+				i = j
+				break
+			}
+		}
+
+		switch {
+		case elem[directive] == directiveDelete:
+			if i >= 0 {
+				merged = append(merged[:i], merged[i+1:]...)
+			}
+		case i >= 0:
+			merged[i] = MergePatch(merged[i], elem)
+		default:
+			merged = append(merged, MergePatch(nil, elem))
+		}
+	}
+	return merged
+}
+
+func identity(elem map[string]any) map[string]any {
+	var id map[string]any
+	for _, key := range mergeKeys {
+		if val, ok := elem[key]; ok {
+			if id == nil {
+				id = make(map[string]any, len(mergeKeys))
+			}
+			id[key] = val
+		}
+	}
+	return id
+}
+
+func strip(patch []any) []any {
+	stripped := make([]any, 0, len(patch))
+	for _, val := range patch {
+		if elem, ok := val.(map[string]any); ok && elem[directive] != nil {
+			continue
+		}
+		stripped = append(stripped, clone(val))
+	}
+	return stripped
+}
+
+func get(doc any, path string) (any, error) {
+	tokens, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := doc
+	for _, token := range tokens {
+		switch v := cur.(type) {
+		case map[string]any:
+			val, ok := v[token]
+			if !ok {
+				return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", path)
+			}
+			cur = val
+		case []any:
+			i, err := index(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+			cur = v[i]
+		default:
+			return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", path)
+		}
+	}
+	return cur, nil
+}
+
+func add(doc any, path string, val any) (any, error) {
+	tokens, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return update(doc, tokens, func(parent any, token string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			v[token] = val
+			return v, nil
+		case []any:
+			if token == "-" {
+				return append(v, val), nil
+			}
+			i, err := index(token, len(v))
+			if err != nil {
+				return nil, err
+			}
+			v = append(v, nil)
+			copy(v[i+1:], v[i:])
+			v[i] = val
+			return v, nil
+		default:
+			return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", path)
+		}
+	}, func() (any, error) {
+		return val, nil
+	})
+}
+
+func remove(doc any, path string) (any, any, error) {
+	tokens, err := parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var removed any
+	doc, err = update(doc, tokens, func(parent any, token string) (any, error) {
+		switch v := parent.(type) {
+		case map[string]any:
+			val, ok := v[token]
+			if !ok {
+				return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", path)
+			}
+			removed = val
+			delete(v, token)
+			return v, nil
+		case []any:
+			i, err := index(token, len(v)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = v[i]
+			return append(v[:i], v[i+1:]...), nil
+		default:
+			return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", path)
+		}
+	}, func() (any, error) {
+		removed = doc
+		return nil, nil
+	})
+	return doc, removed, err
+}
+
+func update(doc any, tokens []string, leaf func(parent any, token string) (any, error), root func() (any, error)) (any, error) {
+	if len(tokens) == 0 {
+		return root()
+	}
+	if len(tokens) == 1 {
+		return leaf(doc, tokens[0])
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		child, ok := v[tokens[0]]
+		if !ok {
+			return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", "/"+strings.Join(tokens, "/"))
+		}
+		child, err := update(child, tokens[1:], leaf, root)
+		if err != nil {
+			return nil, err
+		}
+		v[tokens[0]] = child
+		return v, nil
+	case []any:
+		i, err := index(tokens[0], len(v)-1)
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(v[i], tokens[1:], leaf, root)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	default:
+		return nil, errors.WithMessagef(ErrInvalidPatch, "path %q not found", "/"+strings.Join(tokens, "/"))
+	}
+}
+
+func parse(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.WithMessagef(ErrInvalidPatch, "path %q must start with '/'", path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, errors.WithMessagef(ErrInvalidPatch, "invalid index %q", token)
+	}
+	return i, nil
+}
+
+func clone(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, val := range v {
+			c[key] = clone(val)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, val := range v {
+			c[i] = clone(val)
+		}
+		return c
+	default:
+		return val
+	}
+}
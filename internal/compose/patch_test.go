@@ -0,0 +1,137 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	doc := map[string]any{
+		"kind": "listener",
+		"port": 8000,
+		"env":  map[string]any{"A": "a", "B": "b"},
+		"tags": []any{"x", "y"},
+	}
+	patch := map[string]any{
+		"port": 9000,
+		"env":  map[string]any{"B": nil, "C": "c"},
+		"tags": []any{"z"},
+	}
+
+	patched := MergePatch(doc, patch)
+	require.Equal(t, map[string]any{
+		"kind": "listener",
+		"port": 9000,
+		"env":  map[string]any{"A": "a", "C": "c"},
+		"tags": []any{"z"},
+	}, patched)
+	require.Equal(t, 8000, doc["port"])
+
+	t.Run("KeyedList", func(t *testing.T) {
+		doc := map[string]any{
+			"ports": map[string]any{
+				"out": []any{
+					map[string]any{"name": "a", "port": "in"},
+					map[string]any{"name": "b", "port": "in"},
+				},
+			},
+		}
+
+		patched := MergePatch(doc, map[string]any{
+			"ports": map[string]any{
+				"out": []any{
+					map[string]any{"name": "c", "port": "in"},
+					map[string]any{"name": "a", "port": "in", "$patch": "delete"},
+				},
+			},
+		})
+		require.Equal(t, map[string]any{
+			"ports": map[string]any{
+				"out": []any{
+					map[string]any{"name": "b", "port": "in"},
+					map[string]any{"name": "c", "port": "in"},
+				},
+			},
+		}, patched)
+
+		patched = MergePatch(doc, map[string]any{
+			"ports": map[string]any{
+				"out": []any{
+					map[string]any{"$patch": "replace"},
+					map[string]any{"name": "c", "port": "in"},
+				},
+			},
+		})
+		require.Equal(t, map[string]any{
+			"ports": map[string]any{
+				"out": []any{
+					map[string]any{"name": "c", "port": "in"},
+				},
+			},
+		}, patched)
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	doc := map[string]any{
+		"a": map[string]any{"b": 1},
+		"c": []any{1, 2, 3},
+	}
+
+	tests := []struct {
+		ops    []Operation
+		expect any
+	}{
+		{
+			ops:    []Operation{{Op: "add", Path: "/a/d", Value: 2}},
+			expect: map[string]any{"a": map[string]any{"b": 1, "d": 2}, "c": []any{1, 2, 3}},
+		},
+		{
+			ops:    []Operation{{Op: "add", Path: "/c/-", Value: 4}},
+			expect: map[string]any{"a": map[string]any{"b": 1}, "c": []any{1, 2, 3, 4}},
+		},
+		{
+			ops:    []Operation{{Op: "add", Path: "/c/0", Value: 0}},
+			expect: map[string]any{"a": map[string]any{"b": 1}, "c": []any{0, 1, 2, 3}},
+		},
+		{
+			ops:    []Operation{{Op: "remove", Path: "/c/1"}},
+			expect: map[string]any{"a": map[string]any{"b": 1}, "c": []any{1, 3}},
+		},
+		{
+			ops:    []Operation{{Op: "replace", Path: "/a/b", Value: 5}},
+			expect: map[string]any{"a": map[string]any{"b": 5}, "c": []any{1, 2, 3}},
+		},
+		{
+			ops:    []Operation{{Op: "move", From: "/a/b", Path: "/e"}},
+			expect: map[string]any{"a": map[string]any{}, "c": []any{1, 2, 3}, "e": 1},
+		},
+		{
+			ops:    []Operation{{Op: "copy", From: "/c", Path: "/e"}},
+			expect: map[string]any{"a": map[string]any{"b": 1}, "c": []any{1, 2, 3}, "e": []any{1, 2, 3}},
+		},
+		{
+			ops:    []Operation{{Op: "test", Path: "/a/b", Value: 1}},
+			expect: doc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ops[0].Op, func(t *testing.T) {
+			patched, err := JSONPatch(doc, tt.ops)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, patched)
+		})
+	}
+
+	t.Run("TestFailed", func(t *testing.T) {
+		_, err := JSONPatch(doc, []Operation{{Op: "test", Path: "/a/b", Value: 2}})
+		require.ErrorIs(t, err, ErrTestFailed)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := JSONPatch(doc, []Operation{{Op: "remove", Path: "/x/y"}})
+		require.ErrorIs(t, err, ErrInvalidPatch)
+	})
+}
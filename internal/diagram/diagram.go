@@ -0,0 +1,201 @@
+package diagram
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/validation"
+)
+
+// Diagram is a renderable graph of specs and the links between their ports.
+type Diagram struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+// Node is a spec drawn in a Diagram. Specs that contain other specs are drawn as clusters.
+type Node struct {
+	ID        string    `json:"id"`
+	SpecID    uuid.UUID `json:"spec_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name,omitempty"`
+	Parent    string    `json:"parent,omitempty"`
+	Cluster   bool      `json:"cluster,omitempty"`
+}
+
+// Edge links an output port of a source node to an input port of a target node.
+type Edge struct {
+	Source string `json:"source"`
+	Out    string `json:"out"`
+	Target string `json:"target"`
+	In     string `json:"in"`
+	Count  *int   `json:"count,omitempty"`
+}
+
+// composite captures the fields of specs that nest other specs.
+type composite struct {
+	spec.Meta `json:",inline"`
+	Specs     []*spec.Unstructured   `json:"specs,omitempty"`
+	Inbounds  map[string][]spec.Port `json:"inbounds,omitempty"`
+	Outbounds map[string][]spec.Port `json:"outbounds,omitempty"`
+}
+
+// KindStep is the kind of specs whose nested specs are chained in order.
+const KindStep = "step"
+
+// New builds a Diagram from the given specs, expanding nested specs into clusters.
+func New(specs ...spec.Spec) *Diagram {
+	d := &Diagram{}
+	d.add("", specs)
+	return d
+}
+
+// Label returns a human-readable label for the node.
+func (n *Node) Label() string {
+	name := n.Name
+	if name == "" && n.SpecID != uuid.Nil {
+		name = n.SpecID.String()
+	}
+	if name == "" {
+		return n.Kind
+	}
+	return fmt.Sprintf("%s (%s)", name, n.Kind)
+}
+
+// Label returns a human-readable label for the edge.
+func (e *Edge) Label() string {
+	label := e.Out + " -> " + e.In
+	if e.Count != nil {
+		label = fmt.Sprintf("%s [%d]", label, *e.Count)
+	}
+	return label
+}
+
+// Overlay sets the packet count of every edge leaving a top-level spec using the counts keyed by spec ID and output port.
+func (d *Diagram) Overlay(counts map[uuid.UUID]map[string]int) {
+	nodes := make(map[string]*Node, len(d.Nodes))
+	for _, n := range d.Nodes {
+		nodes[n.ID] = n
+	}
+
+	for _, e := range d.Edges {
+		n := nodes[e.Source]
+		if n == nil || n.Parent != "" || n.SpecID == uuid.Nil {
+			continue
+		}
+		count := counts[n.SpecID][e.Out]
+		e.Count = &count
+	}
+}
+
+// Children returns the nodes directly nested in the node with the given ID.
+func (d *Diagram) Children(id string) []*Node {
+	var children []*Node
+	for _, n := range d.Nodes {
+		if n.Parent == id {
+			children = append(children, n)
+		}
+	}
+	return children
+}
+
+func (d *Diagram) add(parent string, specs []spec.Spec) []*Node {
+	nodes := make(map[spec.Spec]*Node, len(specs))
+	added := make([]*Node, 0, len(specs))
+	for _, sp := range specs {
+		n := &Node{
+			ID:        fmt.Sprintf("n%d", len(d.Nodes)),
+			SpecID:    sp.GetID(),
+			Kind:      sp.GetKind(),
+			Namespace: sp.GetNamespace(),
+			Name:      sp.GetName(),
+			Parent:    parent,
+		}
+		d.Nodes = append(d.Nodes, n)
+		nodes[sp] = n
+		added = append(added, n)
+	}
+
+	for _, sp := range specs {
+		d.expand(nodes[sp], sp)
+	}
+
+	for _, e := range validation.NewGraph(specs...).Edges() {
+		if e.Target == nil {
+			continue
+		}
+		d.Edges = append(d.Edges, &Edge{
+			Source: nodes[e.Source].ID,
+			Out:    e.Out,
+			Target: nodes[e.Target].ID,
+			In:     e.Port.Port,
+		})
+	}
+	return added
+}
+
+func (d *Diagram) expand(n *Node, sp spec.Spec) {
+	c := &composite{}
+	if err := spec.As(sp, c); err != nil || len(c.Specs) == 0 {
+		return
+	}
+	n.Cluster = true
+
+	children := make([]spec.Spec, 0, len(c.Specs))
+	for i, child := range c.Specs {
+		if child.GetNamespace() == "" {
+			child.SetNamespace(meta.NamespacedName(sp))
+		}
+		if child.GetName() == "" {
+			child.SetName(fmt.Sprintf("$%d", i))
+		}
+		if sp.GetKind() == KindStep {
+			child.SetPorts(nil)
+		}
+		children = append(children, child)
+	}
+
+	nodes := d.add(n.ID, children)
+
+	if sp.GetKind() == KindStep {
+		for i := 0; i < len(nodes)-1; i++ {
+			d.Edges = append(d.Edges, &Edge{Source: nodes[i].ID, Out: node.PortOut, Target: nodes[i+1].ID, In: node.PortIn})
+		}
+		if len(nodes) > 1 {
+			d.Edges = append(d.Edges, &Edge{Source: n.ID, Out: node.PortIn, Target: nodes[0].ID, In: node.PortIn})
+			d.Edges = append(d.Edges, &Edge{Source: nodes[len(nodes)-1].ID, Out: node.PortOut, Target: n.ID, In: node.PortOut})
+		}
+		return
+	}
+
+	resolve := func(port spec.Port) *Node {
+		for i, child := range children {
+			if (port.ID != uuid.Nil && child.GetID() == port.ID) || (port.ID == uuid.Nil && child.GetName() == port.Name) {
+				return nodes[i]
+			}
+		}
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Inbounds)) {
+		for _, port := range c.Inbounds[name] {
+			if target := resolve(port); target != nil {
+				d.Edges = append(d.Edges, &Edge{Source: n.ID, Out: name, Target: target.ID, In: port.Port})
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Outbounds)) {
+		for _, port := range c.Outbounds[name] {
+			if source := resolve(port); source != nil {
+				d.Edges = append(d.Edges, &Edge{Source: source.ID, Out: port.Port, Target: n.ID, In: name})
+			}
+		}
+	}
+}
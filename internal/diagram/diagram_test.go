@@ -0,0 +1,120 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Flat", func(t *testing.T) {
+		sp1 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		sp2 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Ports: map[string][]spec.Port{
+				node.PortOut: {{Name: sp1.GetName(), Port: node.PortIn}},
+			},
+		}
+
+		d := New(sp1, sp2)
+		require.Len(t, d.Nodes, 2)
+		require.Len(t, d.Edges, 1)
+		require.Equal(t, &Edge{Source: d.Nodes[1].ID, Out: node.PortOut, Target: d.Nodes[0].ID, In: node.PortIn}, d.Edges[0])
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		sp := &spec.Unstructured{
+			Meta: spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      "block",
+				Namespace: meta.DefaultNamespace,
+				Name:      faker.UUIDHyphenated(),
+			},
+			Fields: map[string]any{
+				"specs": []any{
+					map[string]any{
+						"kind": faker.UUIDHyphenated(),
+						"name": "first",
+						"ports": map[string]any{
+							node.PortOut: []any{map[string]any{"name": "second", "port": node.PortIn}},
+						},
+					},
+					map[string]any{
+						"kind": faker.UUIDHyphenated(),
+						"name": "second",
+					},
+				},
+				"inbounds": map[string]any{
+					node.PortIn: []any{map[string]any{"name": "first", "port": node.PortIn}},
+				},
+				"outbounds": map[string]any{
+					node.PortOut: []any{map[string]any{"name": "second", "port": node.PortOut}},
+				},
+			},
+		}
+
+		d := New(sp)
+		require.Len(t, d.Nodes, 3)
+		require.True(t, d.Nodes[0].Cluster)
+		require.Len(t, d.Children(d.Nodes[0].ID), 2)
+		require.Len(t, d.Edges, 3)
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		sp := &spec.Unstructured{
+			Meta: spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      KindStep,
+				Namespace: meta.DefaultNamespace,
+			},
+			Fields: map[string]any{
+				"specs": []any{
+					map[string]any{"kind": faker.UUIDHyphenated()},
+					map[string]any{"kind": faker.UUIDHyphenated()},
+					map[string]any{"kind": faker.UUIDHyphenated()},
+				},
+			},
+		}
+
+		d := New(sp)
+		require.Len(t, d.Nodes, 4)
+		require.Len(t, d.Children(d.Nodes[0].ID), 3)
+		require.Len(t, d.Edges, 4)
+	})
+}
+
+func TestDiagram_Overlay(t *testing.T) {
+	sp1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+		Ports: map[string][]spec.Port{
+			node.PortOut: {{ID: sp1.GetID(), Port: node.PortIn}},
+		},
+	}
+
+	d := New(sp1, sp2)
+	d.Overlay(map[uuid.UUID]map[string]int{sp2.GetID(): {node.PortOut: 3}})
+
+	require.NotNil(t, d.Edges[0].Count)
+	require.Equal(t, 3, *d.Edges[0].Count)
+	require.Equal(t, "out -> in [3]", d.Edges[0].Label())
+}
@@ -0,0 +1,104 @@
+package diagram
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+)
+
+// Supported output formats.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Render writes the diagram to the writer in the given format.
+func Render(w io.Writer, d *Diagram, format string) error {
+	switch format {
+	case "", FormatDOT:
+		return RenderDOT(w, d)
+	case FormatMermaid:
+		return RenderMermaid(w, d)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(d)
+	default:
+		return errors.WithStack(encoding.ErrUnsupportedValue)
+	}
+}
+
+// RenderDOT writes the diagram as a Graphviz DOT digraph.
+func RenderDOT(w io.Writer, d *Diagram) error {
+	var b strings.Builder
+
+	b.WriteString("digraph {\n")
+	b.WriteString("  compound=true;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	var write func(parent string, depth int)
+	write = func(parent string, depth int) {
+		indent := strings.Repeat("  ", depth)
+		for _, n := range d.Children(parent) {
+			if n.Cluster {
+				fmt.Fprintf(&b, "%ssubgraph cluster_%s {\n", indent, n.ID)
+				fmt.Fprintf(&b, "%s  label=%s;\n", indent, strconv.Quote(n.Label()))
+				fmt.Fprintf(&b, "%s  %s [label=%s, shape=point];\n", indent, n.ID, strconv.Quote(n.Label()))
+				write(n.ID, depth+1)
+				fmt.Fprintf(&b, "%s}\n", indent)
+			} else {
+				fmt.Fprintf(&b, "%s%s [label=%s];\n", indent, n.ID, strconv.Quote(n.Label()))
+			}
+		}
+	}
+	write("", 1)
+
+	for _, e := range d.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", e.Source, e.Target, strconv.Quote(e.Label()))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderMermaid writes the diagram as a Mermaid flowchart.
+func RenderMermaid(w io.Writer, d *Diagram) error {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	var write func(parent string, depth int)
+	write = func(parent string, depth int) {
+		indent := strings.Repeat("  ", depth)
+		for _, n := range d.Children(parent) {
+			if n.Cluster {
+				fmt.Fprintf(&b, "%ssubgraph %s_cluster [%s]\n", indent, n.ID, mermaidQuote(n.Label()))
+				fmt.Fprintf(&b, "%s  %s((%s))\n", indent, n.ID, mermaidQuote(n.Label()))
+				write(n.ID, depth+1)
+				fmt.Fprintf(&b, "%send\n", indent)
+			} else {
+				fmt.Fprintf(&b, "%s%s[%s]\n", indent, n.ID, mermaidQuote(n.Label()))
+			}
+		}
+	}
+	write("", 1)
+
+	for _, e := range d.Edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", e.Source, mermaidQuote(e.Label()), e.Target)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,81 @@
+package diagram
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestRender(t *testing.T) {
+	sp1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+	}
+	sp2 := &spec.Unstructured{
+		Meta: spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      KindStep,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+			Ports: map[string][]spec.Port{
+				node.PortOut: {{Name: sp1.GetName(), Port: node.PortIn}},
+			},
+		},
+		Fields: map[string]any{
+			"specs": []any{
+				map[string]any{"kind": faker.UUIDHyphenated()},
+				map[string]any{"kind": faker.UUIDHyphenated()},
+			},
+		},
+	}
+
+	d := New(sp1, sp2)
+
+	t.Run(FormatDOT, func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Render(buf, d, FormatDOT)
+		require.NoError(t, err)
+
+		require.Contains(t, buf.String(), "digraph {")
+		require.Contains(t, buf.String(), "subgraph cluster_n1 {")
+		require.Contains(t, buf.String(), "n1 -> n0 [label=\"out -> in\"];")
+	})
+
+	t.Run(FormatMermaid, func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Render(buf, d, FormatMermaid)
+		require.NoError(t, err)
+
+		require.Contains(t, buf.String(), "flowchart LR")
+		require.Contains(t, buf.String(), "subgraph n1_cluster")
+		require.Contains(t, buf.String(), "n1 -->|\"out -> in\"| n0")
+	})
+
+	t.Run(FormatJSON, func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Render(buf, d, FormatJSON)
+		require.NoError(t, err)
+
+		var decoded Diagram
+		err = json.Unmarshal(buf.Bytes(), &decoded)
+		require.NoError(t, err)
+		require.Len(t, decoded.Nodes, len(d.Nodes))
+		require.Len(t, decoded.Edges, len(d.Edges))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		err := Render(new(bytes.Buffer), d, faker.Word())
+		require.ErrorIs(t, err, encoding.ErrUnsupportedValue)
+	})
+}
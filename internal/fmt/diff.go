@@ -0,0 +1,200 @@
+package fmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Diff writes a unified diff between two documents in which each line is a field path and its value.
+// It reports whether the documents differ. A nil document is treated as absent.
+func Diff(writer io.Writer, from, to any, fromName, toName string) (bool, error) {
+	x, err := flatten(from)
+	if err != nil {
+		return false, err
+	}
+	y, err := flatten(to)
+	if err != nil {
+		return false, err
+	}
+
+	lines := merge(x, y)
+	if !slices.ContainsFunc(lines, func(l line) bool { return l.op != ' ' }) {
+		return false, nil
+	}
+
+	if _, err := fmt.Fprintf(writer, "--- %s\n+++ %s\n", fromName, toName); err != nil {
+		return true, err
+	}
+	for _, h := range hunks(lines, 3) {
+		if err := h.write(writer); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+type line struct {
+	op   byte
+	text string
+}
+
+type hunk struct {
+	fromStart, fromCount int
+	toStart, toCount     int
+	lines                []line
+}
+
+func (h hunk) write(writer io.Writer) error {
+	if _, err := fmt.Fprintf(writer, "@@ -%d,%d +%d,%d @@\n", h.fromStart, h.fromCount, h.toStart, h.toCount); err != nil {
+		return err
+	}
+	for _, l := range h.lines {
+		if _, err := fmt.Fprintf(writer, "%c%s\n", l.op, l.text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func merge(x, y map[string]string) []line {
+	keys := make([]string, 0, len(x)+len(y))
+	for key := range x {
+		keys = append(keys, key)
+	}
+	for key := range y {
+		if _, ok := x[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var lines []line
+	for _, key := range keys {
+		xv, xok := x[key]
+		yv, yok := y[key]
+		switch {
+		case xok && yok && xv == yv:
+			lines = append(lines, line{op: ' ', text: key + ": " + xv})
+		default:
+			if xok {
+				lines = append(lines, line{op: '-', text: key + ": " + xv})
+			}
+			if yok {
+				lines = append(lines, line{op: '+', text: key + ": " + yv})
+			}
+		}
+	}
+	return lines
+}
+
+func hunks(lines []line, context int) []hunk {
+	var hs []hunk
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].op == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				end = min(end+context, len(lines))
+				break
+			}
+			end = next
+		}
+
+		h := hunk{lines: lines[start:end]}
+		h.fromStart, h.toStart = 1, 1
+		for _, l := range lines[:start] {
+			if l.op != '+' {
+				h.fromStart++
+			}
+			if l.op != '-' {
+				h.toStart++
+			}
+		}
+		for _, l := range h.lines {
+			if l.op != '+' {
+				h.fromCount++
+			}
+			if l.op != '-' {
+				h.toCount++
+			}
+		}
+		if h.fromCount == 0 {
+			h.fromStart--
+		}
+		if h.toCount == 0 {
+			h.toStart--
+		}
+
+		hs = append(hs, h)
+		i = end
+	}
+	return hs
+}
+
+func flatten(value any) (map[string]string, error) {
+	fields := map[string]string{}
+	if value == nil {
+		return fields, nil
+	}
+
+	doc, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var walk func(path string, val any) error
+	walk = func(path string, val any) error {
+		switch v := val.(type) {
+		case map[string]any:
+			if len(v) == 0 && path != "" {
+				fields[path] = "{}"
+			}
+			for key, child := range v {
+				p := key
+				if path != "" {
+					p = path + "." + key
+				}
+				if err := walk(p, child); err != nil {
+					return err
+				}
+			}
+		case []any:
+			if len(v) == 0 {
+				fields[path] = "[]"
+			}
+			for i, child := range v {
+				if err := walk(path+"["+strconv.Itoa(i)+"]", child); err != nil {
+					return err
+				}
+			}
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			fields[path] = string(data)
+		}
+		return nil
+	}
+
+	if err := walk("", doc); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,52 @@
+package fmt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		from := map[string]any{"kind": "snippet", "name": "foo", "code": "a", "ports": map[string]any{"out": []any{map[string]any{"name": "bar", "port": "in"}}}}
+		to := map[string]any{"kind": "snippet", "name": "foo", "code": "b", "ports": map[string]any{"out": []any{map[string]any{"name": "baz", "port": "in"}}}}
+
+		ok, err := Diff(&buf, from, to, "stored", "local")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "--- stored\n+++ local\n@@ -1,5 +1,5 @@\n-code: \"a\"\n+code: \"b\"\n kind: \"snippet\"\n name: \"foo\"\n-ports.out[0].name: \"bar\"\n+ports.out[0].name: \"baz\"\n ports.out[0].port: \"in\"\n", buf.String())
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		ok, err := Diff(&buf, nil, map[string]any{"name": "foo"}, "/dev/null", "local")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "--- /dev/null\n+++ local\n@@ -0,0 +1,1 @@\n+name: \"foo\"\n", buf.String())
+	})
+
+	t.Run("Hunks", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		from := map[string]any{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9}
+		to := map[string]any{"a": 0, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 0}
+
+		ok, err := Diff(&buf, from, to, "stored", "local")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "--- stored\n+++ local\n@@ -1,4 +1,4 @@\n-a: 1\n+a: 0\n b: 2\n c: 3\n d: 4\n@@ -6,4 +6,4 @@\n f: 6\n g: 7\n h: 8\n-i: 9\n+i: 0\n", buf.String())
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		ok, err := Diff(&buf, map[string]any{"name": "foo"}, map[string]any{"name": "foo"}, "stored", "local")
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,171 @@
+package fmt
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JSONPath is a compiled JSONPath expression such as {.ports.out[0].name} or $.annotations['team'].
+type JSONPath struct {
+	steps []step
+}
+
+type step struct {
+	key      string
+	index    int
+	indexed  bool
+	wildcard bool
+}
+
+var ErrInvalidJSONPath = errors.New("invalid jsonpath")
+
+// CompileJSONPath parses the expression into a JSONPath. Surrounding braces and a leading $ are optional.
+func CompileJSONPath(expr string) (*JSONPath, error) {
+	path := strings.TrimSpace(expr)
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+
+	var steps []step
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			j := i + 1
+			for j < len(path) && path[j] != '.' && path[j] != '[' {
+				j++
+			}
+			switch key := path[i+1 : j]; key {
+			case "":
+				if j < len(path) {
+					return nil, errors.WithMessagef(ErrInvalidJSONPath, "%q", expr)
+				}
+			case "*":
+				steps = append(steps, step{wildcard: true})
+			default:
+				steps = append(steps, step{key: key})
+			}
+			i = j
+		case '[':
+			j := strings.IndexByte(path[i:], ']')
+			if j < 0 {
+				return nil, errors.WithMessagef(ErrInvalidJSONPath, "%q", expr)
+			}
+			token := strings.TrimSpace(path[i+1 : i+j])
+			i += j + 1
+
+			switch {
+			case token == "*":
+				steps = append(steps, step{wildcard: true})
+			case len(token) >= 2 && (token[0] == '\'' || token[0] == '"') && token[len(token)-1] == token[0]:
+				steps = append(steps, step{key: token[1 : len(token)-1]})
+			default:
+				index, err := strconv.Atoi(token)
+				if err != nil {
+					return nil, errors.WithMessagef(ErrInvalidJSONPath, "%q", expr)
+				}
+				steps = append(steps, step{index: index, indexed: true})
+			}
+		default:
+			return nil, errors.WithMessagef(ErrInvalidJSONPath, "%q", expr)
+		}
+	}
+	return &JSONPath{steps: steps}, nil
+}
+
+// Find returns the values selected by the path in the document.
+func (p *JSONPath) Find(doc any) []any {
+	values := []any{doc}
+	for _, s := range p.steps {
+		var next []any
+		for _, val := range values {
+			next = append(next, s.apply(val)...)
+		}
+		values = next
+	}
+	return values
+}
+
+// Set replaces the values selected by the path in a document decoded from JSON and returns the document.
+func (p *JSONPath) Set(doc, value any) any {
+	return set(doc, p.steps, value)
+}
+
+func set(val any, steps []step, value any) any {
+	if len(steps) == 0 {
+		return value
+	}
+
+	s, rest := steps[0], steps[1:]
+	switch v := val.(type) {
+	case map[string]any:
+		if s.wildcard {
+			for key, child := range v {
+				v[key] = set(child, rest, value)
+			}
+		} else if child, ok := v[s.key]; ok && !s.indexed {
+			v[s.key] = set(child, rest, value)
+		}
+	case []any:
+		if s.wildcard {
+			for i, child := range v {
+				v[i] = set(child, rest, value)
+			}
+		} else if s.indexed {
+			index := s.index
+			if index < 0 {
+				index += len(v)
+			}
+			if index >= 0 && index < len(v) {
+				v[index] = set(v[index], rest, value)
+			}
+		}
+	}
+	return val
+}
+
+func (s step) apply(val any) []any {
+	v := reflect.ValueOf(val)
+	switch {
+	case s.wildcard:
+		var children []any
+		switch v.Kind() {
+		case reflect.Map:
+			keys := v.MapKeys()
+			slices.SortFunc(keys, func(x, y reflect.Value) int {
+				return strings.Compare(x.String(), y.String())
+			})
+			for _, key := range keys {
+				children = append(children, v.MapIndex(key).Interface())
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				children = append(children, v.Index(i).Interface())
+			}
+		}
+		return children
+	case s.indexed:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil
+		}
+		index := s.index
+		if index < 0 {
+			index += v.Len()
+		}
+		if index < 0 || index >= v.Len() {
+			return nil
+		}
+		return []any{v.Index(index).Interface()}
+	default:
+		if v.Kind() != reflect.Map {
+			return nil
+		}
+		child := v.MapIndex(reflect.ValueOf(s.key))
+		if !child.IsValid() {
+			return nil
+		}
+		return []any{child.Interface()}
+	}
+}
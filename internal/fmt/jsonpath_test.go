@@ -0,0 +1,79 @@
+package fmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath_Find(t *testing.T) {
+	doc := map[string]any{
+		"name": "foo",
+		"annotations": map[string]any{
+			"team":            "payments",
+			"example.com/env": "dev",
+		},
+		"ports": map[string]any{
+			"out": []any{
+				map[string]any{"name": "bar", "port": "in"},
+				map[string]any{"name": "baz", "port": "in"},
+			},
+		},
+	}
+
+	tests := []struct {
+		expr     string
+		expected []any
+	}{
+		{expr: "{.name}", expected: []any{"foo"}},
+		{expr: "$.annotations.team", expected: []any{"payments"}},
+		{expr: ".annotations['example.com/env']", expected: []any{"dev"}},
+		{expr: "{.ports.out[0].name}", expected: []any{"bar"}},
+		{expr: "{.ports.out[-1].name}", expected: []any{"baz"}},
+		{expr: "{.ports.out[*].name}", expected: []any{"bar", "baz"}},
+		{expr: "{.annotations.*}", expected: []any{"dev", "payments"}},
+		{expr: "{.unknown}", expected: nil},
+		{expr: "{.}", expected: []any{doc}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			path, err := CompileJSONPath(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, path.Find(doc))
+		})
+	}
+}
+
+func TestJSONPath_Set(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected any
+	}{
+		{expr: "{.id}", expected: map[string]any{"id": "*", "items": []any{map[string]any{"id": 1}, map[string]any{"id": 2}}}},
+		{expr: "{.items[*].id}", expected: map[string]any{"id": "foo", "items": []any{map[string]any{"id": "*"}, map[string]any{"id": "*"}}}},
+		{expr: "{.items[-1].id}", expected: map[string]any{"id": "foo", "items": []any{map[string]any{"id": 1}, map[string]any{"id": "*"}}}},
+		{expr: "{.unknown}", expected: map[string]any{"id": "foo", "items": []any{map[string]any{"id": 1}, map[string]any{"id": 2}}}},
+		{expr: "{.}", expected: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			doc := map[string]any{
+				"id":    "foo",
+				"items": []any{map[string]any{"id": 1}, map[string]any{"id": 2}},
+			}
+
+			path, err := CompileJSONPath(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, path.Set(doc, "*"))
+		})
+	}
+}
+
+func TestCompileJSONPath(t *testing.T) {
+	for _, expr := range []string{"{.ports[}", "{name}", "{.ports[x]}", "{..name}"} {
+		_, err := CompileJSONPath(expr)
+		require.ErrorIs(t, err, ErrInvalidJSONPath, expr)
+	}
+}
@@ -0,0 +1,185 @@
+package fmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/meta"
+)
+
+// Printer writes values to an io.Writer in a specific output format.
+type Printer interface {
+	Write(value any) error
+}
+
+// Supported output formats.
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatName     = "name"
+	FormatJSONPath = "jsonpath"
+)
+
+type jsonPrinter struct {
+	writer io.Writer
+}
+
+type yamlPrinter struct {
+	writer io.Writer
+}
+
+type namePrinter struct {
+	writer io.Writer
+}
+
+type jsonPathPrinter struct {
+	writer io.Writer
+	path   *JSONPath
+}
+
+var (
+	_ Printer = (*Writer)(nil)
+	_ Printer = (*jsonPrinter)(nil)
+	_ Printer = (*yamlPrinter)(nil)
+	_ Printer = (*namePrinter)(nil)
+	_ Printer = (*jsonPathPrinter)(nil)
+)
+
+// NewPrinter creates a Printer for the output format, which is one of table, json, yaml, name or jsonpath=<expr>.
+func NewPrinter(writer io.Writer, output string) (Printer, error) {
+	format, expr, _ := strings.Cut(output, "=")
+	switch format {
+	case "", FormatTable:
+		return NewWriter(writer), nil
+	case FormatJSON:
+		return &jsonPrinter{writer: writer}, nil
+	case FormatYAML:
+		return &yamlPrinter{writer: writer}, nil
+	case FormatName:
+		return &namePrinter{writer: writer}, nil
+	case FormatJSONPath:
+		path, err := CompileJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathPrinter{writer: writer, path: path}, nil
+	default:
+		return nil, errors.WithMessagef(encoding.ErrUnsupportedValue, "output format %q", output)
+	}
+}
+
+// Write encodes the value as indented JSON.
+func (p *jsonPrinter) Write(value any) error {
+	encoder := json.NewEncoder(p.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// Write encodes the value as YAML.
+func (p *yamlPrinter) Write(value any) error {
+	doc, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(p.writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// Write prints the namespaced name of each element, one per line.
+func (p *namePrinter) Write(value any) error {
+	elements, err := elementsOf(value)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		name, _ := element[meta.KeyName].(string)
+		if name == "" {
+			name, _ = element[meta.KeyID].(string)
+		}
+		namespace, _ := element[meta.KeyNamespace].(string)
+
+		if _, err := fmt.Fprintf(p.writer, "%s/%s\n", namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write prints the values selected by the path in each element, one element per line.
+func (p *jsonPathPrinter) Write(value any) error {
+	elements, err := elementsOf(value)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		var fields []string
+		for _, val := range p.path.Find(element) {
+			switch v := val.(type) {
+			case string:
+				fields = append(fields, v)
+			case map[string]any, []any:
+				data, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, string(data))
+			default:
+				fields = append(fields, fmt.Sprint(v))
+			}
+		}
+
+		if _, err := fmt.Fprintln(p.writer, strings.Join(fields, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func normalize(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func elementsOf(value any) ([]map[string]any, error) {
+	doc, err := normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		elements := make([]map[string]any, 0, len(v))
+		for _, element := range v {
+			if m, ok := element.(map[string]any); ok {
+				elements = append(elements, m)
+			}
+		}
+		return elements, nil
+	default:
+		return nil, nil
+	}
+}
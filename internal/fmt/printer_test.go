@@ -0,0 +1,50 @@
+package fmt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrinter(t *testing.T) {
+	input := []map[string]any{
+		{"namespace": "default", "name": "foo", "annotations": map[string]any{"team": "payments"}},
+		{"namespace": "default", "id": "01908c74-8b22-7cbf-a475-6b6bc871b01a"},
+	}
+
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{output: FormatJSON, expected: "[\n  {\n    \"annotations\": {\n      \"team\": \"payments\"\n    },\n    \"name\": \"foo\",\n    \"namespace\": \"default\"\n  },\n  {\n    \"id\": \"01908c74-8b22-7cbf-a475-6b6bc871b01a\",\n    \"namespace\": \"default\"\n  }\n]\n"},
+		{output: FormatYAML, expected: "- annotations:\n    team: payments\n  name: foo\n  namespace: default\n- id: 01908c74-8b22-7cbf-a475-6b6bc871b01a\n  namespace: default\n"},
+		{output: FormatName, expected: "default/foo\ndefault/01908c74-8b22-7cbf-a475-6b6bc871b01a\n"},
+		{output: FormatJSONPath + "={.annotations.team}", expected: "payments\n\n"},
+		{output: FormatJSONPath + "={.annotations}", expected: "{\"team\":\"payments\"}\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			printer, err := NewPrinter(&buf, tt.output)
+			require.NoError(t, err)
+
+			err = printer.Write(input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, buf.String())
+		})
+	}
+
+	t.Run(FormatTable, func(t *testing.T) {
+		printer, err := NewPrinter(&bytes.Buffer{}, FormatTable)
+		require.NoError(t, err)
+		require.IsType(t, &Writer{}, printer)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := NewPrinter(&bytes.Buffer{}, "unknown")
+		require.Error(t, err)
+	})
+}
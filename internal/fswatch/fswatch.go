@@ -0,0 +1,138 @@
+package fswatch
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+)
+
+// Stamp identifies a version of a file by its modification time and size.
+type Stamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+type notifier struct {
+	watcher *fsnotify.Watcher
+	dirs    map[string]bool
+	ticker  *time.Ticker
+}
+
+// Interval is the interval at which files are polled when the file system cannot notify changes.
+var Interval = 500 * time.Millisecond
+
+// Watch calls changed whenever any of the files returned by files is modified, added or removed, until the context is done.
+// The current state of the files is recorded before Watch returns, and changes are watched in the background.
+// Files on the OS file system are watched through file system notifications and others are polled at Interval.
+// The files are listed again after every change, so that files added to the set are watched as well.
+func Watch(ctx context.Context, fs afero.Fs, files func() []string, changed func()) {
+	names := files()
+	prev := Snapshot(fs, names)
+
+	if _, ok := fs.(*afero.OsFs); ok {
+		if w, err := fsnotify.NewWatcher(); err == nil {
+			n := &notifier{watcher: w, dirs: map[string]bool{}}
+			n.add(names)
+			go n.run(ctx, fs, files, prev, changed)
+			return
+		}
+	}
+	go poll(ctx, fs, files, prev, changed)
+}
+
+// Snapshot returns the stamps of the files. Missing files have a zero stamp.
+func Snapshot(fs afero.Fs, files []string) map[string]Stamp {
+	stamps := make(map[string]Stamp, len(files))
+	for _, name := range files {
+		info, err := fs.Stat(name)
+		if err != nil {
+			stamps[name] = Stamp{}
+			continue
+		}
+		stamps[name] = Stamp{ModTime: info.ModTime(), Size: info.Size()}
+	}
+	return stamps
+}
+
+func poll(ctx context.Context, fs afero.Fs, files func() []string, prev map[string]Stamp, changed func()) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if next := Snapshot(fs, files()); !maps.Equal(prev, next) {
+				prev = next
+				changed()
+			}
+		}
+	}
+}
+
+// add watches the directories of the files, so that files replaced by renames, such as mounted secrets, are still seen.
+// Directories that do not exist yet cannot be watched, so they are polled until they appear.
+func (n *notifier) add(names []string) {
+	ok := true
+	for _, name := range names {
+		dir := filepath.Dir(name)
+		if n.dirs[dir] {
+			continue
+		}
+		if err := n.watcher.Add(dir); err != nil {
+			ok = false
+			continue
+		}
+		n.dirs[dir] = true
+	}
+
+	if ok && n.ticker != nil {
+		n.ticker.Stop()
+		n.ticker = nil
+	} else if !ok && n.ticker == nil {
+		n.ticker = time.NewTicker(Interval)
+	}
+}
+
+func (n *notifier) run(ctx context.Context, fs afero.Fs, files func() []string, prev map[string]Stamp, changed func()) {
+	defer func() {
+		if n.ticker != nil {
+			n.ticker.Stop()
+		}
+		_ = n.watcher.Close()
+	}()
+
+	for {
+		var tick <-chan time.Time
+		if n.ticker != nil {
+			tick = n.ticker.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-n.watcher.Events:
+			if !ok {
+				return
+			}
+		case _, ok := <-n.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-tick:
+		}
+
+		names := files()
+		n.add(names)
+
+		if next := Snapshot(fs, names); !maps.Equal(prev, next) {
+			prev = next
+			changed()
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package fswatch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("Notify", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		fs := afero.NewOsFs()
+		filename := filepath.Join(t.TempDir(), "secret")
+
+		err := afero.WriteFile(fs, filename, []byte(faker.Password()), 0600)
+		require.NoError(t, err)
+
+		changed := make(chan struct{}, 1)
+		Watch(ctx, fs, func() []string { return []string{filename} }, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+
+		err = afero.WriteFile(fs, filename, []byte(faker.Password()+faker.Password()), 0600)
+		require.NoError(t, err)
+
+		select {
+		case <-changed:
+		case <-time.After(Interval / 2):
+			require.Fail(t, "change not notified")
+		}
+	})
+
+	t.Run("Poll", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+		filename := "/secret"
+
+		changed := make(chan struct{}, 1)
+		Watch(ctx, fs, func() []string { return []string{filename} }, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+
+		err := afero.WriteFile(fs, filename, []byte(faker.Password()), 0600)
+		require.NoError(t, err)
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// Data holds the values substituted into the templates of a layout.
+type Data struct {
+	Name      string
+	Module    string
+	Kind      string
+	Type      string
+	GoVersion string
+	Version   string
+}
+
+// Scaffolder generates files from embedded layouts.
+type Scaffolder struct {
+	fs afero.Fs
+}
+
+const (
+	LayoutProject = "project"
+	LayoutPlugin  = "plugin"
+)
+
+var (
+	ErrUnknownLayout = errors.New("unknown layout")
+	ErrFileExists    = errors.New("file already exists")
+)
+
+const (
+	root        = "templates"
+	suffix      = ".tmpl"
+	placeholder = "__name__"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// NewScaffolder creates a new Scaffolder writing files to the given file system.
+func NewScaffolder(fs afero.Fs) *Scaffolder {
+	return &Scaffolder{fs: fs}
+}
+
+// Generate renders the layout into dir and returns the paths of the created files.
+// Nothing is written if any of the files already exists.
+func (s *Scaffolder) Generate(layout, dir string, data Data) ([]string, error) {
+	base := path.Join(root, layout)
+	if _, err := fs.Stat(templates, base); err != nil {
+		return nil, errors.WithMessagef(ErrUnknownLayout, "%s", layout)
+	}
+
+	files := map[string][]byte{}
+	var names []string
+	if err := fs.WalkDir(templates, base, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(name, base+"/"), suffix)
+		rel = filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(rel, placeholder, data.Name)))
+
+		text, err := fs.ReadFile(templates, name)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := template.New(name).Delims("<%", "%>").Option("missingkey=error").Parse(string(text))
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+
+		files[rel] = buf.Bytes()
+		names = append(names, rel)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if ok, err := afero.Exists(s.fs, name); err != nil {
+			return nil, err
+		} else if ok {
+			return nil, errors.WithMessagef(ErrFileExists, "%s", name)
+		}
+	}
+
+	for _, name := range names {
+		if err := s.fs.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return nil, err
+		}
+		if err := afero.WriteFile(s.fs, name, files[name], 0644); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,63 @@
+package scaffold
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffolder_Generate(t *testing.T) {
+	t.Run("Project", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		names, err := NewScaffolder(fs).Generate(LayoutProject, "app", Data{})
+		require.NoError(t, err)
+		require.Contains(t, names, filepath.Join("app", ".uniflow.toml"))
+
+		for _, name := range names {
+			ok, err := afero.Exists(fs, name)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("Plugin", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		names, err := NewScaffolder(fs).Generate(LayoutPlugin, "hello", Data{
+			Name:      "hello",
+			Module:    "example.com/hello",
+			Kind:      "hello",
+			Type:      "Hello",
+			GoVersion: "1.24",
+		})
+		require.NoError(t, err)
+		require.Contains(t, names, filepath.Join("hello", "cmd", "hello", "main.go"))
+		require.Contains(t, names, filepath.Join("hello", "pkg", "node", "hello_test.go"))
+
+		data, err := afero.ReadFile(fs, filepath.Join("hello", "go.mod"))
+		require.NoError(t, err)
+		require.Equal(t, "module example.com/hello\n\ngo 1.24\n", string(data))
+	})
+
+	t.Run("FileExists", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := afero.WriteFile(fs, filepath.Join("app", ".uniflow.toml"), nil, 0644)
+		require.NoError(t, err)
+
+		_, err = NewScaffolder(fs).Generate(LayoutProject, "app", Data{})
+		require.ErrorIs(t, err, ErrFileExists)
+
+		ok, err := afero.Exists(fs, filepath.Join("app", "specs", "router.yaml"))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("UnknownLayout", func(t *testing.T) {
+		_, err := NewScaffolder(afero.NewMemMapFs()).Generate("unknown", ".", Data{})
+		require.ErrorIs(t, err, ErrUnknownLayout)
+	})
+}
@@ -0,0 +1,147 @@
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// typed lists the functions whose results keep their type when they end the only action of a field.
+var typed = []string{"int", "float", "bool", "fromJson"}
+
+var funcs = template.FuncMap{
+	"default":  defaultFunc,
+	"required": required,
+	"toJson":   toJSON,
+	"fromJson": fromJSON,
+	"b64enc":   b64enc,
+	"b64dec":   b64dec,
+	"int":      toInt,
+	"float":    toFloat,
+	"bool":     toBool,
+	"env":      os.Getenv,
+	"sha256":   sha256sum,
+}
+
+var ErrRequired = errors.New("required value is missing")
+
+func defaultFunc(def, val any) any {
+	if empty(val) {
+		return def
+	}
+	return val
+}
+
+func required(msg string, val any) (any, error) {
+	if empty(val) {
+		return nil, errors.WithMessage(ErrRequired, msg)
+	}
+	return val, nil
+}
+
+func toJSON(val any) (string, error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func fromJSON(val string) (any, error) {
+	var data any
+	if err := json.Unmarshal([]byte(val), &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func b64enc(val string) string {
+	return base64.StdEncoding.EncodeToString([]byte(val))
+}
+
+func b64dec(val string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(val)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func toInt(val any) (int, error) {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int(v.Float()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return strconv.Atoi(strings.TrimSpace(v.String()))
+	default:
+		return 0, errors.Errorf("cannot convert %T to int", val)
+	}
+}
+
+func toFloat(val any) (float64, error) {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+	default:
+		return 0, errors.Errorf("cannot convert %T to float", val)
+	}
+}
+
+func toBool(val any) (bool, error) {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return strconv.ParseBool(strings.TrimSpace(v.String()))
+	case reflect.Invalid:
+		return false, nil
+	default:
+		return !v.IsZero(), nil
+	}
+}
+
+func sha256sum(val string) string {
+	sum := sha256.Sum256([]byte(val))
+	return hex.EncodeToString(sum[:])
+}
+
+func empty(val any) bool {
+	v := reflect.ValueOf(val)
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
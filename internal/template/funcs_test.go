@@ -0,0 +1,47 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncs(t *testing.T) {
+	password := faker.Password()
+	t.Setenv("UNIFLOW_TEST_PASSWORD", password)
+
+	testCases := []struct {
+		template string
+		data     any
+		expected any
+	}{
+		{template: `{{ .value | default "fallback" }}`, data: map[string]any{}, expected: "fallback"},
+		{template: `{{ .value | default "fallback" }}`, data: map[string]any{"value": "value"}, expected: "value"},
+		{template: `{{ toJson . }}`, data: map[string]any{"key": "value"}, expected: `{"key":"value"}`},
+		{template: `{{ b64enc . }}`, data: "hello", expected: "aGVsbG8="},
+		{template: `{{ b64dec . }}`, data: "aGVsbG8=", expected: "hello"},
+		{template: `{{ float . }}`, data: "1.5", expected: 1.5},
+		{template: `{{ bool . }}`, data: "true", expected: true},
+		{template: `{{ int . }}`, data: 3.0, expected: 3},
+		{template: `{{ env "UNIFLOW_TEST_PASSWORD" }}`, data: nil, expected: password},
+		{template: `{{ sha256 . }}`, data: "hello", expected: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.template, func(t *testing.T) {
+			value, err := Execute(tc.template, tc.data)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestFuncs_Required(t *testing.T) {
+	_, err := Execute(`{{ required "value is required" .value }}`, map[string]any{})
+	require.ErrorIs(t, err, ErrRequired)
+
+	value, err := Execute(`{{ required "value is required" .value }}`, map[string]any{"value": "value"})
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"bytes"
+	"fmt"
 	"reflect"
 	"text/template"
 )
@@ -15,8 +16,10 @@ type valueNode struct {
 }
 
 type templateNode struct {
+	path     string
 	typ      reflect.Type
 	template *template.Template
+	typed    bool
 }
 
 type sliceNode struct {
@@ -34,9 +37,26 @@ func (v *valueNode) execute(_ any) (any, error) {
 }
 
 func (t *templateNode) execute(data any) (any, error) {
+	tmpl := t.template
+
+	var result any
+	if t.typed {
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return nil, &Error{Path: t.path, Err: err}
+		}
+		tmpl = clone.Funcs(template.FuncMap{capture: func(val any) string {
+			result = val
+			return ""
+		}})
+	}
+
 	var buf bytes.Buffer
-	if err := t.template.Execute(&buf, data); err != nil {
-		return nil, err
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, &Error{Path: t.path, Err: err}
+	}
+	if t.typed {
+		return result, nil
 	}
 	return reflect.ValueOf(buf.String()).Convert(t.typ).Interface(), nil
 }
@@ -48,7 +68,7 @@ func (s *sliceNode) execute(data any) (any, error) {
 		if err != nil {
 			return nil, err
 		}
-		values = reflect.Append(values, reflect.ValueOf(value))
+		values = reflect.Append(values, valueOf(value, s.typ.Elem()))
 	}
 	return values.Interface(), nil
 }
@@ -64,7 +84,18 @@ func (m *mapNode) execute(data any) (any, error) {
 		if err != nil {
 			return nil, err
 		}
-		values.SetMapIndex(reflect.ValueOf(keyRes), reflect.ValueOf(value))
+		values.SetMapIndex(reflect.ValueOf(keyRes), valueOf(value, m.typ.Elem()))
 	}
 	return values.Interface(), nil
 }
+
+func valueOf(val any, typ reflect.Type) reflect.Value {
+	if val == nil {
+		return reflect.Zero(typ)
+	}
+	v := reflect.ValueOf(val)
+	if !v.Type().AssignableTo(typ) && typ.Kind() == reflect.String {
+		return reflect.ValueOf(fmt.Sprint(val)).Convert(typ)
+	}
+	return v
+}
@@ -1,8 +1,11 @@
 package template
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
 	"text/template"
+	"text/template/parse"
 )
 
 // Template represents a parsed template with a root node.
@@ -11,6 +14,16 @@ type Template struct {
 	root node
 }
 
+// Error describes a template that failed at a path of the parsed value.
+type Error struct {
+	Path string
+	Err  error
+}
+
+const capture = "_capture"
+
+var _ error = (*Error)(nil)
+
 // Execute creates a new template, parses the given value, and executes it with the provided data.
 func Execute(value, data any) (any, error) {
 	tmpl, err := New("").Parse(value)
@@ -29,7 +42,7 @@ func New(name string) *Template {
 
 // Parse parses the provided value into the template's root node.
 func (t *Template) Parse(value any) (*Template, error) {
-	root, err := t.parse(reflect.ValueOf(value))
+	root, err := t.parse(reflect.ValueOf(value), "")
 	if err != nil {
 		return nil, err
 	}
@@ -45,18 +58,31 @@ func (t *Template) Execute(data any) (any, error) {
 	return t.root.execute(data)
 }
 
-func (t *Template) parse(val reflect.Value) (node, error) {
+// Error returns the error message prefixed with the path that failed.
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (t *Template) parse(val reflect.Value, path string) (node, error) {
 	switch val.Kind() {
 	case reflect.String:
-		tmpl, err := template.New(t.name).Parse(val.String())
+		tmpl, err := template.New(t.name).Funcs(funcs).Funcs(template.FuncMap{capture: fmt.Sprint}).Parse(val.String())
 		if err != nil {
-			return nil, err
+			return nil, &Error{Path: path, Err: err}
 		}
-		return &templateNode{typ: val.Type(), template: tmpl}, nil
+		return &templateNode{path: path, typ: val.Type(), template: tmpl, typed: captures(tmpl)}, nil
 	case reflect.Slice, reflect.Array:
 		children := make([]node, val.Len())
 		for i := 0; i < val.Len(); i++ {
-			child, err := t.parse(reflect.ValueOf(val.Index(i).Interface()))
+			child, err := t.parse(reflect.ValueOf(val.Index(i).Interface()), fmt.Sprintf("%s[%d]", path, i))
 			if err != nil {
 				return nil, err
 			}
@@ -66,11 +92,16 @@ func (t *Template) parse(val reflect.Value) (node, error) {
 	case reflect.Map:
 		children := make(map[node]node)
 		for _, key := range val.MapKeys() {
-			k, err := t.parse(reflect.ValueOf(key.Interface()))
+			name := fmt.Sprint(key.Interface())
+			if path != "" {
+				name = path + "." + name
+			}
+
+			k, err := t.parse(reflect.ValueOf(key.Interface()), name)
 			if err != nil {
 				return nil, err
 			}
-			v, err := t.parse(reflect.ValueOf(val.MapIndex(key).Interface()))
+			v, err := t.parse(reflect.ValueOf(val.MapIndex(key).Interface()), name)
 			if err != nil {
 				return nil, err
 			}
@@ -81,3 +112,29 @@ func (t *Template) parse(val reflect.Value) (node, error) {
 		return &valueNode{value: val.Interface()}, nil
 	}
 }
+
+// captures rewrites a template made of a single action ending with a typed function so that
+// the result of the action is captured instead of printed, and reports whether it did.
+func captures(tmpl *template.Template) bool {
+	if tmpl.Tree == nil || tmpl.Tree.Root == nil || len(tmpl.Tree.Root.Nodes) != 1 {
+		return false
+	}
+	action, ok := tmpl.Tree.Root.Nodes[0].(*parse.ActionNode)
+	if !ok || action.Pipe == nil || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) == 0 {
+		return false
+	}
+
+	last := action.Pipe.Cmds[len(action.Pipe.Cmds)-1]
+	if len(last.Args) == 0 {
+		return false
+	}
+	ident, ok := last.Args[0].(*parse.IdentifierNode)
+	if !ok || !slices.Contains(typed, ident.Ident) {
+		return false
+	}
+
+	cmd := &parse.CommandNode{NodeType: parse.NodeCommand, Pos: last.Pos}
+	cmd.Args = []parse.Node{parse.NewIdentifier(capture).SetTree(tmpl.Tree).SetPos(last.Pos)}
+	action.Pipe.Cmds = append(action.Pipe.Cmds, cmd)
+	return true
+}
@@ -42,3 +42,49 @@ func TestTemplate_ParseAndExecute(t *testing.T) {
 		require.Equal(t, map[any]any{"key1": "map value", "key2": 456}, value)
 	})
 }
+
+func TestTemplate_Typed(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		value, err := Execute(map[string]any{"port": "{{ int .PORT }}"}, map[string]any{"PORT": "8000"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"port": 8000}, value)
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		value, err := Execute("{{ .PORT | default \"8080\" | int }}", map[string]any{})
+		require.NoError(t, err)
+		require.Equal(t, 8080, value)
+	})
+
+	t.Run("fromJson", func(t *testing.T) {
+		value, err := Execute("{{ fromJson .DATA }}", map[string]any{"DATA": `{"key":"value"}`})
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"key": "value"}, value)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		value, err := Execute("port {{ int .PORT }}", map[string]any{"PORT": "8000"})
+		require.NoError(t, err)
+		require.Equal(t, "port 8000", value)
+	})
+
+	t.Run("typed map", func(t *testing.T) {
+		value, err := Execute(map[string]string{"port": "{{ int .PORT }}"}, map[string]any{"PORT": "8000"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"port": "8000"}, value)
+	})
+}
+
+func TestTemplate_Error(t *testing.T) {
+	_, err := Execute(map[string]any{"routes": []any{map[string]any{"port": "{{ int .PORT }}"}}}, map[string]any{"PORT": "invalid"})
+	require.Error(t, err)
+
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "routes[0].port", e.Path)
+	require.Contains(t, err.Error(), "routes[0].port: ")
+
+	_, err = Execute(map[string]any{"key": "{{ .value "}, nil)
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "key", e.Path)
+}
@@ -69,6 +69,13 @@ func (a *EncodeAssembler[S, T]) Compile(typ reflect.Type) (Encoder[S, T], error)
 		return enc.(Encoder[S, T]), nil
 	}
 
+	// Register a placeholder first so that recursive types resolve to the encoder being compiled.
+	lazy := &lazyEncoder[S, T]{done: make(chan struct{})}
+	if enc, loaded := a.encoders.LoadOrStore(typ, lazy); loaded {
+		return enc.(Encoder[S, T]), nil
+	}
+	defer close(lazy.done)
+
 	encoders := make([]Encoder[S, T], 0, len(a.compilers))
 	for _, compiler := range a.compilers {
 		if enc, err := compiler.Compile(typ); err == nil {
@@ -76,6 +83,7 @@ func (a *EncodeAssembler[S, T]) Compile(typ reflect.Type) (Encoder[S, T], error)
 		}
 	}
 	if len(encoders) == 0 {
+		a.encoders.Delete(typ)
 		return nil, errors.WithStack(ErrUnsupportedType)
 	}
 
@@ -89,6 +97,7 @@ func (a *EncodeAssembler[S, T]) Compile(typ reflect.Type) (Encoder[S, T], error)
 		}
 		enc = group
 	}
+	lazy.encoder = enc
 	a.encoders.Store(typ, enc)
 	return enc, nil
 }
@@ -135,6 +144,13 @@ func (a *DecodeAssembler[S, T]) Compile(typ reflect.Type) (Decoder[S, unsafe.Poi
 		return dec.(Decoder[S, unsafe.Pointer]), nil
 	}
 
+	// Register a placeholder first so that recursive types resolve to the decoder being compiled.
+	lazy := &lazyDecoder[S]{done: make(chan struct{})}
+	if dec, loaded := a.decoders.LoadOrStore(typ, lazy); loaded {
+		return dec.(Decoder[S, unsafe.Pointer]), nil
+	}
+	defer close(lazy.done)
+
 	decoders := make([]Decoder[S, unsafe.Pointer], 0, len(a.compilers))
 	for _, compiler := range a.compilers {
 		if dec, err := compiler.Compile(typ); err == nil {
@@ -142,6 +158,7 @@ func (a *DecodeAssembler[S, T]) Compile(typ reflect.Type) (Decoder[S, unsafe.Poi
 		}
 	}
 	if len(decoders) == 0 {
+		a.decoders.Delete(typ)
 		return nil, errors.WithStack(ErrUnsupportedType)
 	}
 
@@ -155,6 +172,41 @@ func (a *DecodeAssembler[S, T]) Compile(typ reflect.Type) (Decoder[S, unsafe.Poi
 		}
 		dec = group
 	}
+	lazy.decoder = dec
 	a.decoders.Store(typ, dec)
 	return dec, nil
 }
+
+type lazyEncoder[S, T any] struct {
+	encoder Encoder[S, T]
+	done    chan struct{}
+}
+
+type lazyDecoder[S any] struct {
+	decoder Decoder[S, unsafe.Pointer]
+	done    chan struct{}
+}
+
+var (
+	_ Encoder[any, any]            = (*lazyEncoder[any, any])(nil)
+	_ Decoder[any, unsafe.Pointer] = (*lazyDecoder[any])(nil)
+)
+
+// Encode waits for the compilation to finish and delegates to the compiled encoder.
+func (e *lazyEncoder[S, T]) Encode(source S) (T, error) {
+	<-e.done
+	if e.encoder == nil {
+		var zero T
+		return zero, errors.WithStack(ErrUnsupportedType)
+	}
+	return e.encoder.Encode(source)
+}
+
+// Decode waits for the compilation to finish and delegates to the compiled decoder.
+func (d *lazyDecoder[S]) Decode(source S, target unsafe.Pointer) error {
+	<-d.done
+	if d.decoder == nil {
+		return errors.WithStack(ErrUnsupportedType)
+	}
+	return d.decoder.Decode(source, target)
+}
@@ -51,6 +51,34 @@ func (h *Hook) AddUnloadHook(hook symbol.UnloadHook) bool {
 	return true
 }
 
+// RemoveLoadHook removes a LoadHook function from the Hook.
+func (h *Hook) RemoveLoadHook(hook symbol.LoadHook) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, hk := range h.loadHooks {
+		if hk == hook {
+			h.loadHooks = append(h.loadHooks[:i], h.loadHooks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveUnloadHook removes an UnloadHook function from the Hook.
+func (h *Hook) RemoveUnloadHook(hook symbol.UnloadHook) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, hk := range h.unloadHooks {
+		if hk == hook {
+			h.unloadHooks = append(h.unloadHooks[:i], h.unloadHooks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Load executes all LoadHooks registered in the Hook on the provided symbol.
 func (h *Hook) Load(sb *symbol.Symbol) error {
 	h.mu.RLock()
@@ -30,6 +30,16 @@ func TestHook_LoadHook(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
+
+	require.True(t, hooks.RemoveLoadHook(h))
+	require.False(t, hooks.RemoveLoadHook(h))
+
+	err = hooks.Load(&symbol.Symbol{
+		Spec: &spec.Meta{},
+		Node: n,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
 }
 
 func TestHook_UnloadHook(t *testing.T) {
@@ -52,4 +62,14 @@ func TestHook_UnloadHook(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.Equal(t, 1, count)
+
+	require.True(t, hooks.RemoveUnloadHook(h))
+	require.False(t, hooks.RemoveUnloadHook(h))
+
+	err = hooks.Unload(&symbol.Symbol{
+		Spec: &spec.Meta{},
+		Node: n,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
 }
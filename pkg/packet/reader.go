@@ -92,6 +92,14 @@ func (r *Reader) AddOutboundHook(hook Hook) bool {
 	return true
 }
 
+// Pending returns the number of packets read but not yet answered.
+func (r *Reader) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.writers)
+}
+
 // Read returns the channel for reading packets from the reader.
 func (r *Reader) Read() <-chan *Packet {
 	return r.out
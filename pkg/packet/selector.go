@@ -0,0 +1,22 @@
+package packet
+
+// Selector chooses the linked readers that receive a packet.
+type Selector interface {
+	// Select returns the indexes of the readers the packet is written to.
+	Select(pck *Packet, readers []*Reader) []int
+}
+
+type selector struct {
+	selects func(*Packet, []*Reader) []int
+}
+
+var _ Selector = (*selector)(nil)
+
+// SelectorFunc creates a new Selector using the provided function.
+func SelectorFunc(selects func(*Packet, []*Reader) []int) Selector {
+	return &selector{selects: selects}
+}
+
+func (s *selector) Select(pck *Packet, readers []*Reader) []int {
+	return s.selects(pck, readers)
+}
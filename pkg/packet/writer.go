@@ -14,6 +14,7 @@ type Writer struct {
 	done      bool
 	inbounds  Hooks
 	outbounds Hooks
+	selector  Selector
 	mu        sync.RWMutex
 }
 
@@ -107,6 +108,14 @@ func (w *Writer) AddOutboundHook(hook Hook) bool {
 	return true
 }
 
+// SetSelector sets the selector choosing the readers each packet is written to. A nil selector writes to all readers.
+func (w *Writer) SetSelector(selector Selector) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.selector = selector
+}
+
 // Links returns a list of readers linked to the writer.
 func (w *Writer) Links() []*Reader {
 	w.mu.RLock()
@@ -167,7 +176,7 @@ func (w *Writer) Unlink(reader *Reader) bool {
 	return false
 }
 
-// Write writes a packet to all linked readers and returns the count of successful writes.
+// Write writes a packet to the selected linked readers and returns the count of successful writes.
 func (w *Writer) Write(pck *Packet) int {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -178,9 +187,23 @@ func (w *Writer) Write(pck *Packet) int {
 
 	w.outbounds.Handle(pck)
 
-	count := 0
 	receives := make([]*Packet, len(w.readers))
+	if w.selector != nil {
+		for i := range receives {
+			receives[i] = None
+		}
+		for _, i := range w.selector.Select(pck, append([]*Reader(nil), w.readers...)) {
+			if i >= 0 && i < len(receives) {
+				receives[i] = nil
+			}
+		}
+	}
+
+	count := 0
 	for i, r := range w.readers {
+		if receives[i] != nil {
+			continue
+		}
 		if r.write(New(pck.Payload()), w) {
 			count++
 		} else {
@@ -177,6 +177,41 @@ func TestWriter_Write(t *testing.T) {
 	require.Equal(t, pck2.Payload(), pck4.Payload())
 }
 
+func TestWriter_SetSelector(t *testing.T) {
+	w := NewWriter()
+	defer w.Close()
+
+	r1 := NewReader()
+	defer r1.Close()
+
+	r2 := NewReader()
+	defer r2.Close()
+
+	w.Link(r1)
+	w.Link(r2)
+
+	w.SetSelector(SelectorFunc(func(_ *Packet, _ []*Reader) []int {
+		return []int{1}
+	}))
+
+	pck1 := New(types.NewString(faker.UUIDHyphenated()))
+
+	count := w.Write(pck1)
+	require.Equal(t, 1, count)
+
+	pck2, ok := <-r2.Read()
+	require.True(t, ok)
+	require.Equal(t, pck1.Payload(), pck2.Payload())
+	require.Equal(t, 0, r1.Pending())
+	require.Equal(t, 1, r2.Pending())
+
+	r2.Receive(pck2)
+
+	pck3, ok := <-w.Receive()
+	require.True(t, ok)
+	require.Equal(t, pck1.Payload(), pck3.Payload())
+}
+
 func BenchmarkWriter_Write(b *testing.B) {
 	w := NewWriter()
 	defer w.Close()
@@ -0,0 +1,208 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+)
+
+// Dependent is an optional interface for plugins that require other plugins to be loaded first.
+type Dependent interface {
+	Dependencies() []Dependency
+}
+
+// Dependency describes a plugin that must be loaded before the dependent plugin.
+type Dependency struct {
+	Name     string // Name of the required plugin.
+	Version  string // Semver constraint such as ">=0.14.0, <1", "^1.2" or "~1.2.3". Empty matches any version.
+	Optional bool   // Optional dependencies only affect the load order and versions when present.
+	Group    string // Dependencies sharing a group are alternatives, of which at least one must be present.
+}
+
+// Constraint is a parsed semver constraint made of alternatives separated by "||",
+// each a set of comparators that must all match.
+type Constraint struct {
+	alternatives [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version string
+}
+
+var (
+	ErrIncompatibleDependency = errors.New("incompatible dependency")
+	ErrCyclicDependency       = errors.New("cyclic dependency")
+	ErrInvalidConstraint      = errors.New("invalid constraint")
+)
+
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"}
+
+// ParseConstraint parses a semver constraint.
+func ParseConstraint(constraint string) (*Constraint, error) {
+	c := &Constraint{}
+	for _, alternative := range strings.Split(constraint, "||") {
+		fields := strings.FieldsFunc(alternative, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+
+		var comparators []comparator
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			if field == "*" || field == "x" {
+				continue
+			}
+
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(field, o) {
+					op = o
+					break
+				}
+			}
+			v := strings.TrimPrefix(field, op)
+			if v == "" && i+1 < len(fields) {
+				i++
+				v = fields[i]
+			}
+
+			v = canonical(v)
+			if !semver.IsValid(v) {
+				return nil, errors.WithMessagef(ErrInvalidConstraint, "%q", constraint)
+			}
+			comparators = append(comparators, expand(op, v)...)
+		}
+		c.alternatives = append(c.alternatives, comparators)
+	}
+	return c, nil
+}
+
+// Check reports whether the version satisfies the constraint.
+// Prerelease suffixes, such as the commit hash appended to release builds, are ignored.
+func (c *Constraint) Check(version string) bool {
+	v := canonical(version)
+	if !semver.IsValid(v) {
+		return false
+	}
+	v = strings.TrimSuffix(semver.Canonical(v), semver.Prerelease(v))
+
+	for _, comparators := range c.alternatives {
+		ok := true
+		for _, cmp := range comparators {
+			if !cmp.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint in its normalized form.
+func (c *Constraint) String() string {
+	alternatives := make([]string, 0, len(c.alternatives))
+	for _, comparators := range c.alternatives {
+		if len(comparators) == 0 {
+			alternatives = append(alternatives, "*")
+			continue
+		}
+		fields := make([]string, 0, len(comparators))
+		for _, cmp := range comparators {
+			fields = append(fields, cmp.op+cmp.version)
+		}
+		alternatives = append(alternatives, strings.Join(fields, ", "))
+	}
+	return strings.Join(alternatives, " || ")
+}
+
+func (c comparator) check(version string) bool {
+	n := semver.Compare(version, c.version)
+	switch c.op {
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case "!=":
+		return n != 0
+	default:
+		return n == 0
+	}
+}
+
+// expand rewrites caret, tilde and partial versions as ranges of plain comparators.
+func expand(op, version string) []comparator {
+	parts := strings.Split(strings.TrimPrefix(semver.Canonical(version), "v"), ".")
+	precision := strings.Count(strings.TrimPrefix(version, "v"), ".") + 1
+	if semver.Prerelease(version) != "" {
+		precision = 3
+	}
+
+	var upper string
+	switch op {
+	case "^":
+		switch {
+		case parts[0] != "0" || precision == 1:
+			upper = bump(parts, 0)
+		case parts[1] != "0" || precision == 2:
+			upper = bump(parts, 1)
+		default:
+			upper = bump(parts, 2)
+		}
+	case "~":
+		if precision == 1 {
+			upper = bump(parts, 0)
+		} else {
+			upper = bump(parts, 1)
+		}
+	case "", "=", "==":
+		if precision == 3 {
+			return []comparator{{op: "=", version: semver.Canonical(version)}}
+		}
+		upper = bump(parts, precision-1)
+	case ">":
+		if precision < 3 {
+			return []comparator{{op: ">=", version: bump(parts, precision-1)}}
+		}
+		return []comparator{{op: op, version: semver.Canonical(version)}}
+	case "<=":
+		if precision < 3 {
+			return []comparator{{op: "<", version: bump(parts, precision-1)}}
+		}
+		return []comparator{{op: op, version: semver.Canonical(version)}}
+	default:
+		return []comparator{{op: op, version: semver.Canonical(version)}}
+	}
+	return []comparator{{op: ">=", version: semver.Canonical(version)}, {op: "<", version: upper}}
+}
+
+func bump(parts []string, i int) string {
+	next := make([]string, 3)
+	for j := range next {
+		switch {
+		case j < i:
+			next[j] = parts[j]
+		case j == i:
+			n, _ := strconv.Atoi(strings.SplitN(parts[j], "-", 2)[0])
+			next[j] = strconv.Itoa(n + 1)
+		default:
+			next[j] = "0"
+		}
+	}
+	return "v" + strings.Join(next, ".")
+}
+
+func canonical(version string) string {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
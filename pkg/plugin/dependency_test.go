@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConstraint(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		matches    []string
+		mismatches []string
+	}{
+		{constraint: "", matches: []string{"v0.0.1", "1.2.3"}},
+		{constraint: "*", matches: []string{"v0.0.1", "1.2.3"}},
+		{constraint: "1.2.3", matches: []string{"v1.2.3", "1.2.3-3f2a1b0"}, mismatches: []string{"v1.2.4"}},
+		{constraint: "1.2", matches: []string{"v1.2.0", "v1.2.9"}, mismatches: []string{"v1.3.0", "v1.1.9"}},
+		{constraint: ">=0.14.0", matches: []string{"v0.14.0", "v0.14.0-3f2a1b0", "v1.0.0"}, mismatches: []string{"v0.13.9"}},
+		{constraint: ">= 0.14, < 1", matches: []string{"v0.14.0", "v0.99.0"}, mismatches: []string{"v1.0.0", "v0.13.0"}},
+		{constraint: ">1.2", matches: []string{"v1.3.0"}, mismatches: []string{"v1.2.5"}},
+		{constraint: "<=1.2", matches: []string{"v1.2.5"}, mismatches: []string{"v1.3.0"}},
+		{constraint: "!=1.2.3", matches: []string{"v1.2.4"}, mismatches: []string{"v1.2.3"}},
+		{constraint: "^1.2.3", matches: []string{"v1.2.3", "v1.9.0"}, mismatches: []string{"v2.0.0", "v1.2.2"}},
+		{constraint: "^0.14.1", matches: []string{"v0.14.1", "v0.14.9"}, mismatches: []string{"v0.15.0"}},
+		{constraint: "^0.0.3", matches: []string{"v0.0.3"}, mismatches: []string{"v0.0.4"}},
+		{constraint: "~1.2.3", matches: []string{"v1.2.3", "v1.2.9"}, mismatches: []string{"v1.3.0"}},
+		{constraint: "~1", matches: []string{"v1.9.0"}, mismatches: []string{"v2.0.0"}},
+		{constraint: "^1 || ^3", matches: []string{"v1.5.0", "v3.0.0"}, mismatches: []string{"v2.0.0"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tc.constraint)
+			require.NoError(t, err)
+
+			for _, v := range tc.matches {
+				require.True(t, c.Check(v), v)
+			}
+			for _, v := range tc.mismatches {
+				require.False(t, c.Check(v), v)
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseConstraint(">=foo")
+		require.ErrorIs(t, err, ErrInvalidConstraint)
+	})
+}
+
+func TestConstraint_String(t *testing.T) {
+	c, err := ParseConstraint("^1.2 || >=3")
+	require.NoError(t, err)
+	require.Equal(t, ">=v1.2.0, <v2.0.0 || >=v3.0.0", c.String())
+}
@@ -21,6 +21,7 @@ func init() {
 		"NewTracer":        reflect.ValueOf(packet.NewTracer),
 		"NewWriter":        reflect.ValueOf(packet.NewWriter),
 		"None":             reflect.ValueOf(&packet.None).Elem(),
+		"SelectorFunc":     reflect.ValueOf(packet.SelectorFunc),
 		"Send":             reflect.ValueOf(packet.Send),
 		"SendOrFallback":   reflect.ValueOf(packet.SendOrFallback),
 
@@ -30,11 +31,13 @@ func init() {
 		"Packet":    reflect.ValueOf((*packet.Packet)(nil)),
 		"ReadGroup": reflect.ValueOf((*packet.ReadGroup)(nil)),
 		"Reader":    reflect.ValueOf((*packet.Reader)(nil)),
+		"Selector":  reflect.ValueOf((*packet.Selector)(nil)),
 		"Tracer":    reflect.ValueOf((*packet.Tracer)(nil)),
 		"Writer":    reflect.ValueOf((*packet.Writer)(nil)),
 
 		// interface wrapper definitions
-		"_Hook": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_packet_Hook)(nil)),
+		"_Hook":     reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_packet_Hook)(nil)),
+		"_Selector": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_packet_Selector)(nil)),
 	}
 }
 
@@ -47,3 +50,13 @@ type _github_com_siyul_park_uniflow_pkg_packet_Hook struct {
 func (W _github_com_siyul_park_uniflow_pkg_packet_Hook) Handle(a0 *packet.Packet) {
 	W.WHandle(a0)
 }
+
+// _github_com_siyul_park_uniflow_pkg_packet_Selector is an interface wrapper for Selector type
+type _github_com_siyul_park_uniflow_pkg_packet_Selector struct {
+	IValue  interface{}
+	WSelect func(pck *packet.Packet, readers []*packet.Reader) []int
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_packet_Selector) Select(pck *packet.Packet, readers []*packet.Reader) []int {
+	return W.WSelect(pck, readers)
+}
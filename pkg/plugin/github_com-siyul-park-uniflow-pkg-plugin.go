@@ -10,25 +10,63 @@ import (
 func init() {
 	Symbols["github.com/siyul-park/uniflow/pkg/plugin/plugin"] = map[string]reflect.Value{
 		// function, constant and variable definitions
-		"ErrConflict":          reflect.ValueOf(&ErrConflict).Elem(),
-		"ErrInvalidSignature":  reflect.ValueOf(&ErrInvalidSignature).Elem(),
-		"ErrMissingDependency": reflect.ValueOf(&ErrMissingDependency).Elem(),
-		"ErrNotFound":          reflect.ValueOf(&ErrNotFound).Elem(),
-		"MapTypes":             reflect.ValueOf(&MapTypes).Elem(),
-		"NewLoader":            reflect.ValueOf(NewLoader),
-		"NewRegistry":          reflect.ValueOf(NewRegistry),
-		"Symbols":              reflect.ValueOf(&Symbols).Elem(),
+		"ErrConflict":               reflect.ValueOf(&ErrConflict).Elem(),
+		"ErrCyclicDependency":       reflect.ValueOf(&ErrCyclicDependency).Elem(),
+		"ErrIncompatibleBuild":      reflect.ValueOf(&ErrIncompatibleBuild).Elem(),
+		"ErrIncompatibleDependency": reflect.ValueOf(&ErrIncompatibleDependency).Elem(),
+		"ErrInvalidConstraint":      reflect.ValueOf(&ErrInvalidConstraint).Elem(),
+		"ErrInvalidSignature":       reflect.ValueOf(&ErrInvalidSignature).Elem(),
+		"ErrLoaderNotConfigured":    reflect.ValueOf(&ErrLoaderNotConfigured).Elem(),
+		"ErrMissingDependency":      reflect.ValueOf(&ErrMissingDependency).Elem(),
+		"ErrNotFound":               reflect.ValueOf(&ErrNotFound).Elem(),
+		"ListenerFunc":              reflect.ValueOf(ListenerFunc),
+		"MapTypes":                  reflect.ValueOf(&MapTypes).Elem(),
+		"NewLoader":                 reflect.ValueOf(NewLoader),
+		"NewManager":                reflect.ValueOf(NewManager),
+		"NewRegistry":               reflect.ValueOf(NewRegistry),
+		"ParseConstraint":           reflect.ValueOf(ParseConstraint),
+		"Symbols":                   reflect.ValueOf(&Symbols).Elem(),
 
 		// type definitions
-		"Loader":   reflect.ValueOf((*Loader)(nil)),
-		"Plugin":   reflect.ValueOf((*Plugin)(nil)),
-		"Registry": reflect.ValueOf((*Registry)(nil)),
+		"Change":        reflect.ValueOf((*Change)(nil)),
+		"Constraint":    reflect.ValueOf((*Constraint)(nil)),
+		"Dependency":    reflect.ValueOf((*Dependency)(nil)),
+		"Dependent":     reflect.ValueOf((*Dependent)(nil)),
+		"Listener":      reflect.ValueOf((*Listener)(nil)),
+		"LoadOptions":   reflect.ValueOf((*LoadOptions)(nil)),
+		"Loader":        reflect.ValueOf((*Loader)(nil)),
+		"Manager":       reflect.ValueOf((*Manager)(nil)),
+		"ManagerConfig": reflect.ValueOf((*ManagerConfig)(nil)),
+		"Plugin":        reflect.ValueOf((*Plugin)(nil)),
+		"Registry":      reflect.ValueOf((*Registry)(nil)),
 
 		// interface wrapper definitions
-		"_Plugin": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_plugin_Plugin)(nil)),
+		"_Dependent": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_plugin_Dependent)(nil)),
+		"_Listener":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_plugin_Listener)(nil)),
+		"_Plugin":    reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_plugin_Plugin)(nil)),
 	}
 }
 
+// _github_com_siyul_park_uniflow_pkg_plugin_Dependent is an interface wrapper for Dependent type
+type _github_com_siyul_park_uniflow_pkg_plugin_Dependent struct {
+	IValue        interface{}
+	WDependencies func() []Dependency
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_plugin_Dependent) Dependencies() []Dependency {
+	return W.WDependencies()
+}
+
+// _github_com_siyul_park_uniflow_pkg_plugin_Listener is an interface wrapper for Listener type
+type _github_com_siyul_park_uniflow_pkg_plugin_Listener struct {
+	IValue  interface{}
+	WReload func(ctx context.Context, change Change)
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_plugin_Listener) Reload(ctx context.Context, change Change) {
+	W.WReload(ctx, change)
+}
+
 // _github_com_siyul_park_uniflow_pkg_plugin_Plugin is an interface wrapper for Plugin type
 type _github_com_siyul_park_uniflow_pkg_plugin_Plugin struct {
 	IValue   interface{}
@@ -3,20 +3,34 @@
 package plugin
 
 import (
+	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"go/constant"
+	"go/token"
 	"reflect"
 )
 
 func init() {
 	Symbols["github.com/siyul-park/uniflow/pkg/port/port"] = map[string]reflect.Value{
 		// function, constant and variable definitions
-		"CloseHookFunc": reflect.ValueOf(port.CloseHookFunc),
-		"ListenFunc":    reflect.ValueOf(port.ListenFunc),
-		"NewIn":         reflect.ValueOf(port.NewIn),
-		"NewOut":        reflect.ValueOf(port.NewOut),
-		"OpenHookFunc":  reflect.ValueOf(port.OpenHookFunc),
-		"Pipe":          reflect.ValueOf(port.Pipe),
+		"Broadcast":          reflect.ValueOf(port.Broadcast),
+		"CloseHookFunc":      reflect.ValueOf(port.CloseHookFunc),
+		"Hash":               reflect.ValueOf(port.Hash),
+		"LeastBusy":          reflect.ValueOf(port.LeastBusy),
+		"ListenFunc":         reflect.ValueOf(port.ListenFunc),
+		"NewIn":              reflect.ValueOf(port.NewIn),
+		"NewOut":             reflect.ValueOf(port.NewOut),
+		"NewStrategy":        reflect.ValueOf(port.NewStrategy),
+		"OpenHookFunc":       reflect.ValueOf(port.OpenHookFunc),
+		"Pipe":               reflect.ValueOf(port.Pipe),
+		"Random":             reflect.ValueOf(port.Random),
+		"RoundRobin":         reflect.ValueOf(port.RoundRobin),
+		"StrategyBroadcast":  reflect.ValueOf(constant.MakeFromLiteral("\"broadcast\"", token.STRING, 0)),
+		"StrategyHash":       reflect.ValueOf(constant.MakeFromLiteral("\"hash\"", token.STRING, 0)),
+		"StrategyLeastBusy":  reflect.ValueOf(constant.MakeFromLiteral("\"least-busy\"", token.STRING, 0)),
+		"StrategyRandom":     reflect.ValueOf(constant.MakeFromLiteral("\"random\"", token.STRING, 0)),
+		"StrategyRoundRobin": reflect.ValueOf(constant.MakeFromLiteral("\"round-robin\"", token.STRING, 0)),
 
 		// type definitions
 		"CloseHook":  reflect.ValueOf((*port.CloseHook)(nil)),
@@ -27,11 +41,13 @@ func init() {
 		"OpenHook":   reflect.ValueOf((*port.OpenHook)(nil)),
 		"OpenHooks":  reflect.ValueOf((*port.OpenHooks)(nil)),
 		"OutPort":    reflect.ValueOf((*port.OutPort)(nil)),
+		"Strategy":   reflect.ValueOf((*port.Strategy)(nil)),
 
 		// interface wrapper definitions
 		"_CloseHook": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_port_CloseHook)(nil)),
 		"_Listener":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_port_Listener)(nil)),
 		"_OpenHook":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_port_OpenHook)(nil)),
+		"_Strategy":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_port_Strategy)(nil)),
 	}
 }
 
@@ -64,3 +80,13 @@ type _github_com_siyul_park_uniflow_pkg_port_OpenHook struct {
 func (W _github_com_siyul_park_uniflow_pkg_port_OpenHook) Open(a0 *process.Process) {
 	W.WOpen(a0)
 }
+
+// _github_com_siyul_park_uniflow_pkg_port_Strategy is an interface wrapper for Strategy type
+type _github_com_siyul_park_uniflow_pkg_port_Strategy struct {
+	IValue  interface{}
+	WSelect func(pck *packet.Packet, ins []*port.InPort) []int
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_port_Strategy) Select(pck *packet.Packet, ins []*port.InPort) []int {
+	return W.WSelect(pck, ins)
+}
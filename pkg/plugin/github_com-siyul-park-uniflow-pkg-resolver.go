@@ -0,0 +1,47 @@
+// Code generated by 'yaegi extract github.com/siyul-park/uniflow/pkg/resolver'. DO NOT EDIT.
+
+package plugin
+
+import (
+	"context"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"net/url"
+	"reflect"
+)
+
+func init() {
+	Symbols["github.com/siyul-park/uniflow/pkg/resolver/resolver"] = map[string]reflect.Value{
+		// function, constant and variable definitions
+		"DefaultInterval":      reflect.ValueOf(resolver.DefaultInterval),
+		"ErrAlreadyRegistered": reflect.ValueOf(&resolver.ErrAlreadyRegistered).Elem(),
+		"ErrNotFound":          reflect.ValueOf(&resolver.ErrNotFound).Elem(),
+		"ErrNotRegistered":     reflect.ValueOf(&resolver.ErrNotRegistered).Elem(),
+		"IsURI":                reflect.ValueOf(resolver.IsURI),
+		"NewEnvResolver":       reflect.ValueOf(resolver.NewEnvResolver),
+		"NewFileResolver":      reflect.ValueOf(resolver.NewFileResolver),
+		"NewRegistry":          reflect.ValueOf(resolver.NewRegistry),
+		"NewVaultResolver":     reflect.ValueOf(resolver.NewVaultResolver),
+		"ResolveFunc":          reflect.ValueOf(resolver.ResolveFunc),
+
+		// type definitions
+		"EnvResolver":   reflect.ValueOf((*resolver.EnvResolver)(nil)),
+		"FileResolver":  reflect.ValueOf((*resolver.FileResolver)(nil)),
+		"Registry":      reflect.ValueOf((*resolver.Registry)(nil)),
+		"Resolver":      reflect.ValueOf((*resolver.Resolver)(nil)),
+		"Stream":        reflect.ValueOf((*resolver.Stream)(nil)),
+		"VaultResolver": reflect.ValueOf((*resolver.VaultResolver)(nil)),
+
+		// interface wrapper definitions
+		"_Resolver": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_resolver_Resolver)(nil)),
+	}
+}
+
+// _github_com_siyul_park_uniflow_pkg_resolver_Resolver is an interface wrapper for Resolver type
+type _github_com_siyul_park_uniflow_pkg_resolver_Resolver struct {
+	IValue   interface{}
+	WResolve func(ctx context.Context, uri *url.URL) (any, error)
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_resolver_Resolver) Resolve(ctx context.Context, uri *url.URL) (any, error) {
+	return W.WResolve(ctx, uri)
+}
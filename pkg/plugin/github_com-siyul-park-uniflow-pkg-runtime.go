@@ -18,19 +18,26 @@ func init() {
 		"New":               reflect.ValueOf(runtime.New),
 		"NewAgent":          reflect.ValueOf(runtime.NewAgent),
 		"NewBreakpoint":     reflect.ValueOf(runtime.NewBreakpoint),
+		"NewCounter":        reflect.ValueOf(runtime.NewCounter),
+		"NewCoverage":       reflect.ValueOf(runtime.NewCoverage),
 		"NewDebugger":       reflect.ValueOf(runtime.NewDebugger),
 		"NewFrameWatcher":   reflect.ValueOf(runtime.NewFrameWatcher),
 		"NewProcessWatcher": reflect.ValueOf(runtime.NewProcessWatcher),
 
 		// type definitions
-		"Agent":      reflect.ValueOf((*runtime.Agent)(nil)),
-		"Breakpoint": reflect.ValueOf((*runtime.Breakpoint)(nil)),
-		"Config":     reflect.ValueOf((*runtime.Config)(nil)),
-		"Debugger":   reflect.ValueOf((*runtime.Debugger)(nil)),
-		"Frame":      reflect.ValueOf((*runtime.Frame)(nil)),
-		"Runtime":    reflect.ValueOf((*runtime.Runtime)(nil)),
-		"Watcher":    reflect.ValueOf((*runtime.Watcher)(nil)),
-		"Watchers":   reflect.ValueOf((*runtime.Watchers)(nil)),
+		"Agent":          reflect.ValueOf((*runtime.Agent)(nil)),
+		"Breakpoint":     reflect.ValueOf((*runtime.Breakpoint)(nil)),
+		"Config":         reflect.ValueOf((*runtime.Config)(nil)),
+		"Counter":        reflect.ValueOf((*runtime.Counter)(nil)),
+		"Coverage":       reflect.ValueOf((*runtime.Coverage)(nil)),
+		"CoverageReport": reflect.ValueOf((*runtime.CoverageReport)(nil)),
+		"CoverageStat":   reflect.ValueOf((*runtime.CoverageStat)(nil)),
+		"Debugger":       reflect.ValueOf((*runtime.Debugger)(nil)),
+		"Frame":          reflect.ValueOf((*runtime.Frame)(nil)),
+		"Runtime":        reflect.ValueOf((*runtime.Runtime)(nil)),
+		"SymbolCoverage": reflect.ValueOf((*runtime.SymbolCoverage)(nil)),
+		"Watcher":        reflect.ValueOf((*runtime.Watcher)(nil)),
+		"Watchers":       reflect.ValueOf((*runtime.Watchers)(nil)),
 
 		// interface wrapper definitions
 		"_Watcher": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_runtime_Watcher)(nil)),
@@ -0,0 +1,30 @@
+// Code generated by 'yaegi extract github.com/siyul-park/uniflow/pkg/schema'. DO NOT EDIT.
+
+package plugin
+
+import (
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"go/constant"
+	"go/token"
+	"reflect"
+)
+
+func init() {
+	Symbols["github.com/siyul-park/uniflow/pkg/schema/schema"] = map[string]reflect.Value{
+		// function, constant and variable definitions
+		"Compatible":      reflect.ValueOf(schema.Compatible),
+		"ErrIncompatible": reflect.ValueOf(&schema.ErrIncompatible).Elem(),
+		"ErrInvalidValue": reflect.ValueOf(&schema.ErrInvalidValue).Elem(),
+		"TypeArray":       reflect.ValueOf(constant.MakeFromLiteral("\"array\"", token.STRING, 0)),
+		"TypeBoolean":     reflect.ValueOf(constant.MakeFromLiteral("\"boolean\"", token.STRING, 0)),
+		"TypeInteger":     reflect.ValueOf(constant.MakeFromLiteral("\"integer\"", token.STRING, 0)),
+		"TypeNull":        reflect.ValueOf(constant.MakeFromLiteral("\"null\"", token.STRING, 0)),
+		"TypeNumber":      reflect.ValueOf(constant.MakeFromLiteral("\"number\"", token.STRING, 0)),
+		"TypeObject":      reflect.ValueOf(constant.MakeFromLiteral("\"object\"", token.STRING, 0)),
+		"TypeOf":          reflect.ValueOf(schema.TypeOf),
+		"TypeString":      reflect.ValueOf(constant.MakeFromLiteral("\"string\"", token.STRING, 0)),
+
+		// type definitions
+		"Schema": reflect.ValueOf((*schema.Schema)(nil)),
+	}
+}
@@ -12,20 +12,23 @@ import (
 func init() {
 	Symbols["github.com/siyul-park/uniflow/pkg/scheme/scheme"] = map[string]reflect.Value{
 		// function, constant and variable definitions
-		"CodecFunc":    reflect.ValueOf(scheme.CodecFunc),
-		"New":          reflect.ValueOf(scheme.New),
-		"NewBuilder":   reflect.ValueOf(scheme.NewBuilder),
-		"RegisterFunc": reflect.ValueOf(scheme.RegisterFunc),
+		"CodecFunc":        reflect.ValueOf(scheme.CodecFunc),
+		"CodecWithSchemas": reflect.ValueOf(scheme.CodecWithSchemas),
+		"New":              reflect.ValueOf(scheme.New),
+		"NewBuilder":       reflect.ValueOf(scheme.NewBuilder),
+		"RegisterFunc":     reflect.ValueOf(scheme.RegisterFunc),
 
 		// type definitions
-		"Builder":  reflect.ValueOf((*scheme.Builder)(nil)),
-		"Codec":    reflect.ValueOf((*scheme.Codec)(nil)),
-		"Register": reflect.ValueOf((*scheme.Register)(nil)),
-		"Scheme":   reflect.ValueOf((*scheme.Scheme)(nil)),
+		"Builder":   reflect.ValueOf((*scheme.Builder)(nil)),
+		"Codec":     reflect.ValueOf((*scheme.Codec)(nil)),
+		"Describer": reflect.ValueOf((*scheme.Describer)(nil)),
+		"Register":  reflect.ValueOf((*scheme.Register)(nil)),
+		"Scheme":    reflect.ValueOf((*scheme.Scheme)(nil)),
 
 		// interface wrapper definitions
-		"_Codec":    reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_scheme_Codec)(nil)),
-		"_Register": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_scheme_Register)(nil)),
+		"_Codec":     reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_scheme_Codec)(nil)),
+		"_Describer": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_scheme_Describer)(nil)),
+		"_Register":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_scheme_Register)(nil)),
 	}
 }
 
@@ -39,6 +42,16 @@ func (W _github_com_siyul_park_uniflow_pkg_scheme_Codec) Compile(sp spec.Spec) (
 	return W.WCompile(sp)
 }
 
+// _github_com_siyul_park_uniflow_pkg_scheme_Describer is an interface wrapper for Describer type
+type _github_com_siyul_park_uniflow_pkg_scheme_Describer struct {
+	IValue    interface{}
+	WDescribe func(sp spec.Spec) *spec.Schemas
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_scheme_Describer) Describe(sp spec.Spec) *spec.Schemas {
+	return W.WDescribe(sp)
+}
+
 // _github_com_siyul_park_uniflow_pkg_scheme_Register is an interface wrapper for Register type
 type _github_com_siyul_park_uniflow_pkg_scheme_Register struct {
 	IValue       interface{}
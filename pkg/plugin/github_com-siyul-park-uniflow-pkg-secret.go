@@ -0,0 +1,55 @@
+// Code generated by 'yaegi extract github.com/siyul-park/uniflow/pkg/secret'. DO NOT EDIT.
+
+package plugin
+
+import (
+	"context"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"go/constant"
+	"go/token"
+	"reflect"
+)
+
+func init() {
+	Symbols["github.com/siyul-park/uniflow/pkg/secret/secret"] = map[string]reflect.Value{
+		// function, constant and variable definitions
+		"AlgorithmAESGCM":     reflect.ValueOf(constant.MakeFromLiteral("\"AES-256-GCM\"", token.STRING, 0)),
+		"Decrypt":             reflect.ValueOf(secret.Decrypt),
+		"Encrypt":             reflect.ValueOf(secret.Encrypt),
+		"EnvelopeOf":          reflect.ValueOf(secret.EnvelopeOf),
+		"ErrInvalidEnvelope":  reflect.ValueOf(&secret.ErrInvalidEnvelope).Elem(),
+		"ErrInvalidKey":       reflect.ValueOf(&secret.ErrInvalidKey).Elem(),
+		"ErrNoKeyProvider":    reflect.ValueOf(&secret.ErrNoKeyProvider).Elem(),
+		"GenerateKey":         reflect.ValueOf(secret.GenerateKey),
+		"KeySize":             reflect.ValueOf(constant.MakeFromLiteral("32", token.INT, 0)),
+		"NewFileKeyProvider":  reflect.ValueOf(secret.NewFileKeyProvider),
+		"NewLocalKeyProvider": reflect.ValueOf(secret.NewLocalKeyProvider),
+		"Open":                reflect.ValueOf(secret.Open),
+		"Redact":              reflect.ValueOf(secret.Redact),
+		"RedactData":          reflect.ValueOf(secret.RedactData),
+		"Redacted":            reflect.ValueOf(constant.MakeFromLiteral("\"[REDACTED]\"", token.STRING, 0)),
+		"Seal":                reflect.ValueOf(secret.Seal),
+
+		// type definitions
+		"Envelope":         reflect.ValueOf((*secret.Envelope)(nil)),
+		"KeyProvider":      reflect.ValueOf((*secret.KeyProvider)(nil)),
+		"LocalKeyProvider": reflect.ValueOf((*secret.LocalKeyProvider)(nil)),
+
+		// interface wrapper definitions
+		"_KeyProvider": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_secret_KeyProvider)(nil)),
+	}
+}
+
+// _github_com_siyul_park_uniflow_pkg_secret_KeyProvider is an interface wrapper for KeyProvider type
+type _github_com_siyul_park_uniflow_pkg_secret_KeyProvider struct {
+	IValue     interface{}
+	WUnwrapKey func(ctx context.Context, wrapped []byte) ([]byte, error)
+	WWrapKey   func(ctx context.Context, key []byte) ([]byte, error)
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_secret_KeyProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	return W.WUnwrapKey(ctx, wrapped)
+}
+func (W _github_com_siyul_park_uniflow_pkg_secret_KeyProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	return W.WWrapKey(ctx, key)
+}
@@ -21,12 +21,16 @@ func init() {
 		"KeyName":        reflect.ValueOf(constant.MakeFromLiteral("\"name\"", token.STRING, 0)),
 		"KeyNamespace":   reflect.ValueOf(constant.MakeFromLiteral("\"namespace\"", token.STRING, 0)),
 		"KeyPorts":       reflect.ValueOf(constant.MakeFromLiteral("\"ports\"", token.STRING, 0)),
+		"KeySchemas":     reflect.ValueOf(constant.MakeFromLiteral("\"schemas\"", token.STRING, 0)),
+		"KeyStrategies":  reflect.ValueOf(constant.MakeFromLiteral("\"strategies\"", token.STRING, 0)),
 		"New":            reflect.ValueOf(spec.New),
 
 		// type definitions
 		"Meta":         reflect.ValueOf((*spec.Meta)(nil)),
 		"Port":         reflect.ValueOf((*spec.Port)(nil)),
+		"Schemas":      reflect.ValueOf((*spec.Schemas)(nil)),
 		"Spec":         reflect.ValueOf((*spec.Spec)(nil)),
+		"Strategy":     reflect.ValueOf((*spec.Strategy)(nil)),
 		"Unstructured": reflect.ValueOf((*spec.Unstructured)(nil)),
 		"Value":        reflect.ValueOf((*spec.Value)(nil)),
 
@@ -45,6 +49,8 @@ type _github_com_siyul_park_uniflow_pkg_spec_Spec struct {
 	WGetName        func() string
 	WGetNamespace   func() string
 	WGetPorts       func() map[string][]spec.Port
+	WGetSchemas     func() *spec.Schemas
+	WGetStrategies  func() map[string]spec.Strategy
 	WSetAnnotations func(val map[string]string)
 	WSetEnv         func(val map[string]spec.Value)
 	WSetID          func(val uuid.UUID)
@@ -52,6 +58,8 @@ type _github_com_siyul_park_uniflow_pkg_spec_Spec struct {
 	WSetName        func(val string)
 	WSetNamespace   func(val string)
 	WSetPorts       func(val map[string][]spec.Port)
+	WSetSchemas     func(val *spec.Schemas)
+	WSetStrategies  func(val map[string]spec.Strategy)
 }
 
 func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) GetAnnotations() map[string]string {
@@ -75,6 +83,12 @@ func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) GetNamespace() string {
 func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) GetPorts() map[string][]spec.Port {
 	return W.WGetPorts()
 }
+func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) GetSchemas() *spec.Schemas {
+	return W.WGetSchemas()
+}
+func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) GetStrategies() map[string]spec.Strategy {
+	return W.WGetStrategies()
+}
 func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) SetAnnotations(val map[string]string) {
 	W.WSetAnnotations(val)
 }
@@ -96,3 +110,9 @@ func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) SetNamespace(val string) {
 func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) SetPorts(val map[string][]spec.Port) {
 	W.WSetPorts(val)
 }
+func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) SetSchemas(val *spec.Schemas) {
+	W.WSetSchemas(val)
+}
+func (W _github_com_siyul_park_uniflow_pkg_spec_Spec) SetStrategies(val map[string]spec.Strategy) {
+	W.WSetStrategies(val)
+}
@@ -13,30 +13,62 @@ import (
 func init() {
 	Symbols["github.com/siyul-park/uniflow/pkg/testing/testing"] = map[string]reflect.Value{
 		// function, constant and variable definitions
-		"NewErrorReporter": reflect.ValueOf(testing.NewErrorReporter),
-		"NewRunner":        reflect.ValueOf(testing.NewRunner),
-		"NewTester":        reflect.ValueOf(testing.NewTester),
-		"NewTextReporter":  reflect.ValueOf(testing.NewTextReporter),
-		"ReportFunc":       reflect.ValueOf(testing.ReportFunc),
-		"RunFunc":          reflect.ValueOf(testing.RunFunc),
-		"StatusFail":       reflect.ValueOf(constant.MakeFromLiteral("\"FAIL\"", token.STRING, 0)),
-		"StatusPass":       reflect.ValueOf(constant.MakeFromLiteral("\"PASS\"", token.STRING, 0)),
+		"AfterAll":            reflect.ValueOf(testing.AfterAll),
+		"AfterEach":           reflect.ValueOf(testing.AfterEach),
+		"BeforeAll":           reflect.ValueOf(testing.BeforeAll),
+		"BeforeEach":          reflect.ValueOf(testing.BeforeEach),
+		"ErrSnapshotMismatch": reflect.ValueOf(&testing.ErrSnapshotMismatch).Elem(),
+		"ErrSnapshotNotFound": reflect.ValueOf(&testing.ErrSnapshotNotFound).Elem(),
+		"ErrTimeout":          reflect.ValueOf(&testing.ErrTimeout).Elem(),
+		"NewCorpus":           reflect.ValueOf(testing.NewCorpus),
+		"NewErrorReporter":    reflect.ValueOf(testing.NewErrorReporter),
+		"NewJSONReporter":     reflect.ValueOf(testing.NewJSONReporter),
+		"NewJUnitReporter":    reflect.ValueOf(testing.NewJUnitReporter),
+		"NewRunner":           reflect.ValueOf(testing.NewRunner),
+		"NewSnapshot":         reflect.ValueOf(testing.NewSnapshot),
+		"NewTAPReporter":      reflect.ValueOf(testing.NewTAPReporter),
+		"NewTester":           reflect.ValueOf(testing.NewTester),
+		"NewTextReporter":     reflect.ValueOf(testing.NewTextReporter),
+		"Normalized":          reflect.ValueOf(constant.MakeFromLiteral("\"[normalized]\"", token.STRING, 0)),
+		"ReportFunc":          reflect.ValueOf(testing.ReportFunc),
+		"RunFunc":             reflect.ValueOf(testing.RunFunc),
+		"StatusFail":          reflect.ValueOf(constant.MakeFromLiteral("\"FAIL\"", token.STRING, 0)),
+		"StatusPass":          reflect.ValueOf(constant.MakeFromLiteral("\"PASS\"", token.STRING, 0)),
 
 		// type definitions
+		"Corpus":        reflect.ValueOf((*testing.Corpus)(nil)),
 		"ErrorReporter": reflect.ValueOf((*testing.ErrorReporter)(nil)),
+		"Failure":       reflect.ValueOf((*testing.Failure)(nil)),
+		"Flusher":       reflect.ValueOf((*testing.Flusher)(nil)),
+		"JUnitReporter": reflect.ValueOf((*testing.JUnitReporter)(nil)),
+		"Phase":         reflect.ValueOf((*testing.Phase)(nil)),
 		"Reporter":      reflect.ValueOf((*testing.Reporter)(nil)),
 		"Reporters":     reflect.ValueOf((*testing.Reporters)(nil)),
 		"Result":        reflect.ValueOf((*testing.Result)(nil)),
+		"RunOptions":    reflect.ValueOf((*testing.RunOptions)(nil)),
 		"Runner":        reflect.ValueOf((*testing.Runner)(nil)),
+		"Snapshot":      reflect.ValueOf((*testing.Snapshot)(nil)),
 		"Suite":         reflect.ValueOf((*testing.Suite)(nil)),
+		"TAPReporter":   reflect.ValueOf((*testing.TAPReporter)(nil)),
 		"Tester":        reflect.ValueOf((*testing.Tester)(nil)),
 
 		// interface wrapper definitions
+		"_Flusher":  reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_testing_Flusher)(nil)),
 		"_Reporter": reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_testing_Reporter)(nil)),
 		"_Suite":    reflect.ValueOf((*_github_com_siyul_park_uniflow_pkg_testing_Suite)(nil)),
 	}
 }
 
+// _github_com_siyul_park_uniflow_pkg_testing_Flusher is an interface wrapper for Flusher type
+type _github_com_siyul_park_uniflow_pkg_testing_Flusher struct {
+	IValue interface{}
+	WFlush func() error
+}
+
+func (W _github_com_siyul_park_uniflow_pkg_testing_Flusher) Flush() error {
+	return W.WFlush()
+}
+
 // _github_com_siyul_park_uniflow_pkg_testing_Reporter is an interface wrapper for Reporter type
 type _github_com_siyul_park_uniflow_pkg_testing_Reporter struct {
 	IValue  interface{}
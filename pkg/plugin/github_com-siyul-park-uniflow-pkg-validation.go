@@ -0,0 +1,34 @@
+// Code generated by 'yaegi extract github.com/siyul-park/uniflow/pkg/validation'. DO NOT EDIT.
+
+package plugin
+
+import (
+	"github.com/siyul-park/uniflow/pkg/validation"
+	"reflect"
+)
+
+func init() {
+	Symbols["github.com/siyul-park/uniflow/pkg/validation/validation"] = map[string]reflect.Value{
+		// function, constant and variable definitions
+		"DefaultLoops":     reflect.ValueOf(&validation.DefaultLoops).Elem(),
+		"ErrCycle":         reflect.ValueOf(&validation.ErrCycle).Elem(),
+		"ErrDuplicateName": reflect.ValueOf(&validation.ErrDuplicateName).Elem(),
+		"ErrMissingTarget": reflect.ValueOf(&validation.ErrMissingTarget).Elem(),
+		"ErrUnknownKind":   reflect.ValueOf(&validation.ErrUnknownKind).Elem(),
+		"ErrUnknownPort":   reflect.ValueOf(&validation.ErrUnknownPort).Elem(),
+		"ErrUnreachable":   reflect.ValueOf(&validation.ErrUnreachable).Elem(),
+		"Errors":           reflect.ValueOf(validation.Errors),
+		"NewGraph":         reflect.ValueOf(validation.NewGraph),
+		"NewValidator":     reflect.ValueOf(validation.NewValidator),
+		"SeverityError":    reflect.ValueOf(validation.SeverityError),
+		"SeverityWarning":  reflect.ValueOf(validation.SeverityWarning),
+
+		// type definitions
+		"Config":    reflect.ValueOf((*validation.Config)(nil)),
+		"Edge":      reflect.ValueOf((*validation.Edge)(nil)),
+		"Graph":     reflect.ValueOf((*validation.Graph)(nil)),
+		"Issue":     reflect.ValueOf((*validation.Issue)(nil)),
+		"Severity":  reflect.ValueOf((*validation.Severity)(nil)),
+		"Validator": reflect.ValueOf((*validation.Validator)(nil)),
+	}
+}
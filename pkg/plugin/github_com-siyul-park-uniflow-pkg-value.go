@@ -17,6 +17,7 @@ func init() {
 		"KeyID":          reflect.ValueOf(constant.MakeFromLiteral("\"id\"", token.STRING, 0)),
 		"KeyName":        reflect.ValueOf(constant.MakeFromLiteral("\"name\"", token.STRING, 0)),
 		"KeyNamespace":   reflect.ValueOf(constant.MakeFromLiteral("\"namespace\"", token.STRING, 0)),
+		"KeySecret":      reflect.ValueOf(constant.MakeFromLiteral("\"secret\"", token.STRING, 0)),
 		"New":            reflect.ValueOf(value.New),
 
 		// type definitions
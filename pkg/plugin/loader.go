@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"go/ast"
@@ -12,6 +14,7 @@ import (
 	"plugin"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pkg/errors"
@@ -25,6 +28,8 @@ import (
 type Loader struct {
 	fs       afero.Fs
 	validate *validator.Validate
+	natives  map[string]*plugin.Plugin
+	mu       sync.Mutex
 }
 
 // LoadOptions specifies options for loading a plugin.
@@ -38,10 +43,15 @@ func NewLoader(fs afero.Fs) *Loader {
 	return &Loader{
 		fs:       fs,
 		validate: validator.New(validator.WithRequiredStructEnabled()),
+		natives:  make(map[string]*plugin.Plugin),
 	}
 }
 
-var ErrInvalidSignature = errors.New("invalid signature")
+var (
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrIncompatibleBuild   = errors.New("incompatible build")
+	ErrLoaderNotConfigured = errors.New("loader not configured")
+)
 
 // Open loads and initializes a plugin with the given config.
 func (l *Loader) Open(path string, options ...LoadOptions) (Plugin, error) {
@@ -91,46 +101,73 @@ func (l *Loader) openNative(path string, options ...LoadOptions) (Plugin, error)
 		}
 	}
 
-	tmp, err := os.CreateTemp("", "*.so")
+	p, err := l.openShared(path)
 	if err != nil {
 		return nil, err
 	}
-	defer os.Remove(tmp.Name())
 
-	src, err := l.fs.Open(path)
+	ctor, err := p.Lookup("New")
 	if err != nil {
 		return nil, err
 	}
-	defer src.Close()
 
-	if _, err := io.Copy(tmp, src); err != nil {
-		tmp.Close()
+	recv, err := l.invoke(reflect.ValueOf(ctor), config)
+	if err != nil {
 		return nil, err
 	}
-	if err := tmp.Close(); err != nil {
-		return nil, err
+
+	r, ok := recv.Interface().(Plugin)
+	if !ok {
+		return nil, ErrInvalidSignature
 	}
+	return r, nil
+}
 
-	p, err := plugin.Open(tmp.Name())
+// openShared opens the shared object file at the path. Shared objects can not be closed once opened,
+// so the same build is opened only once and reused when it is loaded again. A different build of a plugin
+// that is already open, or one sharing packages that changed since, can not be opened next to it, so native
+// plugins can only be upgraded by restarting the engine.
+func (l *Loader) openShared(path string) (*plugin.Plugin, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tmp, err := os.CreateTemp("", "*.so")
 	if err != nil {
 		return nil, err
 	}
+	defer os.Remove(tmp.Name())
 
-	ctor, err := p.Lookup("New")
+	src, err := l.fs.Open(path)
 	if err != nil {
+		tmp.Close()
 		return nil, err
 	}
+	defer src.Close()
 
-	recv, err := l.invoke(reflect.ValueOf(ctor), config)
-	if err != nil {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), src); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
 		return nil, err
 	}
 
-	r, ok := recv.Interface().(Plugin)
-	if !ok {
-		return nil, ErrInvalidSignature
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if p, ok := l.natives[sum]; ok {
+		return p, nil
 	}
-	return r, nil
+
+	p, err := plugin.Open(tmp.Name())
+	if err != nil {
+		msg := strings.ReplaceAll(err.Error(), strings.TrimSuffix(tmp.Name(), ".so"), strings.TrimSuffix(path, ".so"))
+		if strings.Contains(msg, "plugin already loaded") || strings.Contains(msg, "different version of package") {
+			return nil, errors.WithMessagef(ErrIncompatibleBuild, "%s, native plugins must match the engine build and can not be upgraded without a restart", msg)
+		}
+		return nil, err
+	}
+	l.natives[sum] = p
+	return p, nil
 }
 
 func (l *Loader) openInterp(path string, options ...LoadOptions) (Plugin, error) {
@@ -162,7 +199,7 @@ func (l *Loader) openInterp(path string, options ...LoadOptions) (Plugin, error)
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && strings.HasSuffix(info.Name(), ".go") {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".go") && !strings.HasSuffix(info.Name(), "_test.go") {
 			paths = append(paths, path)
 		}
 		return nil
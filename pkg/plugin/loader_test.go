@@ -2,9 +2,13 @@ package plugin
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +59,17 @@ func (p *Plugin) Unload(_ context.Context) error {
 `)
 	require.NoError(t, err)
 
+	test, err := fs.Create("main_test.go")
+	require.NoError(t, err)
+	defer test.Close()
+
+	_, err = test.WriteString(`
+package main
+
+import "example.com/unknown"
+`)
+	require.NoError(t, err)
+
 	mod, err := fs.Create("go.mod")
 	require.NoError(t, err)
 	defer mod.Close()
@@ -83,3 +98,51 @@ go 1.20
 	err = p.Unload(ctx)
 	require.NoError(t, err)
 }
+
+func TestLoader_OpenNative(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	build := func(version, output string) {
+		err := afero.WriteFile(fs, filepath.Join(dir, "go.mod"), []byte("module example.com/native\n\ngo 1.22\n"), 0644)
+		require.NoError(t, err)
+		err = fs.MkdirAll(filepath.Join(dir, "version"), 0755)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dir, "version", "version.go"), []byte("package version\n\nconst Version = \""+version+"\"\n"), 0644)
+		require.NoError(t, err)
+		err = afero.WriteFile(fs, filepath.Join(dir, "main.go"), []byte("package main\n\nimport \"example.com/native/version\"\n\nfunc New() string {\n\treturn version.Version\n}\n"), 0644)
+		require.NoError(t, err)
+
+		cmd := exec.Command(gobin, "build", "-buildmode=plugin", "-o", output, ".")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("%v: %s", err, out)
+		}
+	}
+
+	v1 := filepath.Join(dir, "v1.so")
+	v2 := filepath.Join(dir, "v2.so")
+
+	build("v1", v1)
+	build("v2", v2)
+
+	ld := NewLoader(fs)
+
+	_, err = ld.Open(v1)
+	if errors.Is(err, ErrIncompatibleBuild) {
+		t.Skip(err)
+	}
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = ld.Open(v1)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = ld.Open(v2)
+	require.ErrorIs(t, err, ErrIncompatibleBuild)
+}
@@ -0,0 +1,305 @@
+package plugin
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/hook"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+)
+
+// ManagerConfig holds the configuration for a Manager.
+type ManagerConfig struct {
+	Registry      *Registry       // Registry holds the plugins of the running engine.
+	Loader        *Loader         // Loader opens plugins from source or shared object files.
+	SchemeBuilder *scheme.Builder // SchemeBuilder is rebuilt after plugins change.
+	HookBuilder   *hook.Builder   // HookBuilder is rebuilt after plugins change.
+	Dependencies  []any           // Dependencies are injected into plugins before they are loaded.
+}
+
+// Manager loads, unloads and upgrades plugins on a running engine and rebuilds the scheme and hook they extend.
+type Manager struct {
+	registry      *Registry
+	loader        *Loader
+	schemeBuilder *scheme.Builder
+	hookBuilder   *hook.Builder
+	dependencies  []any
+	listeners     []Listener
+	mu            sync.Mutex
+}
+
+// Change describes the scheme and hook rebuilt after plugins changed and the kinds whose codecs were added, removed or replaced.
+type Change struct {
+	Scheme *scheme.Scheme
+	Hook   *hook.Hook
+	Kinds  []string
+}
+
+// Listener is notified after plugins are loaded, unloaded or upgraded.
+type Listener interface {
+	Reload(ctx context.Context, change Change)
+}
+
+type listener struct {
+	reload func(context.Context, Change)
+}
+
+var _ Listener = (*listener)(nil)
+
+// ListenerFunc creates a new Listener from the provided function.
+func ListenerFunc(reload func(context.Context, Change)) Listener {
+	return &listener{reload: reload}
+}
+
+// NewManager creates a new Manager with the given configuration.
+func NewManager(config ManagerConfig) *Manager {
+	if config.Registry == nil {
+		config.Registry = NewRegistry()
+	}
+	if config.SchemeBuilder == nil {
+		config.SchemeBuilder = scheme.NewBuilder()
+	}
+	if config.HookBuilder == nil {
+		config.HookBuilder = hook.NewBuilder()
+	}
+
+	return &Manager{
+		registry:      config.Registry,
+		loader:        config.Loader,
+		schemeBuilder: config.SchemeBuilder,
+		hookBuilder:   config.HookBuilder,
+		dependencies:  config.Dependencies,
+	}
+}
+
+// AddListener adds a Listener notified after plugins change.
+func (m *Manager) AddListener(listener Listener) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.Contains(m.listeners, listener) {
+		return false
+	}
+	m.listeners = append(m.listeners, listener)
+	return true
+}
+
+// RemoveListener removes a Listener.
+func (m *Manager) RemoveListener(listener Listener) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := slices.Index(m.listeners, listener)
+	if i < 0 {
+		return false
+	}
+	m.listeners = slices.Delete(m.listeners, i, i+1)
+	return true
+}
+
+// Plugins returns the registered plugins.
+func (m *Manager) Plugins() []Plugin {
+	return m.registry.Plugins()
+}
+
+// Load opens the plugin at the path, loads it after injecting the dependencies and rebuilds the scheme and hook.
+func (m *Manager) Load(ctx context.Context, path string, options ...LoadOptions) (Plugin, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, err := m.open(path, options...)
+	if err != nil {
+		return nil, err
+	}
+	if name := p.Name(); name != "" && m.registry.Lookup(name) != nil {
+		return nil, errors.WithMessagef(ErrConflict, "%s", name)
+	}
+
+	registers := slices.Clone(*m.schemeBuilder)
+	hooks := slices.Clone(*m.hookBuilder)
+
+	if err := m.registry.Register(p); err != nil {
+		return nil, err
+	}
+
+	rollback := func(err error) error {
+		if e := m.registry.Remove(ctx, p); e != nil {
+			return errors.WithMessagef(err, "rollback: %v", e)
+		}
+		reorder(m.schemeBuilder, registers)
+		reorder(m.hookBuilder, hooks)
+		return err
+	}
+
+	if err := m.load(ctx); err != nil {
+		return nil, rollback(err)
+	}
+
+	change, err := m.build(registers)
+	if err != nil {
+		return nil, rollback(err)
+	}
+
+	m.notify(ctx, change)
+	return p, nil
+}
+
+// Unload unloads the plugin with the given name and rebuilds the scheme and hook.
+// It fails if other plugins require it, and the plugin is restored if the scheme or hook can not be built without it.
+func (m *Manager) Unload(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.registry.Lookup(name)
+	if p == nil {
+		return errors.WithMessagef(ErrNotFound, "%s", name)
+	}
+
+	registers := slices.Clone(*m.schemeBuilder)
+	hooks := slices.Clone(*m.hookBuilder)
+
+	if err := m.registry.Remove(ctx, p); err != nil {
+		return err
+	}
+
+	change, err := m.build(registers)
+	if err != nil {
+		if e := m.registry.Register(p); e != nil {
+			return errors.WithMessagef(err, "rollback: %v", e)
+		}
+		if e := m.load(ctx); e != nil {
+			return errors.WithMessagef(err, "rollback: %v", e)
+		}
+		reorder(m.schemeBuilder, registers)
+		reorder(m.hookBuilder, hooks)
+		return err
+	}
+
+	m.notify(ctx, change)
+	return nil
+}
+
+// Upgrade replaces the plugin with the given name by the plugin at the path and rebuilds the scheme and hook.
+// The previous plugin is restored if the new one fails to load.
+func (m *Manager) Upgrade(ctx context.Context, name, path string, options ...LoadOptions) (Plugin, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old := m.registry.Lookup(name)
+	if old == nil {
+		return nil, errors.WithMessagef(ErrNotFound, "%s", name)
+	}
+
+	p, err := m.open(path, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	registers := slices.Clone(*m.schemeBuilder)
+	hooks := slices.Clone(*m.hookBuilder)
+
+	if err := m.registry.Replace(ctx, old, p); err != nil {
+		return nil, err
+	}
+
+	rollback := func(err error) error {
+		if e := m.registry.Replace(ctx, p, old); e != nil {
+			return errors.WithMessagef(err, "rollback: %v", e)
+		}
+		if e := m.registry.Load(ctx); e != nil {
+			return errors.WithMessagef(err, "rollback: %v", e)
+		}
+		reorder(m.schemeBuilder, registers)
+		reorder(m.hookBuilder, hooks)
+		return err
+	}
+
+	if err := m.load(ctx); err != nil {
+		return nil, rollback(err)
+	}
+
+	change, err := m.build(registers)
+	if err != nil {
+		return nil, rollback(err)
+	}
+
+	m.notify(ctx, change)
+	return p, nil
+}
+
+func (m *Manager) open(path string, options ...LoadOptions) (Plugin, error) {
+	if m.loader == nil {
+		return nil, errors.WithStack(ErrLoaderNotConfigured)
+	}
+	return m.loader.Open(path, options...)
+}
+
+func (m *Manager) load(ctx context.Context) error {
+	for _, dep := range m.dependencies {
+		if _, err := m.registry.Inject(dep); err != nil {
+			return err
+		}
+	}
+	return m.registry.Load(ctx)
+}
+
+func (m *Manager) build(registers []scheme.Register) (Change, error) {
+	sc, err := m.schemeBuilder.Build()
+	if err != nil {
+		return Change{}, err
+	}
+	hk, err := m.hookBuilder.Build()
+	if err != nil {
+		return Change{}, err
+	}
+
+	var kinds []string
+	for _, r := range registers {
+		if !slices.Contains(*m.schemeBuilder, r) {
+			kinds = append(kinds, kindsOf(r)...)
+		}
+	}
+	for _, r := range *m.schemeBuilder {
+		if !slices.Contains(registers, r) {
+			kinds = append(kinds, kindsOf(r)...)
+		}
+	}
+	slices.Sort(kinds)
+
+	return Change{Scheme: sc, Hook: hk, Kinds: slices.Compact(kinds)}, nil
+}
+
+func (m *Manager) notify(ctx context.Context, change Change) {
+	for _, l := range m.listeners {
+		l.Reload(ctx, change)
+	}
+}
+
+func (l *listener) Reload(ctx context.Context, change Change) {
+	l.reload(ctx, change)
+}
+
+// reorder moves the registers restored by a rollback back to their previous positions.
+func reorder[S ~[]E, E comparable](builder *S, previous S) {
+	sorted := make(S, 0, len(*builder))
+	for _, r := range previous {
+		if slices.Contains(*builder, r) {
+			sorted = append(sorted, r)
+		}
+	}
+	for _, r := range *builder {
+		if !slices.Contains(sorted, r) {
+			sorted = append(sorted, r)
+		}
+	}
+	*builder = sorted
+}
+
+func kindsOf(register scheme.Register) []string {
+	s := scheme.New()
+	_ = register.AddToScheme(s)
+	return s.Kinds()
+}
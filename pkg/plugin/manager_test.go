@@ -0,0 +1,172 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/hook"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+)
+
+func TestManager_Load(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	fs := afero.NewMemMapFs()
+	writePlugin(t, fs, "foo", "foo", "v1.0.0", "foo")
+
+	schemeBuilder := scheme.NewBuilder()
+	hookBuilder := hook.NewBuilder()
+
+	m := NewManager(ManagerConfig{
+		Loader:        NewLoader(fs),
+		SchemeBuilder: schemeBuilder,
+		HookBuilder:   hookBuilder,
+		Dependencies:  []any{schemeBuilder},
+	})
+
+	var changes []Change
+	m.AddListener(ListenerFunc(func(_ context.Context, change Change) {
+		changes = append(changes, change)
+	}))
+
+	p, err := m.Load(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "foo", p.Name())
+	require.Len(t, m.Plugins(), 1)
+
+	require.Len(t, changes, 1)
+	require.Equal(t, []string{"foo"}, changes[0].Kinds)
+	require.NotNil(t, changes[0].Scheme.Codec("foo"))
+
+	_, err = m.Load(ctx, "foo")
+	require.ErrorIs(t, err, ErrConflict)
+
+	writePlugin(t, fs, "bar", "bar", "v1.0.0", "bar")
+
+	hookBuilder.Register(hook.RegisterFunc(func(_ *hook.Hook) error {
+		return ErrIncompatibleDependency
+	}))
+
+	registers := slices.Clone(*schemeBuilder)
+	hooks := slices.Clone(*hookBuilder)
+
+	_, err = m.Load(ctx, "bar")
+	require.ErrorIs(t, err, ErrIncompatibleDependency)
+	require.Len(t, m.Plugins(), 1)
+	require.Equal(t, registers, *schemeBuilder)
+	require.Equal(t, hooks, *hookBuilder)
+
+	_, err = NewManager(ManagerConfig{}).Load(ctx, "foo")
+	require.ErrorIs(t, err, ErrLoaderNotConfigured)
+}
+
+func TestManager_Unload(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	fs := afero.NewMemMapFs()
+	writePlugin(t, fs, "foo", "foo", "v1.0.0", "foo")
+
+	schemeBuilder := scheme.NewBuilder()
+
+	m := NewManager(ManagerConfig{
+		Loader:        NewLoader(fs),
+		SchemeBuilder: schemeBuilder,
+		Dependencies:  []any{schemeBuilder},
+	})
+
+	_, err := m.Load(ctx, "foo")
+	require.NoError(t, err)
+
+	var changes []Change
+	m.AddListener(ListenerFunc(func(_ context.Context, change Change) {
+		changes = append(changes, change)
+	}))
+
+	err = m.Unload(ctx, "foo")
+	require.NoError(t, err)
+	require.Empty(t, m.Plugins())
+
+	require.Len(t, changes, 1)
+	require.Equal(t, []string{"foo"}, changes[0].Kinds)
+	require.Nil(t, changes[0].Scheme.Codec("foo"))
+
+	err = m.Unload(ctx, "foo")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = m.Load(ctx, "foo")
+	require.NoError(t, err)
+
+	schemeBuilder.Register(scheme.RegisterFunc(func(s *scheme.Scheme) error {
+		if s.Codec("foo") == nil {
+			return ErrMissingDependency
+		}
+		return nil
+	}))
+
+	err = m.Unload(ctx, "foo")
+	require.ErrorIs(t, err, ErrMissingDependency)
+	require.Len(t, m.Plugins(), 1)
+
+	sc, err := schemeBuilder.Build()
+	require.NoError(t, err)
+	require.NotNil(t, sc.Codec("foo"))
+}
+
+func TestManager_Upgrade(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	fs := afero.NewMemMapFs()
+	writePlugin(t, fs, "v1", "foo", "v1.0.0", "foo")
+	writePlugin(t, fs, "v2", "foo", "v2.0.0", "foo", "bar")
+
+	schemeBuilder := scheme.NewBuilder()
+
+	m := NewManager(ManagerConfig{
+		Loader:        NewLoader(fs),
+		SchemeBuilder: schemeBuilder,
+		Dependencies:  []any{schemeBuilder},
+	})
+
+	_, err := m.Load(ctx, "v1")
+	require.NoError(t, err)
+
+	var changes []Change
+	m.AddListener(ListenerFunc(func(_ context.Context, change Change) {
+		changes = append(changes, change)
+	}))
+
+	p, err := m.Upgrade(ctx, "foo", "v2")
+	require.NoError(t, err)
+	require.Equal(t, "v2.0.0", p.Version())
+	require.Equal(t, []Plugin{p}, m.Plugins())
+
+	require.Len(t, changes, 1)
+	require.Equal(t, []string{"bar", "foo"}, changes[0].Kinds)
+	require.NotNil(t, changes[0].Scheme.Codec("bar"))
+
+	_, err = m.Upgrade(ctx, "bar", "v2")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func writePlugin(t *testing.T, fs afero.Fs, dir, name, version string, kinds ...string) {
+	tmpl, err := template.ParseGlob(filepath.Join("testdata", "plugin", "*.tmpl"))
+	require.NoError(t, err)
+
+	data := map[string]any{"Module": filepath.Base(dir), "Name": name, "Version": version, "Kinds": kinds}
+	for _, file := range []string{"main.go", "go.mod"} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, tmpl.ExecuteTemplate(buf, file+".tmpl", data))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, file), buf.Bytes(), 0644))
+	}
+}
@@ -11,7 +11,10 @@ type proxy struct {
 	methods  map[string]reflect.Value
 }
 
-var _ Plugin = (*proxy)(nil)
+var (
+	_ Plugin    = (*proxy)(nil)
+	_ Dependent = (*proxy)(nil)
+)
 
 func (p *proxy) Name() string {
 	m, ok := p.methods["Name"]
@@ -43,6 +46,22 @@ func (p *proxy) Version() string {
 	return ret[0].Interface().(string)
 }
 
+func (p *proxy) Dependencies() []Dependency {
+	m, ok := p.methods["Dependencies"]
+	if !ok || !m.IsValid() {
+		return nil
+	}
+
+	t := m.Type()
+	if t.NumIn() != 1 || t.NumOut() != 1 || !t.Out(0).AssignableTo(reflect.TypeOf([]Dependency(nil))) {
+		return nil
+	}
+
+	ret := m.Call([]reflect.Value{p.receiver})
+	deps, _ := ret[0].Interface().([]Dependency)
+	return deps
+}
+
 func (p *proxy) SetXXX(dep any) error {
 	dv := reflect.ValueOf(dep)
 	dt := dv.Type()
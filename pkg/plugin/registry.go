@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 
@@ -12,6 +13,7 @@ import (
 // Registry manages a list of plugins and controls their lifecycle.
 type Registry struct {
 	plugins []Plugin
+	loaded  map[Plugin]bool
 	mu      sync.RWMutex
 }
 
@@ -22,7 +24,7 @@ var (
 
 // NewRegistry creates a new plugin registry.
 func NewRegistry() *Registry {
-	return &Registry{}
+	return &Registry{loaded: make(map[Plugin]bool)}
 }
 
 // Register adds a plugin to the registry.
@@ -53,6 +55,19 @@ func (r *Registry) Unregister(plugin Plugin) error {
 	return errors.WithStack(ErrNotFound)
 }
 
+// Lookup returns the registered plugin with the given name, or nil if there is none.
+func (r *Registry) Lookup(name string) Plugin {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.plugins {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
 // Plugins returns a slice of all registered plugins.
 func (r *Registry) Plugins() []Plugin {
 	r.mu.RLock()
@@ -61,13 +76,17 @@ func (r *Registry) Plugins() []Plugin {
 	return append([]Plugin(nil), r.plugins...)
 }
 
-// Inject attempts to inject the given dependency into all registered plugins.
+// Inject attempts to inject the given dependency into all registered plugins that are not loaded yet.
 func (r *Registry) Inject(dependency any) (int, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	count := 0
 	for _, p := range r.plugins {
+		if r.loaded[p] {
+			continue
+		}
+
 		pv := reflect.ValueOf(p)
 		pt := pv.Type()
 
@@ -97,28 +116,216 @@ func (r *Registry) Inject(dependency any) (int, error) {
 	return count, nil
 }
 
-// Load calls Load on all registered plugins.
+// Load calls Load on all registered plugins that are not loaded yet, loading dependencies before the plugins that require them.
 func (r *Registry) Load(ctx context.Context) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for i := 0; i < len(r.plugins); i++ {
-		if err := r.plugins[i].Load(ctx); err != nil {
+	plugins, err := resolve(r.plugins)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(plugins); i++ {
+		if r.loaded[plugins[i]] {
+			continue
+		}
+		if err := plugins[i].Load(ctx); err != nil {
 			return err
 		}
+		r.loaded[plugins[i]] = true
 	}
 	return nil
 }
 
-// Unload calls Unload on all registered plugins in reverse order.
+// Unload calls Unload on all registered plugins in the reverse of the load order.
 func (r *Registry) Unload(ctx context.Context) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for i := len(r.plugins) - 1; i >= 0; i-- {
-		if err := r.plugins[i].Unload(ctx); err != nil {
+	plugins, err := resolve(r.plugins)
+	if err != nil {
+		return err
+	}
+
+	for i := len(plugins) - 1; i >= 0; i-- {
+		if err := plugins[i].Unload(ctx); err != nil {
+			return err
+		}
+		delete(r.loaded, plugins[i])
+	}
+	return nil
+}
+
+// Remove unloads the plugin if it is loaded and removes it from the registry.
+// It fails without changes if the remaining plugins require it.
+func (r *Registry) Remove(ctx context.Context, plugin Plugin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.Index(r.plugins, plugin)
+	if i < 0 {
+		return errors.WithStack(ErrNotFound)
+	}
+
+	plugins := slices.Delete(slices.Clone(r.plugins), i, i+1)
+	if _, err := resolve(plugins); err != nil {
+		return err
+	}
+
+	if r.loaded[plugin] {
+		if err := plugin.Unload(ctx); err != nil {
+			return err
+		}
+		delete(r.loaded, plugin)
+	}
+
+	r.plugins = plugins
+	return nil
+}
+
+// Replace unloads the old plugin and registers the given one in its place, to be loaded by the next call to Load.
+// It fails without changes if the dependencies of the registered plugins are not satisfied by the new one.
+func (r *Registry) Replace(ctx context.Context, old, plugin Plugin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.Contains(r.plugins, plugin) {
+		return errors.WithStack(ErrConflict)
+	}
+
+	i := slices.Index(r.plugins, old)
+	if i < 0 {
+		return errors.WithStack(ErrNotFound)
+	}
+
+	plugins := slices.Clone(r.plugins)
+	plugins[i] = plugin
+	if _, err := resolve(plugins); err != nil {
+		return err
+	}
+
+	if r.loaded[old] {
+		if err := old.Unload(ctx); err != nil {
 			return err
 		}
+		delete(r.loaded, old)
 	}
+
+	r.plugins = plugins
 	return nil
 }
+
+// resolve checks the dependencies of the plugins and sorts them topologically,
+// keeping the registration order among plugins that do not depend on each other.
+func resolve(plugins []Plugin) ([]Plugin, error) {
+	dependents := false
+	for _, p := range plugins {
+		if _, ok := p.(Dependent); ok {
+			dependents = true
+			break
+		}
+	}
+	if !dependents {
+		return slices.Clone(plugins), nil
+	}
+
+	names := make(map[string]int, len(plugins))
+	for i, p := range plugins {
+		if name := p.Name(); name != "" {
+			names[name] = i
+		}
+	}
+
+	edges := make([][]int, len(plugins))
+	for i, p := range plugins {
+		d, ok := p.(Dependent)
+		if !ok {
+			continue
+		}
+
+		var groups []string
+		alternatives := map[string][]string{}
+		present := map[string]bool{}
+
+		for _, dep := range d.Dependencies() {
+			if dep.Group != "" {
+				if _, ok := alternatives[dep.Group]; !ok {
+					groups = append(groups, dep.Group)
+				}
+				alternatives[dep.Group] = append(alternatives[dep.Group], dep.Name)
+			}
+
+			j, ok := names[dep.Name]
+			if !ok {
+				if dep.Optional || dep.Group != "" {
+					continue
+				}
+				return nil, errors.WithMessagef(ErrMissingDependency, "%s requires %s", label(p), dep.Name)
+			}
+			present[dep.Group] = true
+
+			if dep.Version != "" {
+				constraint, err := ParseConstraint(dep.Version)
+				if err != nil {
+					return nil, errors.WithMessagef(err, "%s requires %s", label(p), dep.Name)
+				}
+				if version := plugins[j].Version(); version != "" && !constraint.Check(version) {
+					return nil, errors.WithMessagef(ErrIncompatibleDependency, "%s requires %s %s, but %s is registered", label(p), dep.Name, constraint, version)
+				}
+			}
+			edges[i] = append(edges[i], j)
+		}
+
+		for _, group := range groups {
+			if !present[group] {
+				return nil, errors.WithMessagef(ErrMissingDependency, "%s requires one of %s", label(p), strings.Join(alternatives[group], ", "))
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make([]int, len(plugins))
+	sorted := make([]Plugin, 0, len(plugins))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		path = append(path, label(plugins[i]))
+		switch states[i] {
+		case visiting:
+			return errors.WithMessage(ErrCyclicDependency, strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+
+		states[i] = visiting
+		for _, j := range edges[i] {
+			if err := visit(j, path); err != nil {
+				return err
+			}
+		}
+		states[i] = visited
+
+		sorted = append(sorted, plugins[i])
+		return nil
+	}
+
+	for i := range plugins {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func label(p Plugin) string {
+	if name := p.Name(); name != "" {
+		return name
+	}
+	return reflect.TypeOf(p).String()
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,3 +89,218 @@ func TestRegistry_Unload(t *testing.T) {
 	err = r.Unload(ctx)
 	require.NoError(t, err)
 }
+
+func TestRegistry_Dependencies(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	newPlugin := func(name, version string, deps ...Dependency) *dependentMock {
+		m := &dependentMock{Mock: NewMock(t), dependencies: deps}
+		m.On("Name").Return(name).Maybe()
+		m.On("Version").Return(version).Maybe()
+		return m
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		r := NewRegistry()
+
+		var order []string
+
+		ctrl := newPlugin("ctrl", "v0.14.0", Dependency{Name: "cel", Version: ">=0.14.0"}, Dependency{Name: "ecmascript", Optional: true})
+		cel := newPlugin("cel", "v0.14.0-3f2a1b0")
+		net := newPlugin("net", "v0.14.0")
+
+		for _, m := range []*dependentMock{ctrl, cel, net} {
+			m.On("Load", ctx).Run(func(mock.Arguments) { order = append(order, "load "+m.Name()) }).Return(nil)
+			m.On("Unload", ctx).Run(func(mock.Arguments) { order = append(order, "unload "+m.Name()) }).Return(nil)
+
+			err := r.Register(m)
+			require.NoError(t, err)
+		}
+
+		err := r.Load(ctx)
+		require.NoError(t, err)
+
+		err = r.Unload(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"load cel", "load ctrl", "load net", "unload net", "unload ctrl", "unload cel"}, order)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		r := NewRegistry()
+
+		err := r.Register(newPlugin("ctrl", "v0.14.0", Dependency{Name: "cel"}))
+		require.NoError(t, err)
+
+		err = r.Load(ctx)
+		require.ErrorIs(t, err, ErrMissingDependency)
+		require.ErrorContains(t, err, "ctrl requires cel")
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		deps := []Dependency{{Name: "cel", Group: "language"}, {Name: "ecmascript", Group: "language"}}
+
+		r := NewRegistry()
+
+		err := r.Register(newPlugin("ctrl", "v0.14.0", deps...))
+		require.NoError(t, err)
+
+		err = r.Load(ctx)
+		require.ErrorIs(t, err, ErrMissingDependency)
+		require.ErrorContains(t, err, "ctrl requires one of cel, ecmascript")
+
+		r = NewRegistry()
+
+		ctrl := newPlugin("ctrl", "v0.14.0", deps...)
+		ecmascript := newPlugin("ecmascript", "v0.14.0")
+
+		for _, m := range []*dependentMock{ctrl, ecmascript} {
+			m.On("Load", ctx).Return(nil)
+
+			err := r.Register(m)
+			require.NoError(t, err)
+		}
+
+		err = r.Load(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		r := NewRegistry()
+
+		err := r.Register(newPlugin("ctrl", "v0.14.0", Dependency{Name: "cel", Version: "^1.0.0"}))
+		require.NoError(t, err)
+		err = r.Register(newPlugin("cel", "v0.14.0"))
+		require.NoError(t, err)
+
+		err = r.Load(ctx)
+		require.ErrorIs(t, err, ErrIncompatibleDependency)
+		require.ErrorContains(t, err, "ctrl requires cel >=v1.0.0, <v2.0.0, but v0.14.0 is registered")
+	})
+
+	t.Run("Cyclic", func(t *testing.T) {
+		r := NewRegistry()
+
+		err := r.Register(newPlugin("foo", "", Dependency{Name: "bar"}))
+		require.NoError(t, err)
+		err = r.Register(newPlugin("bar", "", Dependency{Name: "foo"}))
+		require.NoError(t, err)
+
+		err = r.Load(ctx)
+		require.ErrorIs(t, err, ErrCyclicDependency)
+		require.ErrorContains(t, err, "foo -> bar -> foo")
+	})
+}
+
+type dependentMock struct {
+	*Mock
+	dependencies []Dependency
+}
+
+var _ Dependent = (*dependentMock)(nil)
+
+func (m *dependentMock) Dependencies() []Dependency {
+	return m.dependencies
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	r := NewRegistry()
+	m := NewMock(t)
+
+	m.On("Name").Return("foo")
+
+	err := r.Register(m)
+	require.NoError(t, err)
+
+	require.Equal(t, m, r.Lookup("foo"))
+	require.Nil(t, r.Lookup("bar"))
+}
+
+func TestRegistry_Remove(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	r := NewRegistry()
+
+	cel := &dependentMock{Mock: NewMock(t)}
+	cel.On("Name").Return("cel").Maybe()
+	cel.On("Load", ctx).Return(nil)
+	cel.On("Unload", ctx).Return(nil)
+
+	ctrl := &dependentMock{Mock: NewMock(t), dependencies: []Dependency{{Name: "cel"}}}
+	ctrl.On("Name").Return("ctrl").Maybe()
+	ctrl.On("Version").Return("").Maybe()
+	ctrl.On("Load", ctx).Return(nil)
+	ctrl.On("Unload", ctx).Return(nil)
+
+	err := r.Register(cel)
+	require.NoError(t, err)
+	err = r.Register(ctrl)
+	require.NoError(t, err)
+
+	err = r.Load(ctx)
+	require.NoError(t, err)
+
+	err = r.Remove(ctx, cel)
+	require.ErrorIs(t, err, ErrMissingDependency)
+	require.Len(t, r.Plugins(), 2)
+
+	err = r.Remove(ctx, ctrl)
+	require.NoError(t, err)
+	err = r.Remove(ctx, cel)
+	require.NoError(t, err)
+	require.Empty(t, r.Plugins())
+
+	err = r.Remove(ctx, cel)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRegistry_Replace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	r := NewRegistry()
+
+	old := &dependentMock{Mock: NewMock(t)}
+	old.On("Name").Return("cel").Maybe()
+	old.On("Version").Return("v1.0.0").Maybe()
+	old.On("Load", ctx).Return(nil).Once()
+	old.On("Unload", ctx).Return(nil).Once()
+
+	ctrl := &dependentMock{Mock: NewMock(t), dependencies: []Dependency{{Name: "cel", Version: "^1"}}}
+	ctrl.On("Name").Return("ctrl").Maybe()
+	ctrl.On("Load", ctx).Return(nil).Once()
+
+	err := r.Register(old)
+	require.NoError(t, err)
+	err = r.Register(ctrl)
+	require.NoError(t, err)
+
+	err = r.Load(ctx)
+	require.NoError(t, err)
+
+	incompatible := &dependentMock{Mock: NewMock(t)}
+	incompatible.On("Name").Return("cel").Maybe()
+	incompatible.On("Version").Return("v2.0.0").Maybe()
+
+	err = r.Replace(ctx, old, incompatible)
+	require.ErrorIs(t, err, ErrIncompatibleDependency)
+
+	upgrade := &dependentMock{Mock: NewMock(t)}
+	upgrade.On("Name").Return("cel").Maybe()
+	upgrade.On("Version").Return("v1.1.0").Maybe()
+	upgrade.On("SetXXX", "foo").Return(nil).Once()
+	upgrade.On("Load", ctx).Return(nil).Once()
+
+	err = r.Replace(ctx, old, upgrade)
+	require.NoError(t, err)
+	require.Equal(t, []Plugin{upgrade, ctrl}, r.Plugins())
+
+	count, err := r.Inject("foo")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	err = r.Load(ctx)
+	require.NoError(t, err)
+}
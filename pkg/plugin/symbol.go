@@ -26,12 +26,16 @@ func init() {
 //_go:generate yaegi extract github.com/siyul-park/uniflow/pkg/plugin
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/port
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/process
+//go:generate yaegi extract github.com/siyul-park/uniflow/pkg/resolver
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/runtime
+//go:generate yaegi extract github.com/siyul-park/uniflow/pkg/schema
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/scheme
+//go:generate yaegi extract github.com/siyul-park/uniflow/pkg/secret
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/spec
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/symbol
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/testing
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/types
+//go:generate yaegi extract github.com/siyul-park/uniflow/pkg/validation
 //go:generate yaegi extract github.com/siyul-park/uniflow/pkg/value
 
 //go:generate yaegi extract github.com/gofrs/uuid
@@ -9,6 +9,7 @@ import (
 
 // InPort represents an input port used for receiving data.
 type InPort struct {
+	key        string
 	readers    map[*process.Process]*packet.Reader
 	openHooks  OpenHooks
 	closeHooks CloseHooks
@@ -16,6 +17,10 @@ type InPort struct {
 	mu         sync.RWMutex
 }
 
+type interception struct {
+	in *InPort
+}
+
 // NewIn creates and returns a new InPort instance.
 func NewIn() *InPort {
 	return &InPort{
@@ -23,6 +28,22 @@ func NewIn() *InPort {
 	}
 }
 
+// SetKey sets a stable identity of the port, used to distribute packets to the same port across reloads.
+func (p *InPort) SetKey(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.key = key
+}
+
+// Key returns the stable identity of the port.
+func (p *InPort) Key() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.key
+}
+
 // AddOpenHook adds a hook to the port if it is not already present.
 func (p *InPort) AddOpenHook(hook OpenHook) bool {
 	p.mu.Lock()
@@ -93,6 +114,24 @@ func (p *InPort) AddListener(listener Listener) bool {
 	return true
 }
 
+// Intercept makes the listener accept the process and its descendants in place of the listeners of the port.
+// It takes effect for processes that open the port afterward.
+func (p *InPort) Intercept(proc *process.Process, listener Listener) {
+	proc.SetValue(interception{in: p}, listener)
+}
+
+// Pending returns the number of packets received by the port but not yet answered.
+func (p *InPort) Pending() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	pending := 0
+	for _, reader := range p.readers {
+		pending += reader.Pending()
+	}
+	return pending
+}
+
 // Open prepares the input port for a given process and returns a reader.
 func (p *InPort) Open(proc *process.Process) *packet.Reader {
 	if proc.Status() == process.StatusTerminated {
@@ -122,6 +161,10 @@ func (p *InPort) Open(proc *process.Process) *packet.Reader {
 
 	p.mu.Unlock()
 
+	if listener, ok := proc.Value(interception{in: p}).(Listener); ok {
+		listeners = Listeners{listener}
+	}
+
 	proc.AddExitHook(process.ExitFunc(func(_ error) {
 		p.mu.Lock()
 		delete(p.readers, proc)
@@ -116,6 +116,50 @@ func TestInPort_Listener(t *testing.T) {
 	}
 }
 
+func TestInPort_Intercept(t *testing.T) {
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	other := process.New()
+	defer other.Exit(nil)
+
+	in := NewIn()
+	defer in.Close()
+
+	accepted := make(chan *process.Process, 2)
+	in.AddListener(ListenFunc(func(proc *process.Process) {
+		accepted <- proc
+	}))
+
+	intercepted := make(chan *process.Process, 1)
+	in.Intercept(proc, ListenFunc(func(proc *process.Process) {
+		intercepted <- proc
+	}))
+
+	child := proc.Fork()
+	defer child.Exit(nil)
+
+	_ = in.Open(child)
+	_ = in.Open(other)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	select {
+	case p := <-intercepted:
+		require.Equal(t, child, p)
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err())
+	}
+
+	select {
+	case p := <-accepted:
+		require.Equal(t, other, p)
+	case <-ctx.Done():
+		require.NoError(t, ctx.Err())
+	}
+}
+
 func BenchmarkInPort_Open(b *testing.B) {
 	in := NewIn()
 	defer in.Close()
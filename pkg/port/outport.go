@@ -14,6 +14,7 @@ type OutPort struct {
 	openHooks  OpenHooks
 	closeHooks CloseHooks
 	listeners  Listeners
+	strategy   Strategy
 	mu         sync.RWMutex
 }
 
@@ -94,6 +95,22 @@ func (p *OutPort) AddListener(listener Listener) bool {
 	return true
 }
 
+// SetStrategy sets the strategy distributing packets across linked input ports. A nil strategy broadcasts.
+func (p *OutPort) SetStrategy(strategy Strategy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.strategy = strategy
+}
+
+// Strategy returns the strategy distributing packets across linked input ports.
+func (p *OutPort) Strategy() Strategy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.strategy
+}
+
 // Links returns the number of input ports this port is connected to.
 func (p *OutPort) Links() []*InPort {
 	p.mu.RLock()
@@ -175,11 +192,41 @@ func (p *OutPort) Open(proc *process.Process) *packet.Writer {
 		writer.Close()
 	}))
 
+	targets := make(map[*packet.Reader]*InPort, len(ins))
 	for _, in := range ins {
 		reader := in.Open(proc)
+		targets[reader] = in
 		writer.Link(reader)
 	}
 
+	writer.SetSelector(packet.SelectorFunc(func(pck *packet.Packet, readers []*packet.Reader) []int {
+		strategy := p.Strategy()
+		if strategy == nil {
+			indexes := make([]int, len(readers))
+			for i := range readers {
+				indexes[i] = i
+			}
+			return indexes
+		}
+
+		indexes := make([]int, 0, len(readers))
+		candidates := make([]*InPort, 0, len(readers))
+		for i, reader := range readers {
+			if in, ok := targets[reader]; ok {
+				indexes = append(indexes, i)
+				candidates = append(candidates, in)
+			}
+		}
+
+		var selected []int
+		for _, i := range strategy.Select(pck, candidates) {
+			if i >= 0 && i < len(indexes) {
+				selected = append(selected, indexes[i])
+			}
+		}
+		return selected
+	}))
+
 	return writer
 }
 
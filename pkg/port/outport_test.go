@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/process"
 )
 
@@ -55,6 +56,39 @@ func TestOutPort_Unlink(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestOutPort_SetStrategy(t *testing.T) {
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	in1 := NewIn()
+	defer in1.Close()
+
+	in2 := NewIn()
+	defer in2.Close()
+
+	out := NewOut()
+	defer out.Close()
+
+	out.Link(in1)
+	out.Link(in2)
+
+	strategy := RoundRobin()
+	out.SetStrategy(strategy)
+	require.Equal(t, strategy, out.Strategy())
+
+	writer := out.Open(proc)
+
+	count := writer.Write(packet.New(nil))
+	require.Equal(t, 1, count)
+	require.Equal(t, 1, in1.Pending())
+	require.Equal(t, 0, in2.Pending())
+
+	count = writer.Write(packet.New(nil))
+	require.Equal(t, 1, count)
+	require.Equal(t, 1, in1.Pending())
+	require.Equal(t, 1, in2.Pending())
+}
+
 func TestOutPort_OpenHook(t *testing.T) {
 	proc := process.New()
 	defer proc.Exit(nil)
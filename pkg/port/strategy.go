@@ -0,0 +1,184 @@
+package port
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// Strategy decides which linked input ports receive a packet written to an output port.
+type Strategy interface {
+	// Select returns the indexes of the input ports the packet is sent to.
+	Select(pck *packet.Packet, ins []*InPort) []int
+}
+
+type broadcast struct{}
+
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+type random struct{}
+
+type leastBusy struct{}
+
+type hash struct {
+	paths []string
+}
+
+// Strategy names supported by NewStrategy.
+const (
+	StrategyBroadcast  = "broadcast"
+	StrategyRoundRobin = "round-robin"
+	StrategyRandom     = "random"
+	StrategyLeastBusy  = "least-busy"
+	StrategyHash       = "hash"
+)
+
+var (
+	_ Strategy = (*broadcast)(nil)
+	_ Strategy = (*roundRobin)(nil)
+	_ Strategy = (*random)(nil)
+	_ Strategy = (*leastBusy)(nil)
+	_ Strategy = (*hash)(nil)
+)
+
+// NewStrategy creates the Strategy with the given name. The key is a dot-separated payload path used by the hash strategy.
+func NewStrategy(name, key string) (Strategy, error) {
+	switch name {
+	case "", StrategyBroadcast:
+		return Broadcast(), nil
+	case StrategyRoundRobin:
+		return RoundRobin(), nil
+	case StrategyRandom:
+		return Random(), nil
+	case StrategyLeastBusy:
+		return LeastBusy(), nil
+	case StrategyHash:
+		if key == "" {
+			return nil, errors.WithMessagef(encoding.ErrUnsupportedValue, "%s strategy requires a key", name)
+		}
+		return Hash(key), nil
+	default:
+		return nil, errors.WithMessagef(encoding.ErrUnsupportedValue, "strategy %q", name)
+	}
+}
+
+// Broadcast returns a Strategy that sends every packet to all input ports.
+func Broadcast() Strategy {
+	return &broadcast{}
+}
+
+// RoundRobin returns a Strategy that sends each packet to the next input port in turn.
+func RoundRobin() Strategy {
+	return &roundRobin{}
+}
+
+// Random returns a Strategy that sends each packet to a randomly chosen input port.
+func Random() Strategy {
+	return &random{}
+}
+
+// LeastBusy returns a Strategy that sends each packet to the input port with the fewest pending packets.
+func LeastBusy() Strategy {
+	return &leastBusy{}
+}
+
+// Hash returns a Strategy that consistently sends packets with the same value at the key path to the same input port.
+func Hash(key string) Strategy {
+	var paths []string
+	if key != "" {
+		paths = strings.Split(key, ".")
+	}
+	return &hash{paths: paths}
+}
+
+func (*broadcast) Select(_ *packet.Packet, ins []*InPort) []int {
+	indexes := make([]int, len(ins))
+	for i := range ins {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+func (s *roundRobin) Select(_ *packet.Packet, ins []*InPort) []int {
+	if len(ins) == 0 {
+		return nil
+	}
+	return []int{int((s.next.Add(1) - 1) % uint64(len(ins)))}
+}
+
+func (*random) Select(_ *packet.Packet, ins []*InPort) []int {
+	if len(ins) == 0 {
+		return nil
+	}
+	return []int{rand.IntN(len(ins))}
+}
+
+func (*leastBusy) Select(_ *packet.Packet, ins []*InPort) []int {
+	selected := -1
+	least := 0
+	for i, in := range ins {
+		if pending := in.Pending(); selected < 0 || pending < least {
+			selected = i
+			least = pending
+		}
+	}
+	if selected < 0 {
+		return nil
+	}
+	return []int{selected}
+}
+
+func (s *hash) Select(pck *packet.Packet, ins []*InPort) []int {
+	if len(ins) == 0 {
+		return nil
+	}
+
+	cur := pck.Payload()
+	for _, path := range s.paths {
+		switch v := cur.(type) {
+		case types.Map:
+			cur = v.Get(types.NewString(path))
+		case types.Slice:
+			index, err := strconv.Atoi(path)
+			if err != nil || index < 0 {
+				cur = nil
+			} else {
+				cur = v.Get(index)
+			}
+		default:
+			cur = nil
+		}
+	}
+	key := types.HashOf(cur)
+
+	// Rendezvous hashing keeps most keys on the same port when links change.
+	// Ports are identified by their keys so that the mapping survives reloads and restarts.
+	selected := 0
+	var highest uint64
+	for i, in := range ins {
+		id := in.Key()
+		if id == "" {
+			id = strconv.Itoa(i)
+		}
+
+		h := fnv.New64a()
+		_ = binary.Write(h, binary.LittleEndian, key)
+		_, _ = h.Write([]byte(id))
+		if score := h.Sum64(); i == 0 || score > highest {
+			selected = i
+			highest = score
+		}
+	}
+	return []int{selected}
+}
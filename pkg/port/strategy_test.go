@@ -0,0 +1,107 @@
+package port
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range []string{StrategyBroadcast, StrategyRoundRobin, StrategyRandom, StrategyLeastBusy, StrategyHash} {
+		s, err := NewStrategy(name, "id")
+		require.NoError(t, err)
+		require.NotNil(t, s)
+	}
+
+	_, err := NewStrategy(StrategyHash, "")
+	require.ErrorIs(t, err, encoding.ErrUnsupportedValue)
+
+	_, err = NewStrategy("unknown", "")
+	require.ErrorIs(t, err, encoding.ErrUnsupportedValue)
+}
+
+func TestBroadcast_Select(t *testing.T) {
+	ins := []*InPort{NewIn(), NewIn()}
+	require.Equal(t, []int{0, 1}, Broadcast().Select(packet.None, ins))
+}
+
+func TestRoundRobin_Select(t *testing.T) {
+	ins := []*InPort{NewIn(), NewIn()}
+	s := RoundRobin()
+
+	require.Equal(t, []int{0}, s.Select(packet.None, ins))
+	require.Equal(t, []int{1}, s.Select(packet.None, ins))
+	require.Equal(t, []int{0}, s.Select(packet.None, ins))
+	require.Nil(t, s.Select(packet.None, nil))
+}
+
+func TestRandom_Select(t *testing.T) {
+	ins := []*InPort{NewIn(), NewIn()}
+
+	selected := Random().Select(packet.None, ins)
+	require.Len(t, selected, 1)
+	require.GreaterOrEqual(t, selected[0], 0)
+	require.Less(t, selected[0], len(ins))
+}
+
+func TestLeastBusy_Select(t *testing.T) {
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	in1 := NewIn()
+	defer in1.Close()
+
+	in2 := NewIn()
+	defer in2.Close()
+
+	w := packet.NewWriter()
+	defer w.Close()
+
+	w.Link(in1.Open(proc))
+	w.SetSelector(packet.SelectorFunc(func(_ *packet.Packet, _ []*packet.Reader) []int {
+		return []int{0}
+	}))
+	w.Write(packet.None)
+
+	require.Equal(t, []int{1}, LeastBusy().Select(packet.None, []*InPort{in1, in2}))
+}
+
+func TestHash_Select(t *testing.T) {
+	ins := []*InPort{NewIn(), NewIn(), NewIn(), NewIn()}
+	s := Hash("user.id")
+
+	payload := func(id string) *packet.Packet {
+		return packet.New(types.NewMap(
+			types.NewString("user"), types.NewMap(types.NewString("id"), types.NewString(id)),
+		))
+	}
+
+	selected := s.Select(payload("foo"), ins)
+	require.Len(t, selected, 1)
+	require.Equal(t, selected, s.Select(payload("foo"), ins))
+
+	seen := map[int]struct{}{}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seen[s.Select(payload(id), ins)[0]] = struct{}{}
+	}
+	require.Greater(t, len(seen), 1)
+
+	keyed := func() []*InPort {
+		ins := make([]*InPort, 4)
+		for i := range ins {
+			ins[i] = NewIn()
+			ins[i].SetKey(fmt.Sprintf("port-%d", i))
+		}
+		return ins
+	}
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		require.Equal(t, s.Select(payload(id), keyed()), s.Select(payload(id), keyed()))
+	}
+}
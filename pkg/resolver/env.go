@@ -0,0 +1,29 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// EnvResolver resolves env://NAME URIs to the environment variables of the process.
+type EnvResolver struct{}
+
+var _ Resolver = (*EnvResolver)(nil)
+
+// NewEnvResolver creates a new EnvResolver.
+func NewEnvResolver() *EnvResolver {
+	return &EnvResolver{}
+}
+
+// Resolve returns the value of the environment variable named by the URI.
+func (r *EnvResolver) Resolve(_ context.Context, uri *url.URL) (any, error) {
+	name := location(uri)
+	val, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, errors.WithMessage(ErrNotFound, name)
+	}
+	return val, nil
+}
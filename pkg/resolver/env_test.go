@@ -0,0 +1,28 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvResolver_Resolve(t *testing.T) {
+	r := NewEnvResolver()
+
+	data := faker.Password()
+	t.Setenv("UNIFLOW_TEST_PASSWORD", data)
+
+	uri, _ := url.Parse("env://UNIFLOW_TEST_PASSWORD")
+
+	val, err := r.Resolve(context.TODO(), uri)
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+
+	uri, _ = url.Parse("env://UNIFLOW_TEST_UNKNOWN")
+
+	_, err = r.Resolve(context.TODO(), uri)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,47 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/siyul-park/uniflow/internal/fswatch"
+)
+
+// FileResolver resolves file:// URIs to the contents of files, such as mounted secrets.
+type FileResolver struct {
+	fs afero.Fs
+}
+
+var (
+	_ Resolver = (*FileResolver)(nil)
+	_ Watcher  = (*FileResolver)(nil)
+)
+
+// NewFileResolver creates a new FileResolver reading from the given file system.
+func NewFileResolver(fs afero.Fs) *FileResolver {
+	return &FileResolver{fs: fs}
+}
+
+// Resolve returns the contents of the file as a string without its trailing newline.
+func (r *FileResolver) Resolve(_ context.Context, uri *url.URL) (any, error) {
+	filename := location(uri)
+	data, err := afero.ReadFile(r.fs, filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.WithMessage(ErrNotFound, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// Watch starts calling changed whenever the file is modified, replaced or removed.
+func (r *FileResolver) Watch(ctx context.Context, uri *url.URL, changed func()) {
+	filename := location(uri)
+	fswatch.Watch(ctx, r.fs, func() []string { return []string{filename} }, changed)
+}
@@ -0,0 +1,32 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileResolver_Resolve(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	r := NewFileResolver(fs)
+
+	data := faker.Password()
+
+	err := afero.WriteFile(fs, "/run/secrets/db", []byte(data+"\n"), 0600)
+	require.NoError(t, err)
+
+	uri, _ := url.Parse("file:///run/secrets/db")
+
+	val, err := r.Resolve(context.TODO(), uri)
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+
+	uri, _ = url.Parse("file:///run/secrets/unknown")
+
+	_, err = r.Resolve(context.TODO(), uri)
+	require.ErrorIs(t, err, ErrNotFound)
+}
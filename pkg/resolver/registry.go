@@ -0,0 +1,256 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Registry dispatches URIs to the resolver registered for their scheme and caches the results.
+type Registry struct {
+	resolvers map[string]Resolver
+	cache     map[string]any
+	streams   []*Stream
+	watchers  map[string]context.CancelFunc
+	mu        sync.RWMutex
+}
+
+// DefaultInterval is the interval at which watched URIs are resolved again to detect changes,
+// unless their resolver implements Watcher.
+const DefaultInterval = 5 * time.Second
+
+var (
+	ErrAlreadyRegistered = errors.New("resolver already registered")
+	ErrNotRegistered     = errors.New("resolver not registered")
+)
+
+// NewRegistry creates and returns a new Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		resolvers: make(map[string]Resolver),
+		cache:     make(map[string]any),
+		watchers:  make(map[string]context.CancelFunc),
+	}
+}
+
+// Register adds a resolver for the URI scheme. Returns an error if the scheme already has a resolver.
+func (r *Registry) Register(scheme string, resolver Resolver) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.resolvers[scheme]; ok {
+		return errors.WithStack(ErrAlreadyRegistered)
+	}
+	r.resolvers[scheme] = resolver
+	return nil
+}
+
+// Unregister removes the resolver of the URI scheme. Returns an error if the scheme has no resolver.
+func (r *Registry) Unregister(scheme string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.resolvers[scheme]; !ok {
+		return errors.WithStack(ErrNotRegistered)
+	}
+	delete(r.resolvers, scheme)
+	return nil
+}
+
+// Lookup retrieves the resolver of the URI scheme. Returns an error if the scheme has no resolver.
+func (r *Registry) Lookup(scheme string) (Resolver, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	resolver, ok := r.resolvers[scheme]
+	if !ok {
+		return nil, errors.WithStack(ErrNotRegistered)
+	}
+	return resolver, nil
+}
+
+// Resolve returns the data addressed by the URI, resolving it only if it is not cached yet.
+func (r *Registry) Resolve(ctx context.Context, uri string) (any, error) {
+	r.mu.RLock()
+	data, ok := r.cache[uri]
+	r.mu.RUnlock()
+
+	if ok {
+		return data, nil
+	}
+
+	data, err := r.resolve(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cache[uri] = data
+	if len(r.streams) > 0 {
+		r.observe(uri)
+	}
+	return data, nil
+}
+
+// Refresh resolves every cached URI again, updates the cache and notifies watchers of the URIs whose data changed.
+// URIs that fail to resolve keep their cached data.
+func (r *Registry) Refresh(ctx context.Context) []string {
+	r.mu.RLock()
+	uris := make([]string, 0, len(r.cache))
+	for uri := range r.cache {
+		uris = append(uris, uri)
+	}
+	r.mu.RUnlock()
+
+	return r.refresh(ctx, uris...)
+}
+
+// Watch returns a stream of the URIs whose data changed. URIs whose resolver implements Watcher are checked
+// when it reports a change, and the others are checked at the given interval.
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) *Stream {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	s := newStream()
+
+	r.mu.Lock()
+	r.streams = append(r.streams, s)
+	for uri := range r.cache {
+		r.observe(uri)
+	}
+	r.mu.Unlock()
+
+	go func() {
+		defer r.unwatch(s)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.mu.RLock()
+				uris := make([]string, 0, len(r.cache))
+				for uri := range r.cache {
+					if _, ok := r.watchers[uri]; !ok {
+						uris = append(uris, uri)
+					}
+				}
+				r.mu.RUnlock()
+
+				r.refresh(context.WithoutCancel(ctx), uris...)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+// Close closes all streams and clears the cache.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	streams := r.streams
+	r.streams = nil
+	r.cache = make(map[string]any)
+	r.unobserve()
+	r.mu.Unlock()
+
+	for _, s := range streams {
+		_ = s.Close(context.TODO())
+	}
+	return nil
+}
+
+func (r *Registry) resolve(ctx context.Context, uri string) (any, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := r.Lookup(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return resolver.Resolve(ctx, u)
+}
+
+func (r *Registry) refresh(ctx context.Context, uris ...string) []string {
+	var changes []string
+	for _, uri := range uris {
+		data, err := r.resolve(ctx, uri)
+		if err != nil {
+			continue
+		}
+
+		r.mu.Lock()
+		if prev, ok := r.cache[uri]; ok && !reflect.DeepEqual(prev, data) {
+			r.cache[uri] = data
+			changes = append(changes, uri)
+		}
+		r.mu.Unlock()
+	}
+
+	r.mu.RLock()
+	streams := r.streams
+	r.mu.RUnlock()
+
+	for _, uri := range changes {
+		for _, s := range streams {
+			s.emit(uri)
+		}
+	}
+	return changes
+}
+
+// observe starts watching the URI if its resolver implements Watcher. The lock must be held.
+func (r *Registry) observe(uri string) {
+	if _, ok := r.watchers[uri]; ok {
+		return
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return
+	}
+	w, ok := r.resolvers[u.Scheme].(Watcher)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.watchers[uri] = cancel
+
+	w.Watch(ctx, u, func() {
+		r.refresh(ctx, uri)
+	})
+}
+
+// unobserve stops watching all URIs. The lock must be held.
+func (r *Registry) unobserve() {
+	for uri, cancel := range r.watchers {
+		cancel()
+		delete(r.watchers, uri)
+	}
+}
+
+func (r *Registry) unwatch(s *Stream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.streams = slices.DeleteFunc(slices.Clone(r.streams), func(stream *Stream) bool {
+		return stream == s
+	})
+	if len(r.streams) == 0 {
+		r.unobserve()
+	}
+}
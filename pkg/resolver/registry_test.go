@@ -0,0 +1,202 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+	defer r.Close()
+
+	scheme := faker.Word()
+
+	err := r.Register(scheme, NewEnvResolver())
+	require.NoError(t, err)
+
+	err = r.Register(scheme, NewEnvResolver())
+	require.ErrorIs(t, err, ErrAlreadyRegistered)
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	r := NewRegistry()
+	defer r.Close()
+
+	scheme := faker.Word()
+
+	err := r.Register(scheme, NewEnvResolver())
+	require.NoError(t, err)
+
+	err = r.Unregister(scheme)
+	require.NoError(t, err)
+
+	err = r.Unregister(scheme)
+	require.ErrorIs(t, err, ErrNotRegistered)
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	r := NewRegistry()
+	defer r.Close()
+
+	scheme := faker.Word()
+	resolver := NewEnvResolver()
+
+	err := r.Register(scheme, resolver)
+	require.NoError(t, err)
+
+	found, err := r.Lookup(scheme)
+	require.NoError(t, err)
+	require.Equal(t, resolver, found)
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	r := NewRegistry()
+	defer r.Close()
+
+	fs := afero.NewMemMapFs()
+
+	err := r.Register("file", NewFileResolver(fs))
+	require.NoError(t, err)
+
+	data := faker.Password()
+
+	err = afero.WriteFile(fs, "/secret", []byte(data), 0600)
+	require.NoError(t, err)
+
+	val, err := r.Resolve(context.TODO(), "file:///secret")
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+
+	err = afero.WriteFile(fs, "/secret", []byte(faker.Password()), 0600)
+	require.NoError(t, err)
+
+	val, err = r.Resolve(context.TODO(), "file:///secret")
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+
+	_, err = r.Resolve(context.TODO(), "unknown:///secret")
+	require.ErrorIs(t, err, ErrNotRegistered)
+}
+
+func TestRegistry_Refresh(t *testing.T) {
+	r := NewRegistry()
+	defer r.Close()
+
+	fs := afero.NewMemMapFs()
+
+	err := r.Register("file", NewFileResolver(fs))
+	require.NoError(t, err)
+
+	err = afero.WriteFile(fs, "/secret", []byte(faker.Password()), 0600)
+	require.NoError(t, err)
+
+	_, err = r.Resolve(context.TODO(), "file:///secret")
+	require.NoError(t, err)
+
+	require.Empty(t, r.Refresh(context.TODO()))
+
+	data := faker.Password()
+
+	err = afero.WriteFile(fs, "/secret", []byte(data), 0600)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"file:///secret"}, r.Refresh(context.TODO()))
+
+	val, err := r.Resolve(context.TODO(), "file:///secret")
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+}
+
+func TestRegistry_Watch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	r := NewRegistry()
+	defer r.Close()
+
+	fs := afero.NewMemMapFs()
+
+	err := r.Register("file", NewFileResolver(fs))
+	require.NoError(t, err)
+
+	err = afero.WriteFile(fs, "/secret", []byte(faker.Password()), 0600)
+	require.NoError(t, err)
+
+	_, err = r.Resolve(ctx, "file:///secret")
+	require.NoError(t, err)
+
+	stream := r.Watch(ctx, 10*time.Millisecond)
+	defer stream.Close(ctx)
+
+	err = afero.WriteFile(fs, "/secret", []byte(faker.Password()), 0600)
+	require.NoError(t, err)
+
+	require.True(t, stream.Next(ctx))
+
+	var uri string
+	err = stream.Decode(&uri)
+	require.NoError(t, err)
+	require.Equal(t, "file:///secret", uri)
+}
+
+func TestRegistry_WatchWithWatcher(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+
+	r := NewRegistry()
+	defer r.Close()
+
+	w := &watcher{changed: make(chan func(), 1)}
+	w.data.Store(faker.UUIDHyphenated())
+
+	err := r.Register("mock", w)
+	require.NoError(t, err)
+
+	_, err = r.Resolve(ctx, "mock:///secret")
+	require.NoError(t, err)
+
+	stream := r.Watch(ctx, 10*time.Millisecond)
+	defer stream.Close(ctx)
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), w.resolved.Load())
+
+	data := faker.UUIDHyphenated()
+	w.data.Store(data)
+	(<-w.changed)()
+
+	require.True(t, stream.Next(ctx))
+
+	var uri string
+	err = stream.Decode(&uri)
+	require.NoError(t, err)
+	require.Equal(t, "mock:///secret", uri)
+
+	val, err := r.Resolve(ctx, "mock:///secret")
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+}
+
+type watcher struct {
+	data     atomic.Value
+	resolved atomic.Int32
+	changed  chan func()
+}
+
+var _ Watcher = (*watcher)(nil)
+
+func (w *watcher) Resolve(_ context.Context, _ *url.URL) (any, error) {
+	w.resolved.Add(1)
+	return w.data.Load(), nil
+}
+
+func (w *watcher) Watch(_ context.Context, _ *url.URL, changed func()) {
+	w.changed <- changed
+}
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Resolver resolves the data addressed by a URI from an external source.
+type Resolver interface {
+	Resolve(ctx context.Context, uri *url.URL) (any, error)
+}
+
+// Watcher is an optional interface for resolvers that detect changes of the data addressed by a URI themselves,
+// such as by watching the files they read, instead of resolving it again at every interval.
+type Watcher interface {
+	// Watch starts calling changed whenever the data addressed by the URI may have changed, until the context is done.
+	// It returns once the current state is recorded and watches in the background.
+	Watch(ctx context.Context, uri *url.URL, changed func())
+}
+
+type resolver struct {
+	resolve func(ctx context.Context, uri *url.URL) (any, error)
+}
+
+var _ Resolver = (*resolver)(nil)
+
+var ErrNotFound = errors.New("value not found")
+
+// ResolveFunc creates a new Resolver from the provided function.
+func ResolveFunc(resolve func(ctx context.Context, uri *url.URL) (any, error)) Resolver {
+	return &resolver{resolve: resolve}
+}
+
+// IsURI reports whether the name addresses an external value, such as env://NAME.
+func IsURI(name string) bool {
+	i := strings.Index(name, "://")
+	if i <= 0 {
+		return false
+	}
+	u, err := url.Parse(name)
+	return err == nil && u.Scheme != ""
+}
+
+// Resolve calls the resolve function.
+func (r *resolver) Resolve(ctx context.Context, uri *url.URL) (any, error) {
+	return r.resolve(ctx, uri)
+}
+
+func location(uri *url.URL) string {
+	return uri.Host + uri.Path
+}
@@ -0,0 +1,31 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFunc(t *testing.T) {
+	data := faker.Word()
+	r := ResolveFunc(func(_ context.Context, _ *url.URL) (any, error) {
+		return data, nil
+	})
+
+	uri, _ := url.Parse("test://" + faker.Word())
+
+	val, err := r.Resolve(context.TODO(), uri)
+	require.NoError(t, err)
+	require.Equal(t, data, val)
+}
+
+func TestIsURI(t *testing.T) {
+	require.True(t, IsURI("env://PASSWORD"))
+	require.True(t, IsURI("file:///run/secrets/db"))
+	require.True(t, IsURI("vault://secret/db"))
+	require.False(t, IsURI(faker.UUIDHyphenated()))
+	require.False(t, IsURI("://invalid"))
+}
@@ -0,0 +1,94 @@
+package resolver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/driver"
+	"github.com/siyul-park/uniflow/pkg/encoding"
+)
+
+// Stream delivers the URIs whose resolved data changed.
+type Stream struct {
+	queue  []string
+	uri    string
+	signal chan struct{}
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+var _ driver.Stream = (*Stream)(nil)
+
+func newStream() *Stream {
+	return &Stream{
+		signal: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// Next waits for the next changed URI. Returns false if the stream or the context is closed.
+func (s *Stream) Next(ctx context.Context) bool {
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			s.uri = s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.signal:
+		case <-s.done:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Decode stores the current URI in the given string pointer.
+func (s *Stream) Decode(val any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := val.(*string)
+	if !ok {
+		return errors.WithStack(encoding.ErrUnsupportedType)
+	}
+	*p = s.uri
+	return nil
+}
+
+// Close closes the stream.
+func (s *Stream) Close(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	return nil
+}
+
+func (s *Stream) emit(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	s.queue = append(s.queue, uri)
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,63 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/siyul-park/uniflow/internal/fswatch"
+)
+
+// VaultResolver resolves vault://path URIs from a local YAML or JSON document that stands in for a Vault server.
+// Each segment of the path selects a key of the nested document.
+type VaultResolver struct {
+	fs       afero.Fs
+	filename string
+}
+
+var (
+	_ Resolver = (*VaultResolver)(nil)
+	_ Watcher  = (*VaultResolver)(nil)
+)
+
+// NewVaultResolver creates a new VaultResolver reading the document from the given file.
+func NewVaultResolver(fs afero.Fs, filename string) *VaultResolver {
+	return &VaultResolver{fs: fs, filename: filename}
+}
+
+// Resolve reads the document and returns the data stored at the path of the URI.
+func (r *VaultResolver) Resolve(_ context.Context, uri *url.URL) (any, error) {
+	data, err := afero.ReadFile(r.fs, r.filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	path := location(uri)
+	for _, key := range strings.Split(strings.Trim(path, "/"), "/") {
+		if key == "" {
+			continue
+		}
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil, errors.WithMessage(ErrNotFound, path)
+		}
+		if doc, ok = m[key]; !ok {
+			return nil, errors.WithMessage(ErrNotFound, path)
+		}
+	}
+	return doc, nil
+}
+
+// Watch starts calling changed whenever the document is modified, replaced or removed.
+func (r *VaultResolver) Watch(ctx context.Context, _ *url.URL, changed func()) {
+	fswatch.Watch(ctx, r.fs, func() []string { return []string{r.filename} }, changed)
+}
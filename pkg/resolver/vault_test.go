@@ -0,0 +1,35 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultResolver_Resolve(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	r := NewVaultResolver(fs, "vault.yaml")
+
+	err := afero.WriteFile(fs, "vault.yaml", []byte("secret:\n  db:\n    username: admin\n    password: secret\n"), 0600)
+	require.NoError(t, err)
+
+	uri, _ := url.Parse("vault://secret/db")
+
+	val, err := r.Resolve(context.TODO(), uri)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"username": "admin", "password": "secret"}, val)
+
+	uri, _ = url.Parse("vault://secret/db/password")
+
+	val, err = r.Resolve(context.TODO(), uri)
+	require.NoError(t, err)
+	require.Equal(t, "secret", val)
+
+	uri, _ = url.Parse("vault://secret/unknown")
+
+	_, err = r.Resolve(context.TODO(), uri)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,91 @@
+package runtime
+
+import (
+	"sync"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/process"
+)
+
+// Counter is a Watcher that counts the packets sent through the output ports of symbols.
+type Counter struct {
+	counts map[uuid.UUID]map[string]int
+	frames map[*process.Process]map[*Frame]struct{}
+	mu     sync.RWMutex
+}
+
+var _ Watcher = (*Counter)(nil)
+
+// NewCounter creates a new Counter.
+func NewCounter() *Counter {
+	return &Counter{
+		counts: make(map[uuid.UUID]map[string]int),
+		frames: make(map[*process.Process]map[*Frame]struct{}),
+	}
+}
+
+// Count returns the number of packets sent through the output port of the symbol.
+func (c *Counter) Count(id uuid.UUID, name string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.counts[id][name]
+}
+
+// Counts returns the packet counts keyed by symbol ID and output port name.
+func (c *Counter) Counts() map[uuid.UUID]map[string]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]map[string]int, len(c.counts))
+	for id, ports := range c.counts {
+		counts[id] = make(map[string]int, len(ports))
+		for name, count := range ports {
+			counts[id][name] = count
+		}
+	}
+	return counts
+}
+
+// OnFrame counts the frame once its packet has been sent through an output port.
+func (c *Counter) OnFrame(frame *Frame) {
+	if frame.Symbol == nil || frame.OutPort == nil || frame.OutPck == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	frames, ok := c.frames[frame.Process]
+	if !ok {
+		frames = make(map[*Frame]struct{})
+		c.frames[frame.Process] = frames
+	}
+	if _, ok := frames[frame]; ok {
+		return
+	}
+	frames[frame] = struct{}{}
+
+	for name, out := range frame.Symbol.Outs() {
+		if out == frame.OutPort {
+			ports, ok := c.counts[frame.Symbol.ID()]
+			if !ok {
+				ports = make(map[string]int)
+				c.counts[frame.Symbol.ID()] = ports
+			}
+			ports[name]++
+			break
+		}
+	}
+}
+
+// OnProcess releases the frames tracked for the process when it exits.
+func (c *Counter) OnProcess(proc *process.Process) {
+	proc.AddExitHook(process.ExitFunc(func(_ error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		delete(c.frames, proc)
+	}))
+}
@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+)
+
+func TestCounter_Count(t *testing.T) {
+	a := NewAgent()
+	defer a.Close()
+
+	c := NewCounter()
+	a.Watch(c)
+
+	sb := &symbol.Symbol{
+		Spec: &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		},
+		Node: node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		}),
+	}
+	defer sb.Close()
+
+	in := port.NewOut()
+	defer in.Close()
+
+	out := port.NewIn()
+	defer out.Close()
+
+	in.Link(sb.In(node.PortIn))
+	sb.Out(node.PortOut).Link(out)
+
+	a.Load(sb)
+	defer a.Unload(sb)
+
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	inWriter := in.Open(proc)
+	outReader := out.Open(proc)
+
+	for i := 0; i < 2; i++ {
+		pck := packet.New(nil)
+
+		inWriter.Write(pck)
+		<-outReader.Read()
+
+		outReader.Receive(pck)
+		<-inWriter.Receive()
+	}
+
+	require.Equal(t, 2, c.Count(sb.ID(), node.PortOut))
+	require.Equal(t, map[uuid.UUID]map[string]int{sb.ID(): {node.PortOut: 2}}, c.Counts())
+}
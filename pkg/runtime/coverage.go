@@ -0,0 +1,229 @@
+package runtime
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+)
+
+// Coverage is a Watcher that records which symbols were entered and which output ports fired.
+type Coverage struct {
+	entered map[uuid.UUID]struct{}
+	fired   map[uuid.UUID]map[string]struct{}
+	mu      sync.RWMutex
+}
+
+// CoverageReport summarizes the coverage of a set of symbols.
+type CoverageReport struct {
+	Symbols  CoverageStat      `json:"symbols"`
+	Ports    CoverageStat      `json:"ports"`
+	Branches CoverageStat      `json:"branches"`
+	Percent  float64           `json:"percent"`
+	Details  []*SymbolCoverage `json:"details"`
+}
+
+// CoverageStat counts the covered items out of the total.
+type CoverageStat struct {
+	Covered int     `json:"covered"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// SymbolCoverage describes the coverage of a single symbol.
+type SymbolCoverage struct {
+	ID        uuid.UUID       `json:"id"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name,omitempty"`
+	Kind      string          `json:"kind"`
+	Entered   bool            `json:"entered"`
+	Ports     map[string]bool `json:"ports,omitempty"`
+	Branches  map[string]bool `json:"branches,omitempty"`
+}
+
+var _ Watcher = (*Coverage)(nil)
+
+// NewCoverage creates a new Coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{
+		entered: make(map[uuid.UUID]struct{}),
+		fired:   make(map[uuid.UUID]map[string]struct{}),
+	}
+}
+
+// Entered reports whether a packet has passed through any port of the symbol.
+func (c *Coverage) Entered(id uuid.UUID) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.entered[id]
+	return ok
+}
+
+// Fired reports whether a packet has been sent through the output port of the symbol.
+func (c *Coverage) Fired(id uuid.UUID, name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.fired[id][name]
+	return ok
+}
+
+// Report summarizes the coverage of the symbols. Indexed output ports, such as the outputs of
+// if and switch nodes, are counted as branches in addition to ports.
+func (c *Coverage) Report(symbols []*symbol.Symbol) *CoverageReport {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var details []*SymbolCoverage
+	for _, sb := range symbols {
+		_, entered := c.entered[sb.ID()]
+
+		detail := &SymbolCoverage{
+			ID:        sb.ID(),
+			Namespace: sb.Namespace(),
+			Name:      sb.Name(),
+			Kind:      sb.Kind(),
+			Entered:   entered,
+		}
+
+		for name := range sb.Outs() {
+			_, fired := c.fired[sb.ID()][name]
+
+			if detail.Ports == nil {
+				detail.Ports = make(map[string]bool)
+			}
+			detail.Ports[name] = fired
+
+			if _, ok := node.IndexOfPort(name); ok {
+				if detail.Branches == nil {
+					detail.Branches = make(map[string]bool)
+				}
+				detail.Branches[name] = fired
+			}
+		}
+
+		details = append(details, detail)
+	}
+	return summarize(details)
+}
+
+// Merge combines the coverage of the other report into the report, treating details with the same ID
+// as the same symbol, which is covered wherever either report covers it.
+func (r *CoverageReport) Merge(other *CoverageReport) {
+	details := make(map[uuid.UUID]*SymbolCoverage, len(r.Details))
+	for _, detail := range r.Details {
+		details[detail.ID] = detail
+	}
+
+	for _, detail := range other.Details {
+		merged, ok := details[detail.ID]
+		if !ok {
+			merged = &SymbolCoverage{
+				ID:        detail.ID,
+				Namespace: detail.Namespace,
+				Name:      detail.Name,
+				Kind:      detail.Kind,
+			}
+			details[detail.ID] = merged
+		}
+
+		merged.Entered = merged.Entered || detail.Entered
+		for name, fired := range detail.Ports {
+			if merged.Ports == nil {
+				merged.Ports = make(map[string]bool)
+			}
+			merged.Ports[name] = merged.Ports[name] || fired
+		}
+		for name, fired := range detail.Branches {
+			if merged.Branches == nil {
+				merged.Branches = make(map[string]bool)
+			}
+			merged.Branches[name] = merged.Branches[name] || fired
+		}
+	}
+
+	*r = *summarize(slices.Collect(maps.Values(details)))
+}
+
+// OnFrame marks the symbol of the frame as entered and its output port as fired once a packet is sent.
+func (c *Coverage) OnFrame(frame *Frame) {
+	if frame.Symbol == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entered[frame.Symbol.ID()] = struct{}{}
+
+	if frame.OutPort == nil || frame.OutPck == nil {
+		return
+	}
+
+	for name, out := range frame.Symbol.Outs() {
+		if out == frame.OutPort {
+			ports, ok := c.fired[frame.Symbol.ID()]
+			if !ok {
+				ports = make(map[string]struct{})
+				c.fired[frame.Symbol.ID()] = ports
+			}
+			ports[name] = struct{}{}
+			break
+		}
+	}
+}
+
+// OnProcess does nothing, as coverage outlives the processes it observes.
+func (c *Coverage) OnProcess(_ *process.Process) {}
+
+func summarize(details []*SymbolCoverage) *CoverageReport {
+	report := &CoverageReport{Details: details}
+	for _, detail := range details {
+		report.Symbols.Total++
+		if detail.Entered {
+			report.Symbols.Covered++
+		}
+		for _, fired := range detail.Ports {
+			report.Ports.Total++
+			if fired {
+				report.Ports.Covered++
+			}
+		}
+		for _, fired := range detail.Branches {
+			report.Branches.Total++
+			if fired {
+				report.Branches.Covered++
+			}
+		}
+	}
+
+	slices.SortFunc(report.Details, func(x, y *SymbolCoverage) int {
+		if c := strings.Compare(x.Namespace, y.Namespace); c != 0 {
+			return c
+		}
+		if c := strings.Compare(x.Name, y.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(x.ID.String(), y.ID.String())
+	})
+
+	report.Symbols.Percent = percent(report.Symbols.Covered, report.Symbols.Total)
+	report.Ports.Percent = percent(report.Ports.Covered, report.Ports.Total)
+	report.Branches.Percent = percent(report.Branches.Covered, report.Branches.Total)
+	report.Percent = percent(report.Symbols.Covered+report.Ports.Covered, report.Symbols.Total+report.Ports.Total)
+	return report
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(total)
+}
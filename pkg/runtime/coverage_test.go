@@ -0,0 +1,124 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+)
+
+func TestCoverage_Report(t *testing.T) {
+	a := NewAgent()
+	defer a.Close()
+
+	c := NewCoverage()
+	a.Watch(c)
+
+	sb1 := &symbol.Symbol{
+		Spec: &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      "a",
+		},
+		Node: node.NewOneToManyNode(func(_ *process.Process, inPck *packet.Packet) ([]*packet.Packet, *packet.Packet) {
+			return []*packet.Packet{inPck}, nil
+		}),
+	}
+	defer sb1.Close()
+
+	sb2 := &symbol.Symbol{
+		Spec: &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      "b",
+		},
+		Node: node.NewOneToOneNode(nil),
+	}
+	defer sb2.Close()
+
+	in := port.NewOut()
+	defer in.Close()
+
+	out0 := port.NewIn()
+	defer out0.Close()
+
+	out1 := port.NewIn()
+	defer out1.Close()
+
+	in.Link(sb1.In(node.PortIn))
+	sb1.Out(node.PortWithIndex(node.PortOut, 0)).Link(out0)
+	sb1.Out(node.PortWithIndex(node.PortOut, 1)).Link(out1)
+	sb1.Out(node.PortError).Link(sb2.In(node.PortIn))
+
+	a.Load(sb1)
+	defer a.Unload(sb1)
+
+	a.Load(sb2)
+	defer a.Unload(sb2)
+
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	inWriter := in.Open(proc)
+	outReader := out0.Open(proc)
+
+	pck := packet.New(nil)
+
+	inWriter.Write(pck)
+	<-outReader.Read()
+
+	outReader.Receive(pck)
+	<-inWriter.Receive()
+
+	require.True(t, c.Entered(sb1.ID()))
+	require.False(t, c.Entered(sb2.ID()))
+	require.True(t, c.Fired(sb1.ID(), node.PortWithIndex(node.PortOut, 0)))
+	require.False(t, c.Fired(sb1.ID(), node.PortWithIndex(node.PortOut, 1)))
+
+	report := c.Report([]*symbol.Symbol{sb2, sb1})
+	require.Equal(t, CoverageStat{Covered: 1, Total: 2, Percent: 50}, report.Symbols)
+	require.Equal(t, CoverageStat{Covered: 1, Total: 3, Percent: 100.0 / 3}, report.Ports)
+	require.Equal(t, CoverageStat{Covered: 1, Total: 2, Percent: 50}, report.Branches)
+	require.Equal(t, 40.0, report.Percent)
+
+	require.Len(t, report.Details, 2)
+	require.Equal(t, sb1.ID(), report.Details[0].ID)
+	require.Equal(t, map[string]bool{
+		node.PortWithIndex(node.PortOut, 0): true,
+		node.PortWithIndex(node.PortOut, 1): false,
+		node.PortError:                      false,
+	}, report.Details[0].Ports)
+	require.Equal(t, map[string]bool{
+		node.PortWithIndex(node.PortOut, 0): true,
+		node.PortWithIndex(node.PortOut, 1): false,
+	}, report.Details[0].Branches)
+}
+
+func TestCoverageReport_Merge(t *testing.T) {
+	id := uuid.Must(uuid.NewV7())
+
+	report := &CoverageReport{Details: []*SymbolCoverage{
+		{ID: id, Entered: true, Ports: map[string]bool{"out[0]": true, "out[1]": false}, Branches: map[string]bool{"out[0]": true, "out[1]": false}},
+	}}
+	report.Merge(&CoverageReport{Details: []*SymbolCoverage{
+		{ID: id, Entered: true, Ports: map[string]bool{"out[0]": false, "out[1]": true}, Branches: map[string]bool{"out[0]": false, "out[1]": true}},
+		{ID: uuid.Must(uuid.NewV7())},
+	}})
+
+	require.Len(t, report.Details, 2)
+	require.Equal(t, CoverageStat{Covered: 1, Total: 2, Percent: 50}, report.Symbols)
+	require.Equal(t, CoverageStat{Covered: 2, Total: 2, Percent: 100}, report.Ports)
+	require.Equal(t, CoverageStat{Covered: 2, Total: 2, Percent: 100}, report.Branches)
+	require.Equal(t, float64(75), report.Percent)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"golang.org/x/sync/errgroup"
@@ -13,7 +14,9 @@ import (
 	"github.com/siyul-park/uniflow/pkg/hook"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/resolver"
 	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/symbol"
 	"github.com/siyul-park/uniflow/pkg/value"
@@ -21,12 +24,18 @@ import (
 
 // Config defines configuration options for the Runtime.
 type Config struct {
-	Namespace   string            // Namespace defines the isolated execution environment for workflows.
-	Environment map[string]string // Environment holds the variables for the loader.
-	Hook        *hook.Hook        // Hook is a collection of hook functions for managing symbols.
-	Scheme      *scheme.Scheme    // Scheme defines the scheme and behaviors for symbols.
-	SpecStore   driver.Store      // SpecStore is responsible for persisting specifications.
-	ValueStore  driver.Store      // ValueStore is responsible for persisting values.
+	Namespace       string             // Namespace defines the isolated execution environment for workflows.
+	Environment     map[string]string  // Environment holds the variables for the loader.
+	Hook            *hook.Hook         // Hook is a collection of hook functions for managing symbols.
+	Scheme          *scheme.Scheme     // Scheme defines the scheme and behaviors for symbols.
+	SpecStore       driver.Store       // SpecStore is responsible for persisting specifications.
+	ValueStore      driver.Store       // ValueStore is responsible for persisting values.
+	StrictSchema    bool               // StrictSchema rejects symbols whose port schemas are incompatible with their links.
+	ValidateSchema  bool               // ValidateSchema checks packets against the schemas of the input ports receiving them.
+	KeyProvider     secret.KeyProvider // KeyProvider decrypts secret values when binding them to specs.
+	Resolver        *resolver.Registry // Resolver resolves values addressed by URI, such as env://NAME, from external sources.
+	ResolveInterval time.Duration      // ResolveInterval is the interval at which values from external sources are checked for changes.
+	Warn            func(error)        // Warn reports problems that do not prevent symbols from loading, such as incompatible port schemas outside strict mode.
 }
 
 // Runtime represents an environment for executing Workflows.
@@ -39,6 +48,13 @@ type Runtime struct {
 	valueStore  driver.Store
 	specStream  driver.Stream
 	valueStream driver.Stream
+	uriStream   driver.Stream
+	keyProvider secret.KeyProvider
+	resolver    *resolver.Registry
+	interval    time.Duration
+	strict      bool
+	validate    bool
+	warn        func(error)
 	mu          sync.RWMutex
 }
 
@@ -66,6 +82,7 @@ func New(config Config) *Runtime {
 	symbolTable := symbol.NewTable(symbol.TableOption{
 		LoadHooks:   []symbol.LoadHook{config.Hook},
 		UnloadHooks: []symbol.UnloadHook{config.Hook},
+		Strict:      config.StrictSchema,
 	})
 
 	return &Runtime{
@@ -75,11 +92,43 @@ func New(config Config) *Runtime {
 		symbolTable: symbolTable,
 		specStore:   config.SpecStore,
 		valueStore:  config.ValueStore,
+		keyProvider: config.KeyProvider,
+		resolver:    config.Resolver,
+		interval:    config.ResolveInterval,
+		strict:      config.StrictSchema,
+		validate:    config.ValidateSchema,
+		warn:        config.Warn,
 	}
 }
 
 // Load loads symbols from the spec store into the symbol table.
 func (r *Runtime) Load(ctx context.Context, filter any) error {
+	return r.load(ctx, filter, false)
+}
+
+// Recompile replaces the scheme and recompiles the symbols of the given kinds, such as those provided by a plugin
+// that was loaded, unloaded or upgraded.
+func (r *Runtime) Recompile(ctx context.Context, sc *scheme.Scheme, kinds ...string) error {
+	r.mu.Lock()
+	r.scheme = sc
+	r.mu.Unlock()
+
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	filters := make([]any, 0, len(kinds))
+	for _, kind := range kinds {
+		filters = append(filters, map[string]any{spec.KeyKind: kind})
+	}
+	return r.load(ctx, map[string]any{"$or": filters}, true)
+}
+
+func (r *Runtime) load(ctx context.Context, filter any, force bool) error {
+	r.mu.RLock()
+	sc := r.scheme
+	r.mu.RUnlock()
+
 	if filter == nil {
 		filter = map[string]any{meta.KeyNamespace: r.namespace}
 	} else {
@@ -96,10 +145,19 @@ func (r *Runtime) Load(ctx context.Context, filter any) error {
 		return err
 	}
 
+	var errs []error
 	var filters []any
+	var resolved []*value.Value
 	for _, sp := range specs {
 		for _, val := range sp.GetEnv() {
-			if val.ID != uuid.Nil {
+			if r.resolver != nil && val.ID == uuid.Nil && resolver.IsURI(val.Name) {
+				data, err := r.resolver.Resolve(ctx, val.Name)
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					resolved = append(resolved, &value.Value{Namespace: sp.GetNamespace(), Name: val.Name, Data: data})
+				}
+			} else if val.ID != uuid.Nil {
 				filters = append(filters, map[string]any{value.KeyNamespace: sp.GetNamespace(), value.KeyID: val.ID})
 			} else if val.Name != "" {
 				filters = append(filters, map[string]any{value.KeyNamespace: sp.GetNamespace(), value.KeyName: val.Name})
@@ -117,35 +175,81 @@ func (r *Runtime) Load(ctx context.Context, filter any) error {
 			return err
 		}
 	}
+	values = append(values, resolved...)
+
+	var secrets []*value.Value
+	for i := 0; i < len(values); i++ {
+		val := values[i]
+		if !val.IsSecret() {
+			continue
+		}
+		if err := secret.Decrypt(ctx, r.keyProvider, val); err != nil {
+			errs = append(errs, err)
+			values = append(values[:i], values[i+1:]...)
+			i--
+			continue
+		}
+		secrets = append(secrets, secret.Redact(val))
+	}
 
 	if len(r.environment) > 0 {
 		values = append(values, &value.Value{Data: r.environment})
 	}
 
+	var redacted []*value.Value
+	if len(secrets) > 0 {
+		redacted = append(secrets, values...)
+	}
+
 	var symbols []*symbol.Symbol
-	var errs []error
 	for _, unstructured := range specs {
 		sp := spec.Spec(unstructured)
+
+		// Symbols expose a copy bound to redacted secrets so that plaintext never leaves the node.
+		view := unstructured
+		if len(secrets) > 0 && unstructured.IsBound(secrets...) {
+			view = &spec.Unstructured{}
+			if err := spec.As(unstructured, view); err != nil {
+				errs = append(errs, err)
+			} else if err := view.Bind(redacted...); err != nil {
+				errs = append(errs, err)
+			} else if err := view.Build(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
 		if err := unstructured.Bind(values...); err != nil {
 			errs = append(errs, err)
 		} else if err := unstructured.Build(); err != nil {
 			errs = append(errs, err)
-		} else if decode, err := r.scheme.Decode(unstructured); err != nil {
+		} else if decode, err := sc.Decode(unstructured); err != nil {
 			errs = append(errs, err)
 		} else {
 			sp = decode
 		}
 
+		unstructured.SetSchemas(sc.Describe(sp))
+		view.SetSchemas(unstructured.GetSchemas())
+
 		sb := r.symbolTable.Lookup(sp.GetID())
-		if sb == nil || !reflect.DeepEqual(sb.Spec, sp) {
+		if sb == nil || force || !reflect.DeepEqual(sb.Spec, sp) {
 			var n node.Node
 			if sp != unstructured {
-				if n, err = r.scheme.Compile(sp); err != nil {
+				if n, err = sc.Compile(sp); err != nil {
 					errs = append(errs, err)
 				}
 			}
 
-			sb = &symbol.Symbol{Spec: unstructured, Node: n}
+			if schemas := unstructured.GetSchemas(); r.validate && n != nil && schemas != nil && len(schemas.Ins) > 0 {
+				n = newValidateNode(n, schemas.Ins)
+			}
+
+			sb = &symbol.Symbol{Spec: view, Node: n}
+			if !r.strict && r.warn != nil {
+				if err := r.symbolTable.Verify(sb); err != nil {
+					r.warn(err)
+				}
+			}
 			if err := r.symbolTable.Insert(sb); err != nil {
 				errs = append(errs, err)
 			}
@@ -221,6 +325,16 @@ func (r *Runtime) Watch(ctx context.Context) error {
 	}
 	r.valueStream = valueStream
 
+	if r.uriStream != nil {
+		if err := r.uriStream.Close(ctx); err != nil {
+			return err
+		}
+		r.uriStream = nil
+	}
+	if r.resolver != nil {
+		r.uriStream = r.resolver.Watch(ctx, r.interval)
+	}
+
 	return nil
 }
 
@@ -230,6 +344,7 @@ func (r *Runtime) Reconcile(ctx context.Context) error {
 
 	specStream := r.specStream
 	valueStream := r.valueStream
+	uriStream := r.uriStream
 
 	r.mu.RUnlock()
 
@@ -269,28 +384,43 @@ func (r *Runtime) Reconcile(ctx context.Context) error {
 			}
 			values = append(values, &value.Value{ID: event.ID})
 
-			var filters []any
-			for _, id := range r.symbolTable.Keys() {
-				if sb := r.symbolTable.Lookup(id); sb != nil {
-					unstructured := &spec.Unstructured{}
-					if err := spec.As(sb.Spec, unstructured); err != nil {
-						return err
-					} else if unstructured.IsBound(values...) {
-						filters = append(filters, map[string]any{spec.KeyID: id})
-					}
-				}
-			}
-
-			if len(filters) > 0 {
-				_ = r.Load(ctx, map[string]any{"$or": filters})
+			if err := r.reload(ctx, values); err != nil {
+				return err
 			}
 		}
 		return nil
 	})
 
+	if uriStream != nil {
+		g.Go(func() error {
+			for uriStream.Next(ctx) {
+				var uri string
+				if err := uriStream.Decode(&uri); err != nil {
+					return err
+				}
+
+				if err := r.reload(ctx, []*value.Value{{Namespace: r.namespace, Name: uri}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
 	return g.Wait()
 }
 
+// Symbols returns the symbols currently held in the symbol table.
+func (r *Runtime) Symbols() []*symbol.Symbol {
+	var symbols []*symbol.Symbol
+	for _, id := range r.symbolTable.Keys() {
+		if sb := r.symbolTable.Lookup(id); sb != nil {
+			symbols = append(symbols, sb)
+		}
+	}
+	return symbols
+}
+
 // Close shuts down the Runtime by closing streams and clearing the symbol table.
 func (r *Runtime) Close(ctx context.Context) error {
 	r.mu.Lock()
@@ -308,5 +438,30 @@ func (r *Runtime) Close(ctx context.Context) error {
 		}
 		r.valueStream = nil
 	}
+	if r.uriStream != nil {
+		if err := r.uriStream.Close(ctx); err != nil {
+			return err
+		}
+		r.uriStream = nil
+	}
 	return r.symbolTable.Close()
 }
+
+func (r *Runtime) reload(ctx context.Context, values []*value.Value) error {
+	var filters []any
+	for _, id := range r.symbolTable.Keys() {
+		if sb := r.symbolTable.Lookup(id); sb != nil {
+			unstructured := &spec.Unstructured{}
+			if err := spec.As(sb.Spec, unstructured); err != nil {
+				return err
+			} else if unstructured.IsBound(values...) {
+				filters = append(filters, map[string]any{spec.KeyID: id})
+			}
+		}
+	}
+
+	if len(filters) > 0 {
+		_ = r.Load(ctx, map[string]any{"$or": filters})
+	}
+	return nil
+}
@@ -2,18 +2,23 @@ package runtime
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 	"time"
 
 	"github.com/go-faker/faker/v4"
 	"github.com/gofrs/uuid"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/hook"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/resolver"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/symbol"
 	"github.com/siyul-park/uniflow/pkg/value"
@@ -41,16 +46,301 @@ func TestRuntime_Load(t *testing.T) {
 	})
 	defer r.Close(ctx)
 
-	meta := &spec.Meta{
-		ID:   uuid.Must(uuid.NewV7()),
-		Kind: kind,
+	sp := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
 	}
 
-	err := specStore.Insert(ctx, []any{meta})
+	err := specStore.Insert(ctx, []any{sp})
 	require.NoError(t, err)
 
 	err = r.Load(ctx, nil)
 	require.NoError(t, err)
+
+	symbols := r.Symbols()
+	require.Len(t, symbols, 1)
+	require.Equal(t, sp.GetID(), symbols[0].ID())
+}
+
+func TestRuntime_Recompile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	kind := faker.UUIDHyphenated()
+
+	s1 := scheme.New()
+	s1.AddKnownType(kind, &spec.Meta{})
+	s1.AddCodec(kind, scheme.CodecFunc(func(spec spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(nil), nil
+	}))
+
+	s2 := scheme.New()
+	s2.AddKnownType(kind, &spec.Meta{})
+	s2.AddCodec(kind, scheme.CodecFunc(func(spec spec.Spec) (node.Node, error) {
+		return node.NewOneToManyNode(nil), nil
+	}))
+
+	specStore := driver.NewStore()
+	valueStore := driver.NewStore()
+
+	r := New(Config{
+		Scheme:     s1,
+		SpecStore:  specStore,
+		ValueStore: valueStore,
+	})
+	defer r.Close(ctx)
+
+	sp := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+	}
+
+	err := specStore.Insert(ctx, []any{sp})
+	require.NoError(t, err)
+
+	err = r.Load(ctx, nil)
+	require.NoError(t, err)
+
+	symbols := r.Symbols()
+	require.Len(t, symbols, 1)
+	require.IsType(t, &node.OneToOneNode{}, symbols[0].Node)
+
+	err = r.Recompile(ctx, s2, kind)
+	require.NoError(t, err)
+
+	symbols = r.Symbols()
+	require.Len(t, symbols, 1)
+	require.IsType(t, &node.OneToManyNode{}, symbols[0].Node)
+
+	err = r.Recompile(ctx, scheme.New(), kind)
+	require.NoError(t, err)
+
+	symbols = r.Symbols()
+	require.Len(t, symbols, 1)
+	require.Nil(t, symbols[0].Node)
+}
+
+func TestRuntime_LoadWithSchemas(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	kind := faker.UUIDHyphenated()
+
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Meta{})
+	s.AddCodec(kind, scheme.CodecWithSchemas(scheme.CodecFunc(func(spec spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(nil), nil
+	}), &spec.Schemas{
+		Ins:  map[string]*schema.Schema{node.PortIn: {Type: schema.TypeObject}},
+		Outs: map[string]*schema.Schema{node.PortOut: {Type: schema.TypeString}},
+	}))
+
+	meta1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+	}
+	meta2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Ports: map[string][]spec.Port{
+			node.PortOut: {{Name: meta1.GetName(), Port: node.PortIn}},
+		},
+	}
+
+	t.Run("Warn", func(t *testing.T) {
+		specStore := driver.NewStore()
+
+		var warnings []error
+		r := New(Config{
+			Scheme:    s,
+			SpecStore: specStore,
+			Warn: func(err error) {
+				warnings = append(warnings, err)
+			},
+		})
+		defer r.Close(ctx)
+
+		err := specStore.Insert(ctx, []any{meta1, meta2})
+		require.NoError(t, err)
+
+		err = r.Load(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, r.symbolTable.Keys(), 2)
+		require.Len(t, warnings, 1)
+		require.ErrorIs(t, warnings[0], schema.ErrIncompatible)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		specStore := driver.NewStore()
+
+		r := New(Config{
+			Scheme:       s,
+			SpecStore:    specStore,
+			StrictSchema: true,
+		})
+		defer r.Close(ctx)
+
+		err := specStore.Insert(ctx, []any{meta1, meta2})
+		require.NoError(t, err)
+
+		err = r.Load(ctx, nil)
+		require.ErrorIs(t, err, schema.ErrIncompatible)
+		require.Len(t, r.symbolTable.Keys(), 1)
+	})
+}
+
+func TestRuntime_LoadWithSecrets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	kind := faker.UUIDHyphenated()
+
+	var password any
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Unstructured{})
+	s.AddCodec(kind, scheme.CodecFunc(func(sp spec.Spec) (node.Node, error) {
+		password, _ = sp.(*spec.Unstructured).Get("password")
+		return node.NewOneToOneNode(nil), nil
+	}))
+
+	key, err := secret.GenerateKey()
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(key)
+	require.NoError(t, err)
+	provider, err := secret.NewLocalKeyProvider(raw)
+	require.NoError(t, err)
+
+	val := &value.Value{
+		ID:        uuid.Must(uuid.NewV7()),
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Secret:    true,
+		Data:      map[string]any{"password": faker.Password()},
+	}
+	plaintext := val.Data.(map[string]any)["password"]
+
+	err = secret.Encrypt(ctx, provider, val)
+	require.NoError(t, err)
+
+	sp := &spec.Unstructured{
+		Meta: spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Env: map[string]spec.Value{
+				"PASSWORD": {Name: val.Name, Data: "{{ .password }}"},
+			},
+		},
+		Fields: map[string]any{"password": "{{ .PASSWORD }}"},
+	}
+
+	t.Run("Decrypt", func(t *testing.T) {
+		specStore := driver.NewStore()
+		valueStore := driver.NewStore()
+
+		r := New(Config{
+			Scheme:      s,
+			SpecStore:   specStore,
+			ValueStore:  valueStore,
+			KeyProvider: provider,
+		})
+		defer r.Close(ctx)
+
+		err := specStore.Insert(ctx, []any{sp})
+		require.NoError(t, err)
+		err = valueStore.Insert(ctx, []any{val})
+		require.NoError(t, err)
+
+		err = r.Load(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, password)
+
+		sb := r.symbolTable.Lookup(sp.GetID())
+		require.NotNil(t, sb)
+
+		field, _ := sb.Spec.(*spec.Unstructured).Get("password")
+		require.Equal(t, secret.Redacted, field)
+	})
+
+	t.Run("NoKeyProvider", func(t *testing.T) {
+		specStore := driver.NewStore()
+		valueStore := driver.NewStore()
+
+		r := New(Config{
+			Scheme:     s,
+			SpecStore:  specStore,
+			ValueStore: valueStore,
+		})
+		defer r.Close(ctx)
+
+		err := specStore.Insert(ctx, []any{sp})
+		require.NoError(t, err)
+		err = valueStore.Insert(ctx, []any{val})
+		require.NoError(t, err)
+
+		err = r.Load(ctx, nil)
+		require.ErrorIs(t, err, secret.ErrNoKeyProvider)
+	})
+}
+
+func TestRuntime_LoadWithResolver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	kind := faker.UUIDHyphenated()
+
+	var password any
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Unstructured{})
+	s.AddCodec(kind, scheme.CodecFunc(func(sp spec.Spec) (node.Node, error) {
+		password, _ = sp.(*spec.Unstructured).Get("password")
+		return node.NewOneToOneNode(nil), nil
+	}))
+
+	fs := afero.NewMemMapFs()
+	data := faker.Password()
+
+	err := afero.WriteFile(fs, "/run/secrets/db", []byte(data+"\n"), 0600)
+	require.NoError(t, err)
+
+	rs := resolver.NewRegistry()
+	defer rs.Close()
+
+	err = rs.Register("file", resolver.NewFileResolver(fs))
+	require.NoError(t, err)
+
+	specStore := driver.NewStore()
+
+	r := New(Config{
+		Scheme:    s,
+		SpecStore: specStore,
+		Resolver:  rs,
+	})
+	defer r.Close(ctx)
+
+	sp := &spec.Unstructured{
+		Meta: spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Env: map[string]spec.Value{
+				"PASSWORD": {Name: "file:///run/secrets/db", Data: "{{ . }}"},
+			},
+		},
+		Fields: map[string]any{"password": "{{ .PASSWORD }}"},
+	}
+
+	err = specStore.Insert(ctx, []any{sp})
+	require.NoError(t, err)
+
+	err = r.Load(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, data, password)
 }
 
 func TestRuntime_Reconcile(t *testing.T) {
@@ -207,4 +497,92 @@ func TestRuntime_Reconcile(t *testing.T) {
 			}
 		}()
 	})
+
+	t.Run("URI", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+		defer cancel()
+
+		s := scheme.New()
+		kind := faker.UUIDHyphenated()
+
+		s.AddKnownType(kind, &spec.Meta{})
+		s.AddCodec(kind, scheme.CodecFunc(func(spec spec.Spec) (node.Node, error) {
+			return node.NewOneToOneNode(nil), nil
+		}))
+
+		fs := afero.NewMemMapFs()
+
+		err := afero.WriteFile(fs, "/run/secrets/db", []byte(faker.UUIDHyphenated()), 0600)
+		require.NoError(t, err)
+
+		rs := resolver.NewRegistry()
+		defer rs.Close()
+
+		err = rs.Register("file", resolver.NewFileResolver(fs))
+		require.NoError(t, err)
+
+		specStore := driver.NewStore()
+
+		h := hook.New()
+		symbols := make(chan *symbol.Symbol)
+
+		h.AddLoadHook(symbol.LoadFunc(func(sb *symbol.Symbol) error {
+			symbols <- sb
+			return nil
+		}))
+
+		r := New(Config{
+			Scheme:          s,
+			Hook:            h,
+			SpecStore:       specStore,
+			Resolver:        rs,
+			ResolveInterval: 10 * time.Millisecond,
+		})
+		defer r.Close(ctx)
+
+		err = r.Watch(ctx)
+		require.NoError(t, err)
+
+		go r.Reconcile(ctx)
+
+		meta := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Env: map[string]spec.Value{
+				"key": {
+					Name: "file:///run/secrets/db",
+					Data: "{{ . }}",
+				},
+			},
+		}
+
+		err = specStore.Insert(ctx, []any{meta})
+		require.NoError(t, err)
+
+		select {
+		case sb := <-symbols:
+			require.Equal(t, meta.GetID(), sb.ID())
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+
+		data := faker.UUIDHyphenated()
+
+		err = afero.WriteFile(fs, "/run/secrets/db", []byte(data), 0600)
+		require.NoError(t, err)
+
+		select {
+		case sb := <-symbols:
+			require.Equal(t, meta.GetID(), sb.ID())
+			require.Equal(t, data, sb.Env()["key"].Data)
+		case <-ctx.Done():
+			require.NoError(t, ctx.Err())
+		}
+
+		go func() {
+			for range symbols {
+			}
+		}()
+	})
 }
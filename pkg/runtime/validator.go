@@ -0,0 +1,80 @@
+package runtime
+
+import (
+	"sync"
+
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// validateNode wraps a node and rejects packets that do not conform to the schemas of its input ports.
+type validateNode struct {
+	node.Node
+	schemas map[string]*schema.Schema
+	guards  map[string]*node.OneToOneNode
+	mu      sync.Mutex
+}
+
+var _ node.Proxy = (*validateNode)(nil)
+
+func newValidateNode(n node.Node, schemas map[string]*schema.Schema) *validateNode {
+	return &validateNode{
+		Node:    n,
+		schemas: schemas,
+		guards:  make(map[string]*node.OneToOneNode),
+	}
+}
+
+// In returns a guarded input port that validates packets before forwarding them to the underlying node.
+func (n *validateNode) In(name string) *port.InPort {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if guard, ok := n.guards[name]; ok {
+		return guard.In(node.PortIn)
+	}
+
+	in := n.Node.In(name)
+	sc := n.schemas[name]
+	if in == nil || sc == nil {
+		return in
+	}
+
+	guard := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+		payload := inPck.Payload()
+		if _, ok := payload.(types.Error); ok {
+			return inPck, nil
+		}
+		if err := sc.Validate(payload); err != nil {
+			return nil, packet.New(types.NewError(err))
+		}
+		return inPck, nil
+	})
+	guard.Out(node.PortOut).Link(in)
+
+	n.guards[name] = guard
+	return guard.In(node.PortIn)
+}
+
+// Unwrap returns the underlying node.
+func (n *validateNode) Unwrap() node.Node {
+	return n.Node
+}
+
+// Close closes the guards and the underlying node.
+func (n *validateNode) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, guard := range n.guards {
+		if err := guard.Close(); err != nil {
+			return err
+		}
+	}
+	n.guards = make(map[string]*node.OneToOneNode)
+	return n.Node.Close()
+}
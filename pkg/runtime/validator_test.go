@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestValidateNode_In(t *testing.T) {
+	n := newValidateNode(node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+		return inPck, nil
+	}), map[string]*schema.Schema{
+		node.PortIn: {Type: schema.TypeString},
+	})
+	defer n.Close()
+
+	var target node.Node
+	require.True(t, node.As(n, &target))
+	require.Equal(t, n.In(node.PortIn), n.In(node.PortIn))
+
+	out := port.NewOut()
+	defer out.Close()
+
+	out.Link(n.In(node.PortIn))
+
+	proc := process.New()
+	defer proc.Exit(nil)
+
+	writer := out.Open(proc)
+
+	t.Run("Valid", func(t *testing.T) {
+		payload := types.NewString("foo")
+		backPck := packet.Send(writer, packet.New(payload))
+		require.Equal(t, payload, backPck.Payload())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		backPck := packet.Send(writer, packet.New(types.NewInt(0)))
+		err, ok := backPck.Payload().(types.Error)
+		require.True(t, ok)
+		require.ErrorIs(t, err, schema.ErrInvalidValue)
+	})
+}
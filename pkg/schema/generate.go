@@ -0,0 +1,282 @@
+package schema
+
+import (
+	"math"
+	"math/rand/v2"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-faker/faker/v4"
+)
+
+const (
+	defaultMaxLength = 16
+	defaultMaxItems  = 4
+	defaultMaxDepth  = 3
+	defaultMaxRepeat = 8
+)
+
+var alphabet = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 _-.,:;!?'\"\\/<>{}[]()@#$%^&*+=~`|éü한글😀\t\n")
+
+// Generate returns a random value conforming to the schema, leaning towards boundary values that tend to uncover edge cases.
+// A nil schema generates an arbitrary JSON value.
+func (s *Schema) Generate(r *rand.Rand) any {
+	return s.generate(r, defaultMaxDepth)
+}
+
+func (s *Schema) generate(r *rand.Rand, depth int) any {
+	if s == nil {
+		return generateAny(r, depth)
+	}
+
+	if len(s.AnyOf) > 0 {
+		sub := s.AnyOf[r.IntN(len(s.AnyOf))].merge(s)
+		return sub.generate(r, depth)
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[r.IntN(len(s.Enum))]
+	}
+
+	typ := s.Type
+	if typ == "" {
+		switch {
+		case len(s.Properties) > 0 || len(s.Required) > 0 || s.AdditionalProperties != nil:
+			typ = TypeObject
+		case s.Items != nil:
+			typ = TypeArray
+		default:
+			return generateAny(r, depth)
+		}
+	}
+
+	switch typ {
+	case TypeNull:
+		return nil
+	case TypeBoolean:
+		return r.IntN(2) == 1
+	case TypeInteger:
+		return int64(math.Round(s.generateNumber(r, true)))
+	case TypeNumber:
+		return s.generateNumber(r, false)
+	case TypeString:
+		return s.generateString(r)
+	case TypeArray:
+		lo, hi := bounds(s.MinItems, s.MaxItems, defaultMaxItems)
+		if depth <= 0 && s.MinItems == nil {
+			hi = lo
+		}
+		items := make([]any, lo+r.IntN(hi-lo+1))
+		for i := range items {
+			items[i] = s.Items.generate(r, depth-1)
+		}
+		return items
+	case TypeObject:
+		obj := make(map[string]any, len(s.Properties))
+		keys := make([]string, 0, len(s.Properties))
+		for key := range s.Properties {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if slices.Contains(s.Required, key) || (depth > 0 && r.IntN(2) == 1) {
+				obj[key] = s.Properties[key].generate(r, depth-1)
+			}
+		}
+		for _, key := range s.Required {
+			if _, ok := obj[key]; !ok {
+				obj[key] = s.AdditionalProperties.generate(r, depth-1)
+			}
+		}
+		if s.AdditionalProperties != nil && depth > 0 {
+			for range r.IntN(3) {
+				key := faker.Word()
+				if _, ok := s.Properties[key]; !ok {
+					obj[key] = s.AdditionalProperties.generate(r, depth-1)
+				}
+			}
+		}
+		return obj
+	default:
+		return generateAny(r, depth)
+	}
+}
+
+func (s *Schema) generateNumber(r *rand.Rand, integer bool) float64 {
+	lo, hi := float64(math.MinInt32), float64(math.MaxInt32)
+	if s.Minimum != nil {
+		lo = *s.Minimum
+	}
+	if s.Maximum != nil {
+		hi = *s.Maximum
+	}
+	if integer {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+	}
+	if lo > hi {
+		return lo
+	}
+
+	if r.IntN(4) == 0 {
+		var edges []float64
+		for _, edge := range []float64{lo, hi, 0, 1, -1, lo + 1, hi - 1} {
+			if edge >= lo && edge <= hi {
+				edges = append(edges, edge)
+			}
+		}
+		return edges[r.IntN(len(edges))]
+	}
+
+	n := lo + r.Float64()*(hi-lo)
+	if integer {
+		n = math.Floor(n)
+	}
+	return n
+}
+
+func (s *Schema) generateString(r *rand.Rand) string {
+	lo, hi := bounds(s.MinLength, s.MaxLength, defaultMaxLength)
+
+	var str string
+	switch {
+	case s.Pattern != "":
+		re, err := syntax.Parse(s.Pattern, syntax.Perl)
+		if err != nil {
+			return ""
+		}
+		re = re.Simplify()
+		for range defaultMaxRepeat {
+			var b strings.Builder
+			generatePattern(r, &b, re)
+			str = b.String()
+			if n := utf8.RuneCountInString(str); n >= lo && n <= hi {
+				break
+			}
+		}
+		return str
+	case s.Format != "":
+		str = generateFormat(r, s.Format)
+	default:
+		runes := make([]rune, lo+r.IntN(hi-lo+1))
+		for i := range runes {
+			runes[i] = alphabet[r.IntN(len(alphabet))]
+		}
+		return string(runes)
+	}
+
+	if n := utf8.RuneCountInString(str); n > hi {
+		str = string([]rune(str)[:hi])
+	} else if n < lo {
+		str += strings.Repeat("a", lo-n)
+	}
+	return str
+}
+
+func generateFormat(r *rand.Rand, format string) string {
+	switch format {
+	case "email":
+		return faker.Email()
+	case "uuid":
+		return faker.UUIDHyphenated()
+	case "uri", "url":
+		return faker.URL()
+	case "ipv4":
+		return faker.IPv4()
+	case "ipv6":
+		return faker.IPv6()
+	case "hostname":
+		return faker.DomainName()
+	case "date":
+		return randomTime(r).Format(time.DateOnly)
+	case "time":
+		return randomTime(r).Format(time.TimeOnly)
+	case "date-time":
+		return randomTime(r).Format(time.RFC3339)
+	default:
+		return faker.Word()
+	}
+}
+
+func generatePattern(r *rand.Rand, b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) < 2 {
+			return
+		}
+		i := r.IntN(len(re.Rune)/2) * 2
+		lo, hi := re.Rune[i], re.Rune[i+1]
+		if hi-lo > 0xFF {
+			hi = lo + 0xFF
+		}
+		b.WriteRune(lo + rune(r.IntN(int(hi-lo)+1)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune(alphabet[r.IntN(len(alphabet)-2)])
+	case syntax.OpCapture:
+		generatePattern(r, b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			generatePattern(r, b, sub)
+		}
+	case syntax.OpAlternate:
+		generatePattern(r, b, re.Sub[r.IntN(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			lo, hi = 0, -1
+		case syntax.OpPlus:
+			lo, hi = 1, -1
+		case syntax.OpQuest:
+			lo, hi = 0, 1
+		}
+		if hi < 0 {
+			hi = lo + defaultMaxRepeat
+		}
+		for range lo + r.IntN(hi-lo+1) {
+			generatePattern(r, b, re.Sub[0])
+		}
+	}
+}
+
+func generateAny(r *rand.Rand, depth int) any {
+	kinds := []string{TypeNull, TypeBoolean, TypeInteger, TypeNumber, TypeString}
+	if depth > 0 {
+		kinds = append(kinds, TypeArray, TypeObject)
+	}
+
+	switch kind := kinds[r.IntN(len(kinds))]; kind {
+	case TypeArray:
+		return (&Schema{Type: TypeArray}).generate(r, depth)
+	case TypeObject:
+		obj := make(map[string]any)
+		for range r.IntN(defaultMaxItems + 1) {
+			obj[faker.Word()] = generateAny(r, depth-1)
+		}
+		return obj
+	default:
+		return (&Schema{Type: kind}).generate(r, depth)
+	}
+}
+
+func randomTime(r *rand.Rand) time.Time {
+	return time.Unix(r.Int64N(1<<32), 0).UTC()
+}
+
+func bounds(minimum, maximum *int, size int) (int, int) {
+	lo, hi := 0, size
+	if minimum != nil {
+		lo = *minimum
+		hi = max(hi, lo+size)
+	}
+	if maximum != nil {
+		hi = *maximum
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestSchema_Generate(t *testing.T) {
+	one := 1
+	three := 3
+	zero := 0.0
+	ten := 10.0
+
+	testCases := []struct {
+		name   string
+		schema *Schema
+	}{
+		{name: "nil", schema: nil},
+		{name: "boolean", schema: &Schema{Type: TypeBoolean}},
+		{name: "integer", schema: &Schema{Type: TypeInteger, Minimum: &zero, Maximum: &ten}},
+		{name: "number", schema: &Schema{Type: TypeNumber, Minimum: &zero, Maximum: &ten}},
+		{name: "string", schema: &Schema{Type: TypeString, MinLength: &one, MaxLength: &three}},
+		{name: "pattern", schema: &Schema{Type: TypeString, Pattern: "^[a-z]{2,4}-[0-9]+$"}},
+		{name: "format", schema: &Schema{Type: TypeString, Format: "date-time"}},
+		{name: "enum", schema: &Schema{Enum: []any{"GET", "POST"}}},
+		{name: "anyOf", schema: &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeNull}}}},
+		{name: "array", schema: &Schema{Type: TypeArray, Items: &Schema{Type: TypeInteger}, MinItems: &one, MaxItems: &three}},
+		{
+			name: "object",
+			schema: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"method": {Type: TypeString, Enum: []any{"GET", "POST"}},
+					"path":   {Type: TypeString, Pattern: "^/"},
+					"body":   {AdditionalProperties: &Schema{Type: TypeString}},
+				},
+				Required: []string{"method", "path"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			for range 100 {
+				val, err := types.Marshal(tc.schema.Generate(r))
+				require.NoError(t, err)
+				require.NoError(t, tc.schema.Validate(val))
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// Schema describes the shape of a payload using a subset of JSON Schema.
+type Schema struct {
+	// Type restricts the payload to a JSON type such as object, array, string, number, integer, boolean or null.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Format annotates the type with a well-known format.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Enum lists the values the payload may take.
+	Enum []any `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Properties defines the schemas of the named object properties.
+	Properties map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	// Required lists the object properties that must be present.
+	Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+	// AdditionalProperties defines the schema of object properties not listed in Properties.
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	// Items defines the schema of array elements.
+	Items *Schema `json:"items,omitempty" yaml:"items,omitempty"`
+	// MinItems is the minimum length of an array.
+	MinItems *int `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	// MaxItems is the maximum length of an array.
+	MaxItems *int `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	// MinLength is the minimum length of a string.
+	MinLength *int `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	// MaxLength is the maximum length of a string.
+	MaxLength *int `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	// Minimum is the inclusive lower bound of a number.
+	Minimum *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	// Maximum is the inclusive upper bound of a number.
+	Maximum *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	// Pattern is a regular expression a string must match.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// AnyOf lists alternative schemas of which at least one must match.
+	AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+}
+
+// Type constants for the JSON types supported by Schema.
+const (
+	TypeNull    = "null"
+	TypeBoolean = "boolean"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeString  = "string"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+var (
+	ErrInvalidValue = errors.New("invalid value")
+	ErrIncompatible = errors.New("incompatible schema")
+)
+
+// patterns caches compiled patterns so that validating packets does not compile them again.
+var patterns sync.Map
+
+// Validate checks whether the value conforms to the schema.
+func (s *Schema) Validate(val types.Value) error {
+	return s.validate(val, "$")
+}
+
+// Compatible checks whether every value produced under the source schema is accepted by the target schema.
+// Constraints that cannot be compared statically are assumed to be compatible.
+func Compatible(source, target *Schema) error {
+	return compatible(source, target, "$")
+}
+
+// TypeOf returns the JSON type of the value.
+func TypeOf(val types.Value) string {
+	switch val.(type) {
+	case nil:
+		return TypeNull
+	case types.Boolean:
+		return TypeBoolean
+	case types.Integer, types.Uinteger:
+		return TypeInteger
+	case types.Float:
+		if f := val.(types.Float).Float(); f == float64(int64(f)) {
+			return TypeInteger
+		}
+		return TypeNumber
+	case types.String, types.Binary, types.Buffer:
+		return TypeString
+	case types.Slice:
+		return TypeArray
+	case types.Map:
+		return TypeObject
+	default:
+		return ""
+	}
+}
+
+func (s *Schema) validate(val types.Value, path string) error {
+	if s == nil {
+		return nil
+	}
+
+	if len(s.AnyOf) > 0 {
+		var errs []string
+		matched := false
+		for _, sub := range s.AnyOf {
+			if err := sub.validate(val, path); err != nil {
+				errs = append(errs, err.Error())
+			} else {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.WithMessagef(ErrInvalidValue, "%s: no schema in anyOf matched: %s", path, strings.Join(errs, "; "))
+		}
+	}
+
+	if s.Type != "" && !assignable(TypeOf(val), s.Type) {
+		return errors.WithMessagef(ErrInvalidValue, "%s: expected %s, got %s", path, s.Type, TypeOf(val))
+	}
+
+	if len(s.Enum) > 0 {
+		matched := false
+		for _, e := range s.Enum {
+			if v, err := types.Marshal(e); err == nil && equal(v, val) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.WithMessagef(ErrInvalidValue, "%s: value %v is not one of %v", path, types.InterfaceOf(val), s.Enum)
+		}
+	}
+
+	switch v := val.(type) {
+	case types.String:
+		n := len([]rune(v.String()))
+		if s.MinLength != nil && n < *s.MinLength {
+			return errors.WithMessagef(ErrInvalidValue, "%s: length %d is less than %d", path, n, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return errors.WithMessagef(ErrInvalidValue, "%s: length %d is greater than %d", path, n, *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			re, err := compilePattern(s.Pattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(v.String()) {
+				return errors.WithMessagef(ErrInvalidValue, "%s: %q does not match %q", path, v.String(), s.Pattern)
+			}
+		}
+	case types.Slice:
+		if s.MinItems != nil && v.Len() < *s.MinItems {
+			return errors.WithMessagef(ErrInvalidValue, "%s: %d items is less than %d", path, v.Len(), *s.MinItems)
+		}
+		if s.MaxItems != nil && v.Len() > *s.MaxItems {
+			return errors.WithMessagef(ErrInvalidValue, "%s: %d items is greater than %d", path, v.Len(), *s.MaxItems)
+		}
+		for i, elem := range v.Range() {
+			if err := s.Items.validate(elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case types.Map:
+		for _, key := range s.Required {
+			if !v.Has(types.NewString(key)) {
+				return errors.WithMessagef(ErrInvalidValue, "%s: missing required property %q", path, key)
+			}
+		}
+		for key, elem := range v.Range() {
+			k, ok := key.(types.String)
+			if !ok {
+				continue
+			}
+			sub, ok := s.Properties[k.String()]
+			if !ok {
+				sub = s.AdditionalProperties
+			}
+			if err := sub.validate(elem, path+"."+k.String()); err != nil {
+				return err
+			}
+		}
+	default:
+		if n, ok := number(val); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				return errors.WithMessagef(ErrInvalidValue, "%s: %v is less than %v", path, n, *s.Minimum)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				return errors.WithMessagef(ErrInvalidValue, "%s: %v is greater than %v", path, n, *s.Maximum)
+			}
+		}
+	}
+	return nil
+}
+
+func compatible(source, target *Schema, path string) error {
+	if source == nil || target == nil {
+		return nil
+	}
+
+	if len(source.AnyOf) > 0 {
+		for _, sub := range source.AnyOf {
+			if err := compatible(sub.merge(source), target, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(target.AnyOf) > 0 {
+		var errs []string
+		for _, sub := range target.AnyOf {
+			err := compatible(source, sub.merge(target), path)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err.Error())
+		}
+		return errors.WithMessagef(ErrIncompatible, "%s: no schema in anyOf accepts the source: %s", path, strings.Join(errs, "; "))
+	}
+
+	if source.Type != "" && target.Type != "" && !assignable(source.Type, target.Type) {
+		return errors.WithMessagef(ErrIncompatible, "%s: expected %s, got %s", path, target.Type, source.Type)
+	}
+
+	if len(source.Enum) > 0 && len(target.Enum) > 0 {
+		for _, e := range source.Enum {
+			if !slices.ContainsFunc(target.Enum, func(t any) bool {
+				x, err1 := types.Marshal(e)
+				y, err2 := types.Marshal(t)
+				return err1 == nil && err2 == nil && equal(x, y)
+			}) {
+				return errors.WithMessagef(ErrIncompatible, "%s: value %v is not one of %v", path, e, target.Enum)
+			}
+		}
+	}
+
+	if len(source.Properties) > 0 || len(source.Required) > 0 {
+		for _, key := range target.Required {
+			if !slices.Contains(source.Required, key) {
+				return errors.WithMessagef(ErrIncompatible, "%s: required property %q is not guaranteed", path, key)
+			}
+		}
+	}
+	for key, sub := range source.Properties {
+		t, ok := target.Properties[key]
+		if !ok {
+			t = target.AdditionalProperties
+		}
+		if err := compatible(sub, t, path+"."+key); err != nil {
+			return err
+		}
+	}
+
+	return compatible(source.Items, target.Items, path+"[]")
+}
+
+func (s *Schema) merge(parent *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	merged := *s
+	if merged.Type == "" {
+		merged.Type = parent.Type
+	}
+	if merged.Properties == nil {
+		merged.Properties = parent.Properties
+	}
+	if merged.Required == nil {
+		merged.Required = parent.Required
+	}
+	if merged.Items == nil {
+		merged.Items = parent.Items
+	}
+	return &merged
+}
+
+func assignable(source, target string) bool {
+	return source == target || (source == TypeInteger && target == TypeNumber)
+}
+
+func equal(x, y types.Value) bool {
+	if a, ok := number(x); ok {
+		if b, ok := number(y); ok {
+			return a == b
+		}
+	}
+	return types.Equal(x, y)
+}
+
+func number(val types.Value) (float64, bool) {
+	switch v := val.(type) {
+	case types.Integer:
+		return float64(v.Int()), true
+	case types.Uinteger:
+		return float64(v.Uint()), true
+	case types.Float:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
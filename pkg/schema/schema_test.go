@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	one := 1
+	two := 2.0
+
+	testCases := []struct {
+		name   string
+		schema *Schema
+		value  any
+		valid  bool
+	}{
+		{name: "nil", schema: nil, value: "foo", valid: true},
+		{name: "type", schema: &Schema{Type: TypeString}, value: "foo", valid: true},
+		{name: "type mismatch", schema: &Schema{Type: TypeString}, value: 1, valid: false},
+		{name: "integer as number", schema: &Schema{Type: TypeNumber}, value: 1, valid: true},
+		{name: "number as integer", schema: &Schema{Type: TypeInteger}, value: 1.5, valid: false},
+		{name: "null", schema: &Schema{Type: TypeNull}, value: nil, valid: true},
+		{name: "enum", schema: &Schema{Enum: []any{"GET", "POST"}}, value: "GET", valid: true},
+		{name: "enum mismatch", schema: &Schema{Enum: []any{"GET", "POST"}}, value: "PUT", valid: false},
+		{name: "enum number", schema: &Schema{Enum: []any{1, 2}}, value: 2.0, valid: true},
+		{name: "minLength", schema: &Schema{MinLength: &one}, value: "", valid: false},
+		{name: "pattern", schema: &Schema{Pattern: "^/"}, value: "/ping", valid: true},
+		{name: "pattern mismatch", schema: &Schema{Pattern: "^/"}, value: "ping", valid: false},
+		{name: "maximum", schema: &Schema{Maximum: &two}, value: 3, valid: false},
+		{name: "maxItems", schema: &Schema{MaxItems: &one}, value: []any{1, 2}, valid: false},
+		{name: "items", schema: &Schema{Items: &Schema{Type: TypeString}}, value: []any{"a", 1}, valid: false},
+		{
+			name:   "required",
+			schema: &Schema{Type: TypeObject, Required: []string{"method"}},
+			value:  map[string]any{"path": "/"},
+			valid:  false,
+		},
+		{
+			name: "properties",
+			schema: &Schema{
+				Type:       TypeObject,
+				Properties: map[string]*Schema{"status": {Type: TypeInteger}},
+			},
+			value: map[string]any{"status": "200"},
+			valid: false,
+		},
+		{
+			name: "additionalProperties",
+			schema: &Schema{
+				Type:                 TypeObject,
+				AdditionalProperties: &Schema{Type: TypeString},
+			},
+			value: map[string]any{"foo": "bar"},
+			valid: true,
+		},
+		{
+			name:   "anyOf",
+			schema: &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeInteger}}},
+			value:  1,
+			valid:  true,
+		},
+		{
+			name:   "anyOf mismatch",
+			schema: &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeInteger}}},
+			value:  true,
+			valid:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := types.Marshal(tc.value)
+			require.NoError(t, err)
+
+			err = tc.schema.Validate(v)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidValue)
+			}
+		})
+	}
+}
+
+func BenchmarkSchema_Validate(b *testing.B) {
+	s := &Schema{Type: TypeString, Pattern: "^/[a-z]+$"}
+	v := types.NewString("/ping")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Validate(v)
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	testCases := []struct {
+		name       string
+		source     *Schema
+		target     *Schema
+		compatible bool
+	}{
+		{name: "nil", source: nil, target: &Schema{Type: TypeString}, compatible: true},
+		{name: "same type", source: &Schema{Type: TypeString}, target: &Schema{Type: TypeString}, compatible: true},
+		{name: "different type", source: &Schema{Type: TypeString}, target: &Schema{Type: TypeObject}, compatible: false},
+		{name: "integer to number", source: &Schema{Type: TypeInteger}, target: &Schema{Type: TypeNumber}, compatible: true},
+		{name: "number to integer", source: &Schema{Type: TypeNumber}, target: &Schema{Type: TypeInteger}, compatible: false},
+		{name: "enum subset", source: &Schema{Enum: []any{"a"}}, target: &Schema{Enum: []any{"a", "b"}}, compatible: true},
+		{name: "enum superset", source: &Schema{Enum: []any{"a", "c"}}, target: &Schema{Enum: []any{"a", "b"}}, compatible: false},
+		{
+			name:       "required guaranteed",
+			source:     &Schema{Type: TypeObject, Required: []string{"method", "path"}},
+			target:     &Schema{Type: TypeObject, Required: []string{"method"}},
+			compatible: true,
+		},
+		{
+			name:       "required not guaranteed",
+			source:     &Schema{Type: TypeObject, Required: []string{"path"}},
+			target:     &Schema{Type: TypeObject, Required: []string{"method"}},
+			compatible: false,
+		},
+		{
+			name:       "properties",
+			source:     &Schema{Type: TypeObject, Properties: map[string]*Schema{"status": {Type: TypeString}}},
+			target:     &Schema{Type: TypeObject, Properties: map[string]*Schema{"status": {Type: TypeInteger}}},
+			compatible: false,
+		},
+		{
+			name:       "items",
+			source:     &Schema{Type: TypeArray, Items: &Schema{Type: TypeInteger}},
+			target:     &Schema{Type: TypeArray, Items: &Schema{Type: TypeNumber}},
+			compatible: true,
+		},
+		{
+			name:       "source anyOf",
+			source:     &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeInteger}}},
+			target:     &Schema{Type: TypeString},
+			compatible: false,
+		},
+		{
+			name:       "target anyOf",
+			source:     &Schema{Type: TypeInteger},
+			target:     &Schema{AnyOf: []*Schema{{Type: TypeString}, {Type: TypeNumber}}},
+			compatible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Compatible(tc.source, tc.target)
+			if tc.compatible {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrIncompatible)
+			}
+		})
+	}
+}
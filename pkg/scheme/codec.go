@@ -14,11 +14,26 @@ type Codec interface {
 	Compile(sp spec.Spec) (node.Node, error)
 }
 
+// Describer is implemented by codecs that declare the payload schemas of the nodes they compile.
+type Describer interface {
+	// Describe returns the port schemas of the node compiled from the given spec.Spec.
+	Describe(sp spec.Spec) *spec.Schemas
+}
+
 type codec struct {
 	compile func(sp spec.Spec) (node.Node, error)
 }
 
-var _ Codec = (*codec)(nil)
+type describedCodec struct {
+	Codec
+	schemas *spec.Schemas
+}
+
+var (
+	_ Codec     = (*codec)(nil)
+	_ Codec     = (*describedCodec)(nil)
+	_ Describer = (*describedCodec)(nil)
+)
 
 // CodecFunc takes a compile function and returns a struct that implements the Codec interface.
 func CodecFunc(compile func(sp spec.Spec) (node.Node, error)) Codec {
@@ -38,3 +53,12 @@ func CodecWithType[T spec.Spec](compile func(spec T) (node.Node, error)) Codec {
 func (c *codec) Compile(sp spec.Spec) (node.Node, error) {
 	return c.compile(sp)
 }
+
+// CodecWithSchemas wraps a Codec so that it declares the given port schemas.
+func CodecWithSchemas(codec Codec, schemas *spec.Schemas) Codec {
+	return &describedCodec{Codec: codec, schemas: schemas}
+}
+
+func (c *describedCodec) Describe(_ spec.Spec) *spec.Schemas {
+	return c.schemas
+}
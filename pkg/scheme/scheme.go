@@ -22,7 +22,10 @@ type Scheme struct {
 	mu       sync.RWMutex
 }
 
-var _ Codec = (*Scheme)(nil)
+var (
+	_ Codec     = (*Scheme)(nil)
+	_ Describer = (*Scheme)(nil)
+)
 
 // New creates a new Scheme instance with initialized type and codec maps.
 func New() *Scheme {
@@ -162,3 +165,15 @@ func (s *Scheme) Compile(sp spec.Spec) (node.Node, error) {
 	}
 	return cdc.Compile(sp)
 }
+
+// Describe returns the port schemas declared by the codec of the spec, overridden by those declared in the spec itself.
+func (s *Scheme) Describe(sp spec.Spec) *spec.Schemas {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var schemas *spec.Schemas
+	if d, ok := s.codecs[sp.GetKind()].(Describer); ok {
+		schemas = d.Describe(sp)
+	}
+	return schemas.Merge(sp.GetSchemas())
+}
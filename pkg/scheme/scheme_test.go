@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/spec"
 )
 
@@ -85,6 +86,20 @@ func TestScheme_Decode(t *testing.T) {
 	decode, err := s.Decode(meta)
 	require.NoError(t, err)
 	require.IsType(t, decode, &spec.Meta{})
+
+	meta.Strategies = map[string]spec.Strategy{node.PortOut: {Type: faker.UUIDHyphenated()}}
+
+	_, err = s.Decode(meta)
+	require.Error(t, err)
+	meta.Strategies = map[string]spec.Strategy{node.PortOut: {Type: "hash"}}
+
+	_, err = s.Decode(meta)
+	require.Error(t, err)
+
+	meta.Strategies = map[string]spec.Strategy{node.PortOut: {Type: "hash", Key: "id"}}
+
+	_, err = s.Decode(meta)
+	require.NoError(t, err)
 }
 
 func TestScheme_Compile(t *testing.T) {
@@ -102,3 +117,24 @@ func TestScheme_Compile(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, n)
 }
+
+func TestScheme_Describe(t *testing.T) {
+	s := New()
+	kind := faker.UUIDHyphenated()
+
+	s.AddCodec(kind, CodecWithSchemas(CodecFunc(func(spec spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(nil), nil
+	}), &spec.Schemas{
+		Ins:  map[string]*schema.Schema{node.PortIn: {Type: schema.TypeObject}},
+		Outs: map[string]*schema.Schema{node.PortOut: {Type: schema.TypeObject}},
+	}))
+
+	schemas := s.Describe(&spec.Meta{
+		Kind: kind,
+		Schemas: &spec.Schemas{
+			Outs: map[string]*schema.Schema{node.PortOut: {Type: schema.TypeString}},
+		},
+	})
+	require.Equal(t, schema.TypeObject, schemas.In(node.PortIn).Type)
+	require.Equal(t, schema.TypeString, schemas.Out(node.PortOut).Type)
+}
@@ -0,0 +1,115 @@
+package secret
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// Envelope holds data encrypted with a data key that is itself wrapped by a KeyProvider.
+type Envelope struct {
+	Algorithm  string `json:"algorithm"`
+	Key        string `json:"key"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// AlgorithmAESGCM identifies envelopes encrypted with AES-256-GCM.
+const AlgorithmAESGCM = "AES-256-GCM"
+
+var ErrInvalidEnvelope = errors.New("invalid envelope")
+
+// Seal encrypts the plaintext with a new data key and wraps the key with the provider.
+func Seal(ctx context.Context, provider KeyProvider, plaintext []byte) (*Envelope, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := provider.WrapKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		Algorithm:  AlgorithmAESGCM,
+		Key:        base64.StdEncoding.EncodeToString(wrapped),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, plaintext, nil)),
+	}, nil
+}
+
+// Open unwraps the data key with the provider and decrypts the envelope.
+func Open(ctx context.Context, provider KeyProvider, env *Envelope) ([]byte, error) {
+	if env.Algorithm != AlgorithmAESGCM {
+		return nil, errors.WithStack(ErrInvalidEnvelope)
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(env.Key)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidEnvelope, err.Error())
+	}
+	nonce, err := base64.StdEncoding.DecodeString(env.Nonce)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidEnvelope, err.Error())
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(env.Ciphertext)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidEnvelope, err.Error())
+	}
+
+	key, err := provider.UnwrapKey(ctx, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.WithStack(ErrInvalidEnvelope)
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidEnvelope, err.Error())
+	}
+	return plaintext, nil
+}
+
+// EnvelopeOf returns the envelope held by the data, or nil if the data is not sealed.
+func EnvelopeOf(data any) *Envelope {
+	if env, ok := data.(*Envelope); ok {
+		return env
+	}
+	if kind := reflect.ValueOf(data).Kind(); kind != reflect.Map && kind != reflect.Struct && kind != reflect.Pointer {
+		return nil
+	}
+
+	doc, err := types.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	env := &Envelope{}
+	if err := types.Unmarshal(doc, env); err != nil || env.Algorithm == "" || env.Ciphertext == "" {
+		return nil
+	}
+	return env
+}
@@ -0,0 +1,60 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeal(t *testing.T) {
+	p, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	plaintext := []byte(faker.Sentence())
+
+	env, err := Seal(context.TODO(), p, plaintext)
+	require.NoError(t, err)
+	require.Equal(t, AlgorithmAESGCM, env.Algorithm)
+	require.NotContains(t, env.Ciphertext, string(plaintext))
+
+	opened, err := Open(context.TODO(), p, env)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+}
+
+func TestOpen(t *testing.T) {
+	p, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	env, err := Seal(context.TODO(), p, []byte(faker.Sentence()))
+	require.NoError(t, err)
+
+	tampered := *env
+	tampered.Ciphertext = env.Nonce
+
+	_, err = Open(context.TODO(), p, &tampered)
+	require.ErrorIs(t, err, ErrInvalidEnvelope)
+
+	tampered = *env
+	tampered.Algorithm = faker.Word()
+
+	_, err = Open(context.TODO(), p, &tampered)
+	require.ErrorIs(t, err, ErrInvalidEnvelope)
+}
+
+func TestEnvelopeOf(t *testing.T) {
+	p, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	env, err := Seal(context.TODO(), p, []byte(faker.Sentence()))
+	require.NoError(t, err)
+
+	require.Equal(t, env, EnvelopeOf(env))
+	require.Equal(t, env, EnvelopeOf(map[string]string{
+		"algorithm":  env.Algorithm,
+		"key":        env.Key,
+		"nonce":      env.Nonce,
+		"ciphertext": env.Ciphertext,
+	}))
+	require.Nil(t, EnvelopeOf(faker.Word()))
+	require.Nil(t, EnvelopeOf(map[string]any{"password": faker.Password()}))
+}
@@ -0,0 +1,98 @@
+package secret
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// KeyProvider wraps and unwraps the data keys used to encrypt values.
+type KeyProvider interface {
+	// WrapKey encrypts a data key with the key encryption key.
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyProvider wraps data keys with a key encryption key held in memory.
+type LocalKeyProvider struct {
+	aead cipher.AEAD
+}
+
+// KeySize is the size in bytes of key encryption keys and data keys.
+const KeySize = 32
+
+var ErrInvalidKey = errors.New("invalid key")
+
+var _ KeyProvider = (*LocalKeyProvider)(nil)
+
+// NewLocalKeyProvider creates a LocalKeyProvider using the given 32-byte key.
+func NewLocalKeyProvider(key []byte) (*LocalKeyProvider, error) {
+	if len(key) != KeySize {
+		return nil, errors.WithStack(ErrInvalidKey)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKeyProvider{aead: aead}, nil
+}
+
+// NewFileKeyProvider creates a LocalKeyProvider from a file containing a base64 encoded 32-byte key.
+func NewFileKeyProvider(fs afero.Fs, filename string) (*LocalKeyProvider, error) {
+	data, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	return NewLocalKeyProvider(key)
+}
+
+// GenerateKey returns a new random key encoded in base64, suitable for a key file.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// WrapKey encrypts the data key with the local key.
+func (p *LocalKeyProvider) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return p.aead.Seal(nonce, nonce, key, nil), nil
+}
+
+// UnwrapKey decrypts the data key with the local key.
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	size := p.aead.NonceSize()
+	if len(wrapped) < size {
+		return nil, errors.WithStack(ErrInvalidKey)
+	}
+	key, err := p.aead.Open(nil, wrapped[:size], wrapped[size:], nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidKey, err.Error())
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
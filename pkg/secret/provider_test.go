@@ -0,0 +1,66 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	_, err := NewLocalKeyProvider(make([]byte, KeySize))
+	require.NoError(t, err)
+
+	_, err = NewLocalKeyProvider(make([]byte, KeySize-1))
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	err = afero.WriteFile(fs, "key", []byte(key+"\n"), 0600)
+	require.NoError(t, err)
+
+	p, err := NewFileKeyProvider(fs, "key")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+
+	err = afero.WriteFile(fs, "invalid", []byte(faker.Word()), 0600)
+	require.NoError(t, err)
+
+	_, err = NewFileKeyProvider(fs, "invalid")
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestLocalKeyProvider_WrapKey(t *testing.T) {
+	p, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	key := []byte(faker.UUIDDigit())
+
+	wrapped, err := p.WrapKey(context.TODO(), key)
+	require.NoError(t, err)
+	require.NotEqual(t, key, wrapped)
+
+	unwrapped, err := p.UnwrapKey(context.TODO(), wrapped)
+	require.NoError(t, err)
+	require.Equal(t, key, unwrapped)
+}
+
+func TestLocalKeyProvider_UnwrapKey(t *testing.T) {
+	p1, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	wrapped, err := p1.WrapKey(context.TODO(), []byte(faker.UUIDDigit()))
+	require.NoError(t, err)
+
+	other := make([]byte, KeySize)
+	other[0] = 1
+	p2, _ := NewLocalKeyProvider(other)
+
+	_, err = p2.UnwrapKey(context.TODO(), wrapped)
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
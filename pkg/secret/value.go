@@ -0,0 +1,98 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+// Redacted replaces sensitive data when it is displayed.
+const Redacted = "[REDACTED]"
+
+var ErrNoKeyProvider = errors.New("no key provider")
+
+// Encrypt seals the data of a secret value. Values that are not secret or already sealed are left unchanged.
+func Encrypt(ctx context.Context, provider KeyProvider, val *value.Value) error {
+	if !val.IsSecret() || EnvelopeOf(val.Data) != nil {
+		return nil
+	}
+	if provider == nil {
+		return errors.WithStack(ErrNoKeyProvider)
+	}
+
+	doc, err := types.Marshal(val.Data)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(types.InterfaceOf(doc))
+	if err != nil {
+		return err
+	}
+
+	env, err := Seal(ctx, provider, plaintext)
+	if err != nil {
+		return err
+	}
+	val.Data = env
+	return nil
+}
+
+// Decrypt opens the sealed data of a value. Values whose data is not sealed are left unchanged.
+func Decrypt(ctx context.Context, provider KeyProvider, val *value.Value) error {
+	env := EnvelopeOf(val.Data)
+	if env == nil {
+		return nil
+	}
+	if provider == nil {
+		return errors.WithStack(ErrNoKeyProvider)
+	}
+
+	plaintext, err := Open(ctx, provider, env)
+	if err != nil {
+		return err
+	}
+
+	var data any
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return err
+	}
+	val.Data = data
+	return nil
+}
+
+// Redact returns a copy of the value whose data is redacted if the value is secret.
+func Redact(val *value.Value) *value.Value {
+	if !val.IsSecret() {
+		return val
+	}
+	redacted := *val
+	redacted.Data = RedactData(val.Data)
+	return &redacted
+}
+
+// RedactData replaces every leaf of the data with Redacted while keeping its structure.
+func RedactData(data any) any {
+	if EnvelopeOf(data) != nil {
+		return Redacted
+	}
+	switch v := data.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, val := range v {
+			redacted[key] = RedactData(val)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, val := range v {
+			redacted[i] = RedactData(val)
+		}
+		return redacted
+	default:
+		return Redacted
+	}
+}
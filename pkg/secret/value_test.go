@@ -0,0 +1,62 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/value"
+)
+
+func TestEncrypt(t *testing.T) {
+	p, _ := NewLocalKeyProvider(make([]byte, KeySize))
+
+	data := map[string]any{"password": faker.Password()}
+	val := &value.Value{
+		ID:     uuid.Must(uuid.NewV7()),
+		Secret: true,
+		Data:   data,
+	}
+
+	err := Encrypt(context.TODO(), p, val)
+	require.NoError(t, err)
+	require.NotNil(t, EnvelopeOf(val.Data))
+
+	err = Decrypt(context.TODO(), p, val)
+	require.NoError(t, err)
+	require.Equal(t, data, val.Data)
+}
+
+func TestEncrypt_NoKeyProvider(t *testing.T) {
+	val := &value.Value{
+		ID:     uuid.Must(uuid.NewV7()),
+		Secret: true,
+		Data:   faker.Password(),
+	}
+
+	err := Encrypt(context.TODO(), nil, val)
+	require.ErrorIs(t, err, ErrNoKeyProvider)
+
+	val.Secret = false
+
+	err = Encrypt(context.TODO(), nil, val)
+	require.NoError(t, err)
+}
+
+func TestRedact(t *testing.T) {
+	val := &value.Value{
+		ID:     uuid.Must(uuid.NewV7()),
+		Secret: true,
+		Data:   map[string]any{"password": faker.Password(), "keys": []any{faker.Word()}},
+	}
+
+	redacted := Redact(val)
+	require.Equal(t, map[string]any{"password": Redacted, "keys": []any{Redacted}}, redacted.Data)
+	require.NotEqual(t, val.Data, redacted.Data)
+
+	val.Secret = false
+	require.Equal(t, val, Redact(val))
+}
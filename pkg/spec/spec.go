@@ -7,6 +7,7 @@ import (
 	"github.com/siyul-park/uniflow/internal/template"
 	"github.com/siyul-park/uniflow/pkg/encoding"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/types"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
@@ -41,6 +42,14 @@ type Spec interface {
 	GetPorts() map[string][]Port
 	// SetPorts sets the ports of the node.
 	SetPorts(val map[string][]Port)
+	// GetSchemas returns the port schemas of the node.
+	GetSchemas() *Schemas
+	// SetSchemas sets the port schemas of the node.
+	SetSchemas(val *Schemas)
+	// GetStrategies returns the distribution strategies of the node's output ports.
+	GetStrategies() map[string]Strategy
+	// SetStrategies sets the distribution strategies of the node's output ports.
+	SetStrategies(val map[string]Strategy)
 }
 
 // Meta contains metadata for node specifications.
@@ -59,6 +68,10 @@ type Meta struct {
 	Env map[string]Value `json:"env,omitempty" yaml:"env,omitempty"`
 	// Ports define connections to other nodes.
 	Ports map[string][]Port `json:"ports,omitempty" yaml:"ports,omitempty"`
+	// Schemas declare the payload schemas of the node's ports.
+	Schemas *Schemas `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	// Strategies define how output ports distribute packets across their links.
+	Strategies map[string]Strategy `json:"strategies,omitempty" yaml:"strategies,omitempty" validate:"dive"`
 }
 
 // Port represents a node port or connection on a node.
@@ -71,6 +84,22 @@ type Port struct {
 	Port string `json:"port" bson:"port" yaml:"port" validate:"required"`
 }
 
+// Schemas holds the payload schemas of input and output ports, keyed by port name.
+type Schemas struct {
+	// Ins are the schemas of payloads accepted by input ports.
+	Ins map[string]*schema.Schema `json:"ins,omitempty" yaml:"ins,omitempty"`
+	// Outs are the schemas of payloads emitted by output ports.
+	Outs map[string]*schema.Schema `json:"outs,omitempty" yaml:"outs,omitempty"`
+}
+
+// Strategy configures how an output port distributes packets across its links.
+type Strategy struct {
+	// Type is the name of the strategy: broadcast, round-robin, random, least-busy or hash.
+	Type string `json:"type" yaml:"type" validate:"required,oneof=broadcast round-robin random least-busy hash"`
+	// Key is the dot-separated payload path hashed by the hash strategy.
+	Key string `json:"key,omitempty" yaml:"key,omitempty" validate:"required_if=Type hash"`
+}
+
 // Value represents a sensitive piece of data associated with a node.
 type Value struct {
 	// ID is the unique identifier of the value.
@@ -170,6 +199,26 @@ func (m *Meta) SetPorts(val map[string][]Port) {
 	m.Ports = val
 }
 
+// GetSchemas returns the node's port schemas.
+func (m *Meta) GetSchemas() *Schemas {
+	return m.Schemas
+}
+
+// SetSchemas sets the node's port schemas.
+func (m *Meta) SetSchemas(val *Schemas) {
+	m.Schemas = val
+}
+
+// GetStrategies returns the node's output port strategies.
+func (m *Meta) GetStrategies() map[string]Strategy {
+	return m.Strategies
+}
+
+// SetStrategies sets the node's output port strategies.
+func (m *Meta) SetStrategies(val map[string]Strategy) {
+	m.Strategies = val
+}
+
 // IsBound checks if the spec is bound to any provided values.
 func (m *Meta) IsBound(values ...*value.Value) bool {
 	for _, val := range m.Env {
@@ -212,7 +261,7 @@ func (m *Meta) Bind(values ...*value.Value) error {
 		if value != nil {
 			v, err := template.Execute(val.Data, value.Data)
 			if err != nil {
-				return err
+				return errors.WithMessagef(err, "%s: %s.%s", meta.NamespacedName(m), KeyEnv, key)
 			}
 
 			val.ID = value.GetID()
@@ -227,6 +276,48 @@ func (m *Meta) Bind(values ...*value.Value) error {
 	return nil
 }
 
+// In returns the schema of the named input port.
+func (s *Schemas) In(name string) *schema.Schema {
+	if s == nil {
+		return nil
+	}
+	return s.Ins[name]
+}
+
+// Out returns the schema of the named output port.
+func (s *Schemas) Out(name string) *schema.Schema {
+	if s == nil {
+		return nil
+	}
+	return s.Outs[name]
+}
+
+// Merge returns schemas where the given schemas override the receiver's per port.
+func (s *Schemas) Merge(other *Schemas) *Schemas {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+	merged := &Schemas{}
+	for _, src := range []*Schemas{s, other} {
+		for name, sc := range src.Ins {
+			if merged.Ins == nil {
+				merged.Ins = make(map[string]*schema.Schema)
+			}
+			merged.Ins[name] = sc
+		}
+		for name, sc := range src.Outs {
+			if merged.Outs == nil {
+				merged.Outs = make(map[string]*schema.Schema)
+			}
+			merged.Outs[name] = sc
+		}
+	}
+	return merged
+}
+
 // IsIdentified checks whether the Value instance has a unique identifier or name.
 func (v *Value) IsIdentified() bool {
 	return v.ID != uuid.Nil || v.Name != ""
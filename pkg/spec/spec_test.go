@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/value"
 )
 
@@ -88,6 +89,44 @@ func TestMeta_Ports(t *testing.T) {
 	require.Equal(t, ports, meta.GetPorts())
 }
 
+func TestMeta_Schemas(t *testing.T) {
+	meta := &Meta{}
+	schemas := &Schemas{
+		Ins: map[string]*schema.Schema{
+			"in": {Type: schema.TypeObject},
+		},
+	}
+	meta.SetSchemas(schemas)
+	require.Equal(t, schemas, meta.GetSchemas())
+}
+
+func TestMeta_Strategies(t *testing.T) {
+	meta := &Meta{}
+	strategies := map[string]Strategy{
+		"out": {Type: "hash", Key: "user.id"},
+	}
+	meta.SetStrategies(strategies)
+	require.Equal(t, strategies, meta.GetStrategies())
+}
+
+func TestSchemas_Merge(t *testing.T) {
+	s1 := &Schemas{
+		Ins:  map[string]*schema.Schema{"in": {Type: schema.TypeObject}},
+		Outs: map[string]*schema.Schema{"out": {Type: schema.TypeObject}},
+	}
+	s2 := &Schemas{
+		Outs: map[string]*schema.Schema{"out": {Type: schema.TypeString}},
+	}
+
+	merged := s1.Merge(s2)
+	require.Equal(t, schema.TypeObject, merged.In("in").Type)
+	require.Equal(t, schema.TypeString, merged.Out("out").Type)
+	require.Nil(t, merged.In("error"))
+
+	require.Equal(t, s1, s1.Merge(nil))
+	require.Equal(t, s2, (*Schemas)(nil).Merge(s2))
+}
+
 func TestMeta_IsBound(t *testing.T) {
 	sec1 := &value.Value{
 		ID: uuid.Must(uuid.NewV7()),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/siyul-park/uniflow/internal/template"
 	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/meta"
 )
 
 // Unstructured implements the Spec interface with a flexible key-value structure.
@@ -26,6 +27,8 @@ const (
 	KeyAnnotations = "annotations"
 	KeyEnv         = "env"
 	KeyPorts       = "ports"
+	KeySchemas     = "schemas"
+	KeyStrategies  = "strategies"
 )
 
 var (
@@ -51,6 +54,10 @@ func (u *Unstructured) Get(key string) (any, bool) {
 		return u.Env, true
 	case KeyPorts:
 		return u.Ports, true
+	case KeySchemas:
+		return u.Schemas, true
+	case KeyStrategies:
+		return u.Strategies, true
 	default:
 		if u.Fields == nil {
 			return nil, false
@@ -91,6 +98,14 @@ func (u *Unstructured) Set(key string, val any) {
 		if v, ok := val.(map[string][]Port); ok {
 			u.Ports = v
 		}
+	case KeySchemas:
+		if v, ok := val.(*Schemas); ok {
+			u.Schemas = v
+		}
+	case KeyStrategies:
+		if v, ok := val.(map[string]Strategy); ok {
+			u.Strategies = v
+		}
 	default:
 		if u.Fields == nil {
 			u.Fields = make(map[string]any)
@@ -109,7 +124,7 @@ func (u *Unstructured) Build() error {
 	if len(env) > 0 {
 		fields, err := template.Execute(u.Fields, env)
 		if err != nil {
-			return err
+			return errors.WithMessage(err, meta.NamespacedName(u))
 		}
 
 		if fields, ok := fields.(map[string]any); ok {
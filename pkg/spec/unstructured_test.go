@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/schema"
 )
 
 func TestUnstructured_GetAndSet(t *testing.T) {
@@ -81,6 +82,24 @@ func TestUnstructured_GetAndSet(t *testing.T) {
 		require.Equal(t, env, val)
 	})
 
+	t.Run("KeySchemas", func(t *testing.T) {
+		unstructured := &Unstructured{}
+		schemas := &Schemas{Ins: map[string]*schema.Schema{node.PortIn: {Type: schema.TypeString}}}
+		unstructured.Set(KeySchemas, schemas)
+		val, ok := unstructured.Get(KeySchemas)
+		require.True(t, ok)
+		require.Equal(t, schemas, val)
+	})
+
+	t.Run("KeyStrategies", func(t *testing.T) {
+		unstructured := &Unstructured{}
+		strategies := map[string]Strategy{node.PortOut: {Type: "round-robin"}}
+		unstructured.Set(KeyStrategies, strategies)
+		val, ok := unstructured.Get(KeyStrategies)
+		require.True(t, ok)
+		require.Equal(t, strategies, val)
+	})
+
 	t.Run("CustomField", func(t *testing.T) {
 		unstructured := &Unstructured{}
 		customKey := "customField"
@@ -113,6 +132,50 @@ func TestUnstructured_Build(t *testing.T) {
 	require.Equal(t, "foo", unstructured.Fields["foo"])
 }
 
+func TestUnstructured_BuildTyped(t *testing.T) {
+	unstructured := &Unstructured{
+		Meta: Meta{
+			ID:   uuid.Must(uuid.NewV7()),
+			Kind: faker.UUIDHyphenated(),
+			Env: map[string]Value{
+				"PORT": {
+					Data: "8000",
+				},
+			},
+		},
+		Fields: map[string]any{
+			"port": "{{ int .PORT }}",
+		},
+	}
+
+	err := unstructured.Build()
+	require.NoError(t, err)
+	require.Equal(t, 8000, unstructured.Fields["port"])
+}
+
+func TestUnstructured_BuildError(t *testing.T) {
+	unstructured := &Unstructured{
+		Meta: Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: "default",
+			Name:      faker.UUIDHyphenated(),
+			Env: map[string]Value{
+				"PORT": {
+					Data: faker.Word(),
+				},
+			},
+		},
+		Fields: map[string]any{
+			"port": "{{ int .PORT }}",
+		},
+	}
+
+	err := unstructured.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "default/"+unstructured.Name+": port: ")
+}
+
 func TestUnstructured_MarshalJSON(t *testing.T) {
 	unstructured1 := &Unstructured{
 		Meta: Meta{
@@ -123,6 +186,11 @@ func TestUnstructured_MarshalJSON(t *testing.T) {
 					Data: "foo",
 				},
 			},
+			Schemas: &Schemas{
+				Ins: map[string]*schema.Schema{
+					node.PortIn: {Type: schema.TypeObject, Required: []string{"foo"}},
+				},
+			},
 		},
 		Fields: map[string]any{
 			"customField": "customValue",
@@ -103,6 +103,26 @@ func (s *Symbol) SetPorts(ports map[string][]spec.Port) {
 	s.Spec.SetPorts(ports)
 }
 
+// Schemas returns the port schemas associated with the Symbol.
+func (s *Symbol) Schemas() *spec.Schemas {
+	return s.Spec.GetSchemas()
+}
+
+// SetSchemas sets the port schemas of the Symbol.
+func (s *Symbol) SetSchemas(schemas *spec.Schemas) {
+	s.Spec.SetSchemas(schemas)
+}
+
+// Strategies returns the output port strategies associated with the Symbol.
+func (s *Symbol) Strategies() map[string]spec.Strategy {
+	return s.Spec.GetStrategies()
+}
+
+// SetStrategies sets the output port strategies of the Symbol.
+func (s *Symbol) SetStrategies(strategies map[string]spec.Strategy) {
+	s.Spec.SetStrategies(strategies)
+}
+
 // Ins returns the input ports associated with the Symbol.
 func (s *Symbol) Ins() map[string]*port.InPort {
 	s.mu.RLock()
@@ -127,6 +147,7 @@ func (s *Symbol) In(name string) *port.InPort {
 	p, ok := s.ins[name]
 	if !ok && s.Node != nil {
 		if p = s.Node.In(name); p != nil {
+			p.SetKey(s.ID().String() + "/" + name)
 			s.ins[name] = p
 		}
 	}
@@ -48,6 +48,7 @@ func TestSymbol_Getter(t *testing.T) {
 	require.Equal(t, meta.GetPorts(), sb.Ports())
 	require.Equal(t, meta.GetEnv(), sb.Env())
 	require.Equal(t, n.In(node.PortIn), sb.In(node.PortIn))
+	require.Equal(t, sb.ID().String()+"/"+node.PortIn, sb.In(node.PortIn).Key())
 	require.Equal(t, n.Out(node.PortOut), sb.Out(node.PortOut))
 	require.Contains(t, sb.Ins(), node.PortIn)
 	require.Contains(t, sb.Outs(), node.PortOut)
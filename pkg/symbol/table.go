@@ -2,14 +2,17 @@ package symbol
 
 import (
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 
 	"github.com/siyul-park/uniflow/pkg/node"
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/types"
 )
@@ -18,6 +21,7 @@ import (
 type TableOption struct {
 	LoadHooks   []LoadHook   // LoadHooks are functions executed when symbols are loaded.
 	UnloadHooks []UnloadHook // UnloadHooks are functions executed when symbols are unloaded.
+	Strict      bool         // Strict rejects symbols whose port schemas are incompatible with their links.
 }
 
 // Table manages symbols, providing storage and operations.
@@ -27,6 +31,7 @@ type Table struct {
 	references  map[uuid.UUID]map[string][]spec.Port
 	loadHooks   LoadHooks
 	unloadHooks UnloadHooks
+	strict      bool
 	mu          sync.RWMutex
 }
 
@@ -34,9 +39,11 @@ type Table struct {
 func NewTable(opts ...TableOption) *Table {
 	var loadHooks []LoadHook
 	var unloadHooks []UnloadHook
+	strict := false
 	for _, opt := range opts {
 		loadHooks = append(loadHooks, opt.LoadHooks...)
 		unloadHooks = append(unloadHooks, opt.UnloadHooks...)
+		strict = strict || opt.Strict
 	}
 
 	return &Table{
@@ -45,6 +52,7 @@ func NewTable(opts ...TableOption) *Table {
 		references:  make(map[uuid.UUID]map[string][]spec.Port),
 		loadHooks:   loadHooks,
 		unloadHooks: unloadHooks,
+		strict:      strict,
 	}
 }
 
@@ -125,10 +133,30 @@ func (t *Table) Insert(sb *Symbol) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.strict {
+		if err := t.verify(sb); err != nil {
+			return err
+		}
+	}
+
+	// Strategies are built first, so that a rejected symbol leaves the table untouched.
+	strategies, err := t.strategies(sb)
+	if err != nil {
+		return err
+	}
+
 	if _, err := t.free(sb.ID()); err != nil {
 		return err
 	}
-	return t.insert(sb)
+	return t.insert(sb, strategies)
+}
+
+// Verify checks whether the port schemas of the symbol are compatible with the symbols it would be linked to.
+func (t *Table) Verify(sb *Symbol) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.verify(sb)
 }
 
 // Free removes a symbol from the table by its ID.
@@ -224,7 +252,7 @@ func (t *Table) Close() error {
 	return nil
 }
 
-func (t *Table) insert(sb *Symbol) error {
+func (t *Table) insert(sb *Symbol, strategies map[*port.OutPort]port.Strategy) error {
 	t.symbols[sb.ID()] = sb
 
 	if sb.Name() != "" {
@@ -236,7 +264,7 @@ func (t *Table) insert(sb *Symbol) error {
 		ns[sb.Name()] = sb.ID()
 	}
 
-	t.links(sb)
+	t.links(sb, strategies)
 	return t.load(sb)
 }
 
@@ -302,7 +330,25 @@ func (t *Table) unload(sb *Symbol) error {
 	return nil
 }
 
-func (t *Table) links(sb *Symbol) {
+func (t *Table) strategies(sb *Symbol) (map[*port.OutPort]port.Strategy, error) {
+	strategies := make(map[*port.OutPort]port.Strategy)
+	for name, strategy := range sb.Strategies() {
+		if out := sb.Out(name); out != nil {
+			s, err := port.NewStrategy(strategy.Type, strategy.Key)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "port %s", name)
+			}
+			strategies[out] = s
+		}
+	}
+	return strategies, nil
+}
+
+func (t *Table) links(sb *Symbol, strategies map[*port.OutPort]port.Strategy) {
+	for out, strategy := range strategies {
+		out.SetStrategy(strategy)
+	}
+
 	for name, ports := range sb.Ports() {
 		out := sb.Out(name)
 
@@ -367,6 +413,64 @@ func (t *Table) links(sb *Symbol) {
 	}
 }
 
+func (t *Table) verify(sb *Symbol) error {
+	var errs []error
+
+	for name, ports := range sb.Ports() {
+		for _, port := range ports {
+			id := port.ID
+			if id == uuid.Nil {
+				if port.Name != "" && port.Name == sb.Name() {
+					id = sb.ID()
+				} else {
+					id = t.lookup(sb.Namespace(), port.Name)
+				}
+			}
+
+			ref, ok := t.symbols[id]
+			if id == sb.ID() {
+				ref, ok = sb, true
+			}
+			if !ok || ref.Namespace() != sb.Namespace() {
+				continue
+			}
+
+			if err := schema.Compatible(sb.Schemas().Out(name), ref.Schemas().In(port.Port)); err != nil {
+				errs = append(errs, errors.WithMessagef(err, "%s.%s -> %s.%s", sb.NamespacedName(), name, ref.NamespacedName(), port.Port))
+			}
+		}
+	}
+
+	for _, ref := range t.symbols {
+		if ref.ID() == sb.ID() || ref.Namespace() != sb.Namespace() {
+			continue
+		}
+
+		for name, ports := range ref.Ports() {
+			for _, port := range ports {
+				if (port.ID == sb.ID()) || (port.Name != "" && port.Name == sb.Name()) {
+					if err := schema.Compatible(ref.Schemas().Out(name), sb.Schemas().In(port.Port)); err != nil {
+						errs = append(errs, errors.WithMessagef(err, "%s.%s -> %s.%s", ref.NamespacedName(), name, sb.NamespacedName(), port.Port))
+					}
+				}
+			}
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return errors.WithMessage(schema.ErrIncompatible, strings.Join(msgs, "; "))
+}
+
 func (t *Table) unlinks(sb *Symbol) {
 	for name, ports := range sb.Ports() {
 		out := sb.Out(name)
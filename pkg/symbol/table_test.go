@@ -7,8 +7,11 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/siyul-park/uniflow/pkg/encoding"
 	"github.com/siyul-park/uniflow/pkg/meta"
 	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/spec"
 )
 
@@ -129,6 +132,146 @@ func TestTable_Insert(t *testing.T) {
 	require.Len(t, p3.Links(), 1)
 }
 
+func TestTable_Strategies(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	tb := NewTable()
+	defer tb.Close()
+
+	meta1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Strategies: map[string]spec.Strategy{
+			node.PortOut: {Type: port.StrategyRoundRobin},
+		},
+	}
+
+	sym1 := &Symbol{Spec: meta1, Node: node.NewOneToOneNode(nil)}
+
+	err := tb.Insert(sym1)
+	require.NoError(t, err)
+	require.NotNil(t, sym1.Out(node.PortOut).Strategy())
+	require.Nil(t, sym1.Out(node.PortError).Strategy())
+
+	meta2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Strategies: map[string]spec.Strategy{
+			node.PortOut: {Type: port.StrategyHash},
+		},
+	}
+
+	sym2 := &Symbol{Spec: meta2, Node: node.NewOneToOneNode(nil)}
+
+	err = tb.Insert(sym2)
+	require.ErrorIs(t, err, encoding.ErrUnsupportedValue)
+	require.Nil(t, tb.Lookup(sym2.ID()))
+	require.Nil(t, sym2.Out(node.PortOut).Strategy())
+
+	meta1.Strategies = map[string]spec.Strategy{
+		node.PortOut: {Type: port.StrategyHash},
+	}
+
+	err = tb.Insert(&Symbol{Spec: meta1, Node: node.NewOneToOneNode(nil)})
+	require.ErrorIs(t, err, encoding.ErrUnsupportedValue)
+	require.Equal(t, sym1, tb.Lookup(sym1.ID()))
+}
+
+func TestTable_Verify(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	tb := NewTable()
+	defer tb.Close()
+
+	meta1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Schemas: &spec.Schemas{
+			Outs: map[string]*schema.Schema{node.PortOut: {Type: schema.TypeString}},
+		},
+	}
+	meta2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Schemas: &spec.Schemas{
+			Ins: map[string]*schema.Schema{node.PortIn: {Type: schema.TypeObject}},
+		},
+	}
+
+	meta1.Ports = map[string][]spec.Port{
+		node.PortOut: {
+			{
+				Name: meta2.GetName(),
+				Port: node.PortIn,
+			},
+		},
+	}
+
+	sym1 := &Symbol{Spec: meta1, Node: node.NewOneToOneNode(nil)}
+	sym2 := &Symbol{Spec: meta2, Node: node.NewOneToOneNode(nil)}
+
+	err := tb.Insert(sym1)
+	require.NoError(t, err)
+
+	err = tb.Verify(sym2)
+	require.ErrorIs(t, err, schema.ErrIncompatible)
+
+	meta2.Schemas.Ins[node.PortIn] = &schema.Schema{Type: schema.TypeString}
+
+	err = tb.Verify(sym2)
+	require.NoError(t, err)
+}
+
+func TestTable_Strict(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	tb := NewTable(TableOption{Strict: true})
+	defer tb.Close()
+
+	meta1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Schemas: &spec.Schemas{
+			Ins: map[string]*schema.Schema{node.PortIn: {Type: schema.TypeObject}},
+		},
+	}
+	meta2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      kind,
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Schemas: &spec.Schemas{
+			Outs: map[string]*schema.Schema{node.PortOut: {Type: schema.TypeArray}},
+		},
+		Ports: map[string][]spec.Port{
+			node.PortOut: {
+				{
+					ID:   meta1.GetID(),
+					Port: node.PortIn,
+				},
+			},
+		},
+	}
+
+	sym1 := &Symbol{Spec: meta1, Node: node.NewOneToOneNode(nil)}
+	sym2 := &Symbol{Spec: meta2, Node: node.NewOneToOneNode(nil)}
+
+	err := tb.Insert(sym1)
+	require.NoError(t, err)
+
+	err = tb.Insert(sym2)
+	require.ErrorIs(t, err, schema.ErrIncompatible)
+	require.Nil(t, tb.Lookup(sym2.ID()))
+}
+
 func TestTable_Free(t *testing.T) {
 	kind := faker.UUIDHyphenated()
 
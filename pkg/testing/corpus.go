@@ -0,0 +1,122 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
+)
+
+// Corpus keeps the inputs that made fuzz tests fail so that later runs replay them as regressions.
+type Corpus struct {
+	fs  afero.Fs
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewCorpus creates a new Corpus storing its files in the file system, or in the OS if it's nil.
+func NewCorpus(fs afero.Fs) *Corpus {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Corpus{fs: fs}
+}
+
+// SetDir sets the directory against which relative corpus file names are resolved.
+func (c *Corpus) SetDir(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dir = dir
+}
+
+// Load returns the inputs stored in the corpus file, or nothing if the file doesn't exist.
+func (c *Corpus) Load(filename string) ([]any, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.load(c.path(filename))
+}
+
+// Add appends the inputs that aren't stored yet to the corpus file, creating it if needed.
+func (c *Corpus) Add(filename string, inputs ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.path(filename)
+
+	entries, err := c.load(path)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, input := range inputs {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		var entry any
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+
+		exists := false
+		for _, e := range entries {
+			if reflect.DeepEqual(e, entry) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			entries = append(entries, entry)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	printer, err := fmt2.NewPrinter(&buf, fmt2.FormatJSON)
+	if err != nil {
+		return err
+	}
+	if err := printer.Write(entries); err != nil {
+		return err
+	}
+
+	if err := c.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return afero.WriteFile(c.fs, path, buf.Bytes(), 0644)
+}
+
+func (c *Corpus) path(filename string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+	return filepath.Join(c.dir, filename)
+}
+
+func (c *Corpus) load(path string) ([]any, error) {
+	data, err := afero.ReadFile(c.fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entries []any
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpus_Load(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	c := NewCorpus(fs)
+
+	entries, err := c.Load(faker.Word() + ".json")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestCorpus_Add(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	c := NewCorpus(fs)
+	c.SetDir("tests")
+
+	filename := filepath.Join("corpus", faker.Word()+".json")
+
+	err := c.Add(filename, map[string]any{"name": "foo"}, 1)
+	require.NoError(t, err)
+
+	err = c.Add(filename, 1, "bar")
+	require.NoError(t, err)
+
+	ok, err := afero.Exists(fs, filepath.Join("tests", filename))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	entries, err := c.Load(filename)
+	require.NoError(t, err)
+	require.Equal(t, []any{map[string]any{"name": "foo"}, 1.0, "bar"}, entries)
+}
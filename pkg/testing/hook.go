@@ -0,0 +1,41 @@
+package testing
+
+import "strings"
+
+// Phase is the point of a run at which a hook executes.
+type Phase int
+
+const (
+	// BeforeAll hooks run once before any suite of the run starts.
+	BeforeAll Phase = iota
+	// BeforeEach hooks run before every suite, within the process of the suite.
+	BeforeEach
+	// AfterEach hooks run after every suite, within the process of the suite.
+	AfterEach
+	// AfterAll hooks run once after every suite of the run finishes.
+	AfterAll
+)
+
+// String returns the name of the phase.
+func (p Phase) String() string {
+	switch p {
+	case BeforeAll:
+		return "before-all"
+	case BeforeEach:
+		return "before-each"
+	case AfterEach:
+		return "after-each"
+	case AfterAll:
+		return "after-all"
+	default:
+		return "unknown"
+	}
+}
+
+// scopeOf returns the namespace part of a name, which limits the suites a hook applies to.
+func scopeOf(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhase_String(t *testing.T) {
+	require.Equal(t, "before-all", BeforeAll.String())
+	require.Equal(t, "before-each", BeforeEach.String())
+	require.Equal(t, "after-each", AfterEach.String())
+	require.Equal(t, "after-all", AfterAll.String())
+}
@@ -0,0 +1,66 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type jsonResult struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Suite     string    `json:"suite,omitempty"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Node      string    `json:"node,omitempty"`
+	Payload   any       `json:"payload,omitempty"`
+	Flaky     bool      `json:"flaky,omitempty"`
+	Retries   []string  `json:"retries,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Duration  float64   `json:"duration"`
+}
+
+// NewJSONReporter creates a new Reporter that writes each test result to the provided io.Writer as a line of JSON.
+func NewJSONReporter(w io.Writer) Reporter {
+	if w == nil {
+		w = io.Discard
+	}
+
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+	return ReportFunc(func(_ context.Context, result *Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return encoder.Encode(jsonResult{
+			ID:        result.ID,
+			Name:      result.Name,
+			Suite:     result.Suite(),
+			Status:    result.Status(),
+			Error:     result.Message(),
+			Node:      result.Node,
+			Payload:   result.Payload,
+			Flaky:     result.Flaky(),
+			Retries:   messages(result.Retries),
+			StartTime: result.StartTime,
+			EndTime:   result.EndTime,
+			Duration:  result.Duration().Seconds(),
+		})
+	})
+}
+
+func messages(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
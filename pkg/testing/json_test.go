@@ -0,0 +1,67 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONReporter_Report(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewJSONReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "default/foo", StartTime: now, EndTime: now})
+	require.NoError(t, err)
+	err = reporter.Report(ctx, &Result{Name: "default/bar", Error: fmt.Errorf("error"), Node: "assert", Payload: "baz", StartTime: now, EndTime: now.Add(time.Second)})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var pass map[string]any
+	err = json.Unmarshal([]byte(lines[0]), &pass)
+	require.NoError(t, err)
+	require.Equal(t, StatusPass, pass["status"])
+	require.Equal(t, "default", pass["suite"])
+	require.NotContains(t, pass, "error")
+
+	var fail map[string]any
+	err = json.Unmarshal([]byte(lines[1]), &fail)
+	require.NoError(t, err)
+	require.Equal(t, StatusFail, fail["status"])
+	require.Equal(t, "error", fail["error"])
+	require.Equal(t, "assert", fail["node"])
+	require.Equal(t, "baz", fail["payload"])
+	require.Equal(t, 1.0, fail["duration"])
+}
+
+func TestJSONReporter_Flaky(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewJSONReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "default/foo", Retries: []error{fmt.Errorf("error")}, StartTime: now, EndTime: now})
+	require.NoError(t, err)
+
+	var flaky map[string]any
+	err = json.Unmarshal(output.Bytes(), &flaky)
+	require.NoError(t, err)
+	require.Equal(t, StatusPass, flaky["status"])
+	require.Equal(t, true, flaky["flaky"])
+	require.Equal(t, []any{"error"}, flaky["retries"])
+}
@@ -0,0 +1,165 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JUnitReporter collects test results and writes them as a JUnit XML report when flushed.
+type JUnitReporter struct {
+	writer  io.Writer
+	results []*Result
+	mu      sync.Mutex
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	Classname string         `xml:"classname,attr"`
+	Time      string         `xml:"time,attr"`
+	Failure   *junitFailure  `xml:"failure,omitempty"`
+	Flaky     []junitFailure `xml:"flakyFailure,omitempty"`
+	Reruns    []junitFailure `xml:"rerunFailure,omitempty"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+var (
+	_ Reporter = (*JUnitReporter)(nil)
+	_ Flusher  = (*JUnitReporter)(nil)
+)
+
+// NewJUnitReporter creates a new JUnitReporter that writes the report to the provided io.Writer.
+func NewJUnitReporter(w io.Writer) *JUnitReporter {
+	if w == nil {
+		w = io.Discard
+	}
+	return &JUnitReporter{writer: w}
+}
+
+// Report collects the test result until the reporter is flushed.
+func (r *JUnitReporter) Report(_ context.Context, result *Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+	return nil
+}
+
+// Flush writes the collected results grouped by suite and resets the reporter.
+func (r *JUnitReporter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := r.results
+	r.results = nil
+
+	slices.SortStableFunc(results, func(x, y *Result) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+
+	report := junitTestSuites{}
+	var start, end time.Time
+	for i := 0; i < len(results); {
+		name := results[i].Suite()
+		suite := junitTestSuite{Name: name}
+
+		var suiteStart, suiteEnd time.Time
+		for ; i < len(results) && results[i].Suite() == name; i++ {
+			result := results[i]
+
+			testCase := junitTestCase{
+				Name:      strings.TrimPrefix(strings.TrimPrefix(result.Name, name), "/"),
+				Classname: name,
+				Time:      seconds(result.Duration()),
+			}
+			if result.Error != nil {
+				testCase.Failure = &junitFailure{Message: result.Message(), Type: result.Node}
+				suite.Failures++
+			}
+			for _, err := range result.Retries {
+				if result.Flaky() {
+					testCase.Flaky = append(testCase.Flaky, junitFailure{Message: err.Error()})
+				} else {
+					testCase.Reruns = append(testCase.Reruns, junitFailure{Message: err.Error()})
+				}
+			}
+			if result.Payload != nil {
+				data, err := json.Marshal(result.Payload)
+				if err != nil {
+					return err
+				}
+				testCase.SystemOut = string(data)
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+
+			if suiteStart.IsZero() || result.StartTime.Before(suiteStart) {
+				suiteStart = result.StartTime
+			}
+			if result.EndTime.After(suiteEnd) {
+				suiteEnd = result.EndTime
+			}
+		}
+
+		suite.Time = seconds(suiteEnd.Sub(suiteStart))
+		suite.Timestamp = suiteStart.UTC().Format(time.RFC3339)
+
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+
+		if start.IsZero() || suiteStart.Before(start) {
+			start = suiteStart
+		}
+		if suiteEnd.After(end) {
+			end = suiteEnd
+		}
+	}
+	report.Time = seconds(end.Sub(start))
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(r.writer, xml.Header); err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
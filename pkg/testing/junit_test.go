@@ -0,0 +1,90 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReporter_Flush(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewJUnitReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "default/foo", StartTime: now, EndTime: now.Add(time.Second)})
+	require.NoError(t, err)
+	err = reporter.Report(ctx, &Result{Name: "default/bar", Error: fmt.Errorf("error"), Node: "assert", Payload: "baz", StartTime: now, EndTime: now.Add(2 * time.Second)})
+	require.NoError(t, err)
+	err = reporter.Report(ctx, &Result{Name: "other/qux", StartTime: now, EndTime: now})
+	require.NoError(t, err)
+
+	require.Empty(t, output.String())
+
+	err = reporter.Flush()
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	err = xml.Unmarshal(output.Bytes(), &report)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Len(t, report.Suites, 2)
+
+	suite := report.Suites[0]
+	require.Equal(t, "default", suite.Name)
+	require.Equal(t, 2, suite.Tests)
+	require.Equal(t, "2.000", suite.Time)
+	require.Equal(t, "bar", suite.Cases[0].Name)
+	require.Equal(t, "error", suite.Cases[0].Failure.Message)
+	require.Equal(t, "assert", suite.Cases[0].Failure.Type)
+	require.Equal(t, `"baz"`, suite.Cases[0].SystemOut)
+	require.Nil(t, suite.Cases[1].Failure)
+
+	output.Reset()
+
+	err = reporter.Flush()
+	require.NoError(t, err)
+	require.Contains(t, output.String(), `<testsuites tests="0" failures="0"`)
+}
+
+func TestJUnitReporter_Flaky(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewJUnitReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "default/foo", Retries: []error{fmt.Errorf("error")}, StartTime: now, EndTime: now})
+	require.NoError(t, err)
+	err = reporter.Report(ctx, &Result{Name: "default/bar", Error: fmt.Errorf("error"), Retries: []error{fmt.Errorf("error")}, StartTime: now, EndTime: now})
+	require.NoError(t, err)
+
+	err = reporter.Flush()
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	err = xml.Unmarshal(output.Bytes(), &report)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, report.Failures)
+
+	cases := report.Suites[0].Cases
+	require.Equal(t, "bar", cases[0].Name)
+	require.Empty(t, cases[0].Flaky)
+	require.Len(t, cases[0].Reruns, 1)
+	require.Equal(t, "foo", cases[1].Name)
+	require.Nil(t, cases[1].Failure)
+	require.Equal(t, []junitFailure{{Message: "error"}}, cases[1].Flaky)
+}
@@ -13,6 +13,11 @@ type Reporter interface {
 	Report(ctx context.Context, result *Result) error
 }
 
+// Flusher is implemented by reporters that write their output once all results are reported.
+type Flusher interface {
+	Flush() error
+}
+
 // Reporters is a collection of Reporter instances.
 type Reporters []Reporter
 
@@ -28,6 +33,7 @@ type reporter struct {
 
 var (
 	_ Reporter = (Reporters)(nil)
+	_ Flusher  = (Reporters)(nil)
 	_ Reporter = (*ErrorReporter)(nil)
 	_ Reporter = (*reporter)(nil)
 )
@@ -61,6 +67,15 @@ func NewTextReporter(logOutput io.Writer) Reporter {
 				return err
 			}
 		}
+		if len(result.Retries) > 0 {
+			status := "failed"
+			if result.Flaky() {
+				status = "flaky: passed"
+			}
+			if _, err := fmt.Fprintf(logOutput, "    %s after %d retries\n", status, len(result.Retries)); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -75,6 +90,19 @@ func (r Reporters) Report(ctx context.Context, result *Result) error {
 	return nil
 }
 
+// Flush flushes all Reporter instances in the collection that implement Flusher.
+func (r Reporters) Flush() error {
+	var errs []error
+	for _, r := range r {
+		if f, ok := r.(Flusher); ok {
+			if err := f.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Report method appends the error to the collection.
 func (r *ErrorReporter) Report(_ context.Context, result *Result) error {
 	r.mu.Lock()
@@ -66,4 +66,18 @@ func TestTextReporter_Report(t *testing.T) {
 		require.NoError(t, err)
 		require.Contains(t, output.String(), "FAIL\tfoo")
 	})
+
+	t.Run("Flaky", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		output := &bytes.Buffer{}
+		reporter := NewTextReporter(output)
+		result := &Result{Name: "foo", Retries: []error{fmt.Errorf("error")}, StartTime: time.Now(), EndTime: time.Now()}
+
+		err := reporter.Report(ctx, result)
+		require.NoError(t, err)
+		require.Contains(t, output.String(), "PASS\tfoo")
+		require.Contains(t, output.String(), "flaky: passed after 1 retries")
+	})
 }
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -11,15 +12,27 @@ type Result struct {
 	ID        uuid.UUID
 	Name      string
 	Error     error
+	Node      string
+	Payload   any
+	Retries   []error
 	StartTime time.Time
 	EndTime   time.Time
 }
 
+// Failure is an error raised by a node that failed a test, carrying the payload it received.
+type Failure struct {
+	Node    string
+	Payload any
+	Err     error
+}
+
 const (
 	StatusPass = "PASS"
 	StatusFail = "FAIL"
 )
 
+var _ error = (*Failure)(nil)
+
 // Status returns the status of the test as a string.
 func (r *Result) Status() string {
 	if r.Error != nil {
@@ -28,7 +41,38 @@ func (r *Result) Status() string {
 	return StatusPass
 }
 
+// Flaky reports whether the test passed only after being retried.
+func (r *Result) Flaky() bool {
+	return r.Error == nil && len(r.Retries) > 0
+}
+
+// Suite returns the suite of the test, which is the namespace part of its name.
+func (r *Result) Suite() string {
+	return scopeOf(r.Name)
+}
+
+// Message returns the failure message of the test, or an empty string if it passed.
+func (r *Result) Message() string {
+	if r.Error == nil {
+		return ""
+	}
+	return r.Error.Error()
+}
+
 // Duration calculates the duration of the test.
 func (r *Result) Duration() time.Duration {
 	return r.EndTime.Sub(r.StartTime)
 }
+
+// Error returns the message of the underlying error prefixed with the failing node.
+func (f *Failure) Error() string {
+	if f.Node == "" {
+		return f.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", f.Node, f.Err)
+}
+
+// Unwrap returns the underlying error.
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
@@ -45,3 +45,27 @@ func TestResult_Duration(t *testing.T) {
 	}
 	require.Equal(t, 2*time.Second, result.Duration())
 }
+
+func TestResult_Suite(t *testing.T) {
+	require.Equal(t, "default", (&Result{Name: "default/foo"}).Suite())
+	require.Equal(t, "", (&Result{Name: "foo"}).Suite())
+}
+
+func TestResult_Message(t *testing.T) {
+	require.Equal(t, "", (&Result{}).Message())
+	require.Equal(t, "test error", (&Result{Error: fmt.Errorf("test error")}).Message())
+}
+
+func TestFailure_Error(t *testing.T) {
+	cause := fmt.Errorf("test error")
+
+	failure := &Failure{Node: "assert", Err: cause}
+	require.Equal(t, "assert: test error", failure.Error())
+	require.ErrorIs(t, failure, cause)
+}
+
+func TestResult_Flaky(t *testing.T) {
+	require.False(t, (&Result{}).Flaky())
+	require.True(t, (&Result{Retries: []error{fmt.Errorf("error")}}).Flaky())
+	require.False(t, (&Result{Error: fmt.Errorf("error"), Retries: []error{fmt.Errorf("error")}}).Flaky())
+}
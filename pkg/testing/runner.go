@@ -2,7 +2,11 @@ package testing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -13,12 +17,27 @@ import (
 type Runner struct {
 	reporters Reporters
 	suites    map[string]Suite
+	hooks     map[Phase]map[string]Suite
 	mu        sync.RWMutex
 }
 
+// RunOptions configures a run of the test suites.
+type RunOptions struct {
+	Concurrency  int           // Concurrency limits the number of suites running at once; zero means no limit.
+	Count        int           // Count is the number of times each suite runs; zero means once.
+	Retries      int           // Retries is the number of times a failing suite is run again before it's reported as failed.
+	Timeout      time.Duration // Timeout limits the duration of the whole run; zero means no limit.
+	SuiteTimeout time.Duration // SuiteTimeout limits the duration of each suite run; zero means no limit.
+}
+
+var ErrTimeout = errors.New("timed out")
+
 // NewRunner creates a new Runner instance.
 func NewRunner() *Runner {
-	return &Runner{suites: make(map[string]Suite)}
+	return &Runner{
+		suites: make(map[string]Suite),
+		hooks:  make(map[Phase]map[string]Suite),
+	}
 }
 
 // AddReporter adds a reporter if it's not already present.
@@ -73,57 +92,238 @@ func (r *Runner) Unregister(name string) bool {
 	return true
 }
 
-// Run executes all test suites matching the filter concurrently.
-func (r *Runner) Run(ctx context.Context, match func(string) bool) error {
+// AddHook registers a hook to run at the given phase if it's not already registered.
+// A hook named with a namespace, such as "default/setup", only applies to the suites of that namespace.
+func (r *Runner) AddHook(phase Phase, name string, hook Suite) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hooks, ok := r.hooks[phase]
+	if !ok {
+		hooks = make(map[string]Suite)
+		r.hooks[phase] = hooks
+	}
+	if _, exists := hooks[name]; exists {
+		return false
+	}
+	hooks[name] = hook
+	return true
+}
+
+// RemoveHook removes a hook registered at the given phase.
+func (r *Runner) RemoveHook(phase Phase, name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.hooks[phase][name]; !exists {
+		return false
+	}
+	delete(r.hooks[phase], name)
+	return true
+}
+
+// Suites returns the names of the registered test suites in order.
+func (r *Runner) Suites() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	names := make([]string, 0, len(r.suites))
+	for name := range r.suites {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Run executes all test suites matching the filter concurrently and flushes the reporters once they finish.
+// Before-all hooks run first, and a failing one fails every suite; after-all hooks run once the suites finish.
+// A suite that fails is run again up to the number of retries, and is reported as flaky if a retry passes.
+func (r *Runner) Run(ctx context.Context, match func(string) bool, opts ...RunOptions) error {
 	if match == nil {
 		match = func(string) bool { return true }
 	}
 
-	errorReporter := NewErrorReporter()
-	reporters := append(r.reporters, errorReporter)
-	g, ctx := errgroup.WithContext(ctx)
+	var opt RunOptions
+	for _, o := range opts {
+		if o.Concurrency > 0 {
+			opt.Concurrency = o.Concurrency
+		}
+		if o.Count > 0 {
+			opt.Count = o.Count
+		}
+		if o.Retries > 0 {
+			opt.Retries = o.Retries
+		}
+		if o.Timeout > 0 {
+			opt.Timeout = o.Timeout
+		}
+		if o.SuiteTimeout > 0 {
+			opt.SuiteTimeout = o.SuiteTimeout
+		}
+	}
+	count := max(opt.Count, 1)
 
+	r.mu.RLock()
+	suites := make(map[string]Suite)
+	var scopes []string
 	for name, suite := range r.suites {
 		if match(name) {
-			g.Go(func() error {
-				tester := NewTester(name)
-
-				errs := make(chan error)
-				defer close(errs)
-
-				tester.AddExitHook(process.ExitFunc(func(err error) {
-					errs <- reporters.Report(ctx, &Result{
-						ID:        tester.ID(),
-						Name:      tester.Name(),
-						Error:     err,
-						StartTime: tester.StartTime(),
-						EndTime:   tester.EndTime(),
-					})
-				}))
-
-				go func() {
-					select {
-					case <-ctx.Done():
-						tester.Exit(ctx.Err())
-					case <-tester.Done():
-					}
-				}()
+			suites[name] = suite
+			scopes = append(scopes, scopeOf(name))
+		}
+	}
+	reporters := append(Reporters(nil), r.reporters...)
+	r.mu.RUnlock()
 
-				go func() {
-					suite.Run(tester)
-					tester.Exit(nil)
-				}()
+	errorReporter := NewErrorReporter()
+	reporters = append(reporters, errorReporter)
 
-				return <-errs
-			})
-		}
+	rctx := ctx
+	if opt.Timeout > 0 {
+		var cancel context.CancelFunc
+		rctx, cancel = context.WithTimeoutCause(ctx, opt.Timeout, fmt.Errorf("%w after %v", ErrTimeout, opt.Timeout))
+		defer cancel()
+	}
+
+	setup := r.runHooks(rctx, nil, BeforeAll, scopes...)
+
+	g, gctx := errgroup.WithContext(rctx)
+	if opt.Concurrency > 0 {
+		g.SetLimit(opt.Concurrency)
 	}
 
-	if err := g.Wait(); err != nil {
+	for name, suite := range suites {
+		g.Go(func() error {
+			for range count {
+				var retries []error
+				for {
+					result := r.attempt(gctx, name, suite, setup, opt.SuiteTimeout)
+					if result.Error == nil || setup != nil || len(retries) >= opt.Retries || gctx.Err() != nil {
+						result.Retries = retries
+						if err := reporters.Report(gctx, result); err != nil {
+							return err
+						}
+						break
+					}
+					retries = append(retries, result.Error)
+				}
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	teardown := r.runHooks(ctx, nil, AfterAll, scopes...)
+
+	if err := errors.Join(err, teardown, reporters.Flush()); err != nil {
 		return err
 	}
 	return errorReporter.Error()
 }
+
+func (r *Runner) attempt(ctx context.Context, name string, suite Suite, setup error, timeout time.Duration) *Result {
+	tester := NewTester(name)
+
+	results := make(chan *Result, 1)
+	tester.AddExitHook(process.ExitFunc(func(err error) {
+		result := &Result{
+			ID:        tester.ID(),
+			Name:      tester.Name(),
+			Error:     err,
+			StartTime: tester.StartTime(),
+			EndTime:   tester.EndTime(),
+		}
+
+		var failure *Failure
+		if errors.As(err, &failure) {
+			result.Node = failure.Node
+			result.Payload = failure.Payload
+		}
+
+		results <- result
+	}))
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			tester.Exit(context.Cause(ctx))
+		case <-tester.Done():
+		}
+	}()
+
+	go func() {
+		if setup != nil {
+			tester.Exit(setup)
+			return
+		}
+		tester.Exit(r.runSuite(ctx, tester, suite, timeout))
+	}()
+
+	return <-results
+}
+
+func (r *Runner) runSuite(ctx context.Context, tester *Tester, suite Suite, timeout time.Duration) error {
+	scope := scopeOf(tester.Name())
+	if err := r.runHooks(ctx, tester, BeforeEach, scope); err != nil {
+		return err
+	}
+
+	sctx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		sctx, cancel = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w after %v", ErrTimeout, timeout))
+		defer cancel()
+	}
+
+	err := execute(sctx, tester.Fork(tester.Name()), suite)
+	return errors.Join(err, r.runHooks(ctx, tester, AfterEach, scope))
+}
+
+func (r *Runner) runHooks(ctx context.Context, parent *Tester, phase Phase, scopes ...string) error {
+	r.mu.RLock()
+	var names []string
+	for name := range r.hooks[phase] {
+		if scope := scopeOf(name); scope == "" || slices.Contains(scopes, scope) {
+			names = append(names, name)
+		}
+	}
+	hooks := make([]Suite, 0, len(names))
+	slices.Sort(names)
+	for _, name := range names {
+		hooks = append(hooks, r.hooks[phase][name])
+	}
+	r.mu.RUnlock()
+
+	for i, hook := range hooks {
+		tester := NewTester(names[i])
+		if parent != nil {
+			tester = parent.Fork(names[i])
+		}
+		if err := execute(ctx, tester, hook); err != nil {
+			return fmt.Errorf("%s %s: %w", phase, names[i], err)
+		}
+	}
+	return nil
+}
+
+func execute(ctx context.Context, tester *Tester, suite Suite) error {
+	errs := make(chan error, 1)
+	tester.AddExitHook(process.ExitFunc(func(err error) {
+		errs <- err
+	}))
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			tester.Exit(context.Cause(ctx))
+		case <-tester.Done():
+		}
+	}()
+
+	go func() {
+		suite.Run(tester)
+		tester.Exit(nil)
+	}()
+
+	return <-errs
+}
@@ -1,11 +1,15 @@
 package testing
 
 import (
+	"bytes"
 	"context"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-faker/faker/v4"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,22 +65,328 @@ func TestRunner_Unregister(t *testing.T) {
 	require.False(t, ok)
 }
 
-func TestRunner_Run(t *testing.T) {
-	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
-	defer cancel()
+func TestRunner_AddHook(t *testing.T) {
+	runner := NewRunner()
+	h := RunFunc(func(tester *Tester) {})
+
+	ok := runner.AddHook(BeforeEach, "foo", h)
+	require.True(t, ok)
 
+	ok = runner.AddHook(BeforeEach, "foo", h)
+	require.False(t, ok)
+
+	ok = runner.AddHook(AfterEach, "foo", h)
+	require.True(t, ok)
+}
+
+func TestRunner_RemoveHook(t *testing.T) {
 	runner := NewRunner()
+	h := RunFunc(func(tester *Tester) {})
 
-	var count atomic.Int32
-	runner.AddReporter(ReportFunc(func(_ context.Context, _ *Result) error {
-		count.Add(1)
-		return nil
-	}))
+	runner.AddHook(BeforeEach, "foo", h)
+
+	ok := runner.RemoveHook(BeforeEach, "foo")
+	require.True(t, ok)
+
+	ok = runner.RemoveHook(BeforeEach, "foo")
+	require.False(t, ok)
+}
+
+func TestRunner_Suites(t *testing.T) {
+	runner := NewRunner()
+	s := RunFunc(func(tester *Tester) {})
+
+	runner.Register("foo", s)
+	runner.Register("bar", s)
+
+	require.Equal(t, []string{"bar", "foo"}, runner.Suites())
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Run("Report", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var count atomic.Int32
+		runner.AddReporter(ReportFunc(func(_ context.Context, _ *Result) error {
+			count.Add(1)
+			return nil
+		}))
+
+		runner.Register("foo", RunFunc(func(tester *Tester) {}))
+		runner.Register("bar", RunFunc(func(tester *Tester) {}))
+
+		err := runner.Run(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), count.Load())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		results := make(chan *Result, 1)
+		runner.AddReporter(ReportFunc(func(_ context.Context, result *Result) error {
+			results <- result
+			return nil
+		}))
+
+		cause := errors.New(faker.Sentence())
+		runner.Register("foo", RunFunc(func(tester *Tester) {
+			tester.Exit(errors.WithStack(&Failure{Node: "assert", Payload: "bar", Err: cause}))
+		}))
+
+		err := runner.Run(ctx, nil)
+		require.ErrorIs(t, err, cause)
+
+		result := <-results
+		require.Equal(t, StatusFail, result.Status())
+		require.Equal(t, "assert", result.Node)
+		require.Equal(t, "bar", result.Payload)
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		output := &bytes.Buffer{}
+		runner.AddReporter(NewTAPReporter(output))
+
+		runner.Register("foo", RunFunc(func(tester *Tester) {}))
+
+		err := runner.Run(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, "TAP version 13\nok 1 - foo\n1..1\n", output.String())
+	})
+
+	t.Run("Hook", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var mu sync.Mutex
+		var calls []string
+		record := func(name string) Suite {
+			return RunFunc(func(tester *Tester) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls = append(calls, name)
+			})
+		}
+
+		runner.AddHook(BeforeAll, "default/before-all", record("before-all"))
+		runner.AddHook(BeforeEach, "default/before-each", record("before-each"))
+		runner.AddHook(AfterEach, "default/after-each", record("after-each"))
+		runner.AddHook(AfterAll, "default/after-all", record("after-all"))
+		runner.AddHook(BeforeEach, "other/before-each", record("other"))
+
+		runner.Register("default/foo", record("foo"))
+
+		err := runner.Run(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"before-all", "before-each", "foo", "after-each", "after-all"}, calls)
+	})
+
+	t.Run("HookProcess", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
 
-	runner.Register("foo", RunFunc(func(tester *Tester) {}))
-	runner.Register("bar", RunFunc(func(tester *Tester) {}))
+		runner := NewRunner()
 
-	err := runner.Run(ctx, nil)
-	require.NoError(t, err)
-	require.Equal(t, int32(2), count.Load())
+		key := faker.UUIDHyphenated()
+		value := faker.Word()
+
+		runner.AddHook(BeforeEach, "setup", RunFunc(func(tester *Tester) {
+			tester.Process().Parent().SetValue(key, value)
+		}))
+
+		values := make(chan any, 1)
+		runner.Register("foo", RunFunc(func(tester *Tester) {
+			values <- tester.Process().Value(key)
+		}))
+
+		err := runner.Run(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, value, <-values)
+	})
+
+	t.Run("BeforeAllFailure", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var count atomic.Int32
+		runner.AddReporter(ReportFunc(func(_ context.Context, result *Result) error {
+			count.Add(1)
+			return nil
+		}))
+
+		cause := errors.New(faker.Sentence())
+		runner.AddHook(BeforeAll, "setup", RunFunc(func(tester *Tester) {
+			tester.Exit(cause)
+		}))
+
+		var ran atomic.Bool
+		runner.Register("foo", RunFunc(func(tester *Tester) {
+			ran.Store(true)
+		}))
+
+		err := runner.Run(ctx, nil)
+		require.ErrorIs(t, err, cause)
+		require.False(t, ran.Load())
+		require.Equal(t, int32(1), count.Load())
+	})
+
+	t.Run("AfterEachFailure", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		cause := errors.New(faker.Sentence())
+		runner.AddHook(AfterEach, "teardown", RunFunc(func(tester *Tester) {
+			tester.Exit(cause)
+		}))
+
+		runner.Register("foo", RunFunc(func(tester *Tester) {}))
+
+		err := runner.Run(ctx, nil)
+		require.ErrorIs(t, err, cause)
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var running, peak atomic.Int32
+		for _, name := range []string{"foo", "bar", "baz"} {
+			runner.Register(name, RunFunc(func(tester *Tester) {
+				n := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}))
+		}
+
+		err := runner.Run(ctx, nil, RunOptions{Concurrency: 1})
+		require.NoError(t, err)
+		require.Equal(t, int32(1), peak.Load())
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var count atomic.Int32
+		runner.Register(faker.Word(), RunFunc(func(_ *Tester) {
+			count.Add(1)
+		}))
+
+		var results atomic.Int32
+		runner.AddReporter(ReportFunc(func(_ context.Context, _ *Result) error {
+			results.Add(1)
+			return nil
+		}))
+
+		err := runner.Run(ctx, nil, RunOptions{Count: 3})
+		require.NoError(t, err)
+		require.Equal(t, int32(3), count.Load())
+		require.Equal(t, int32(3), results.Load())
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var count atomic.Int32
+		runner.Register(faker.Word(), RunFunc(func(tester *Tester) {
+			if count.Add(1) < 3 {
+				tester.Exit(errors.New(faker.Sentence()))
+			}
+		}))
+
+		results := make(chan *Result, 1)
+		runner.AddReporter(ReportFunc(func(_ context.Context, result *Result) error {
+			results <- result
+			return nil
+		}))
+
+		err := runner.Run(ctx, nil, RunOptions{Retries: 2})
+		require.NoError(t, err)
+
+		result := <-results
+		require.True(t, result.Flaky())
+		require.Len(t, result.Retries, 2)
+	})
+
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+
+		var count atomic.Int32
+		runner.Register(faker.Word(), RunFunc(func(tester *Tester) {
+			count.Add(1)
+			tester.Exit(errors.New(faker.Sentence()))
+		}))
+
+		err := runner.Run(ctx, nil, RunOptions{Retries: 2})
+		require.Error(t, err)
+		require.Equal(t, int32(3), count.Load())
+	})
+
+	t.Run("SuiteTimeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+		runner.Register(faker.Word(), RunFunc(func(tester *Tester) {
+			<-tester.Done()
+		}))
+
+		var teardown atomic.Bool
+		runner.AddHook(AfterEach, faker.Word(), RunFunc(func(_ *Tester) {
+			teardown.Store(true)
+		}))
+
+		err := runner.Run(ctx, nil, RunOptions{SuiteTimeout: 10 * time.Millisecond})
+		require.ErrorIs(t, err, ErrTimeout)
+		require.True(t, teardown.Load())
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		runner := NewRunner()
+		for _, name := range []string{"foo", "bar"} {
+			runner.Register(name, RunFunc(func(tester *Tester) {
+				<-tester.Done()
+			}))
+		}
+
+		err := runner.Run(ctx, nil, RunOptions{Timeout: 10 * time.Millisecond, Retries: 1})
+		require.ErrorIs(t, err, ErrTimeout)
+	})
 }
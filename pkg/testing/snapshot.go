@@ -0,0 +1,153 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	fmt2 "github.com/siyul-park/uniflow/internal/fmt"
+)
+
+// Snapshot compares payloads with golden files, or rewrites the files when updating.
+type Snapshot struct {
+	fs     afero.Fs
+	dir    string
+	update bool
+	mu     sync.RWMutex
+}
+
+// Normalized is the placeholder that replaces volatile fields of a snapshot.
+const Normalized = "[normalized]"
+
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrSnapshotMismatch = errors.New("snapshot mismatch")
+)
+
+// NewSnapshot creates a new Snapshot reading golden files from the file system, or from the OS if it's nil.
+func NewSnapshot(fs afero.Fs) *Snapshot {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &Snapshot{fs: fs}
+}
+
+// SetDir sets the directory against which relative golden file names are resolved.
+func (s *Snapshot) SetDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dir = dir
+}
+
+// SetUpdate sets whether golden files are rewritten with the payloads instead of compared.
+func (s *Snapshot) SetUpdate(update bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update = update
+}
+
+// Match serializes the payload as indented JSON and compares it with the golden file, reporting the
+// differences as a diff. Fields selected by normalize are replaced by Normalized beforehand; a JSONPath
+// such as $.items[*].id selects fields by path and a plain key such as id selects them at any depth.
+func (s *Snapshot) Match(filename string, payload any, normalize ...string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	doc, err := s.normalize(payload, normalize)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	printer, err := fmt2.NewPrinter(&buf, fmt2.FormatJSON)
+	if err != nil {
+		return err
+	}
+	if err := printer.Write(doc); err != nil {
+		return err
+	}
+
+	path := filename
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.dir, path)
+	}
+
+	if s.update {
+		if err := s.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return afero.WriteFile(s.fs, path, buf.Bytes(), 0644)
+	}
+
+	data, err := afero.ReadFile(s.fs, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, filename)
+	} else if err != nil {
+		return err
+	}
+
+	var expected any
+	if err := json.Unmarshal(data, &expected); err != nil {
+		return err
+	}
+
+	var diff strings.Builder
+	if changed, err := fmt2.Diff(&diff, expected, doc, filename, "payload"); err != nil {
+		return err
+	} else if changed {
+		return fmt.Errorf("%w: %s\n%s", ErrSnapshotMismatch, filename, strings.TrimSuffix(diff.String(), "\n"))
+	}
+	return nil
+}
+
+func (s *Snapshot) normalize(payload any, normalize []string) (any, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	for _, field := range normalize {
+		if strings.HasPrefix(field, "$") || strings.HasPrefix(field, "{") {
+			path, err := fmt2.CompileJSONPath(field)
+			if err != nil {
+				return nil, err
+			}
+			doc = path.Set(doc, Normalized)
+		} else {
+			doc = replace(doc, field)
+		}
+	}
+	return doc, nil
+}
+
+func replace(val any, key string) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if k == key {
+				v[k] = Normalized
+			} else {
+				v[k] = replace(child, key)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = replace(child, key)
+		}
+	}
+	return val
+}
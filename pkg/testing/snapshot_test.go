@@ -0,0 +1,85 @@
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_Match(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		s := NewSnapshot(fs)
+		s.SetDir("tests")
+		s.SetUpdate(true)
+
+		err := s.Match(filepath.Join("__snapshots__", "foo.json"), map[string]any{"name": "foo"})
+		require.NoError(t, err)
+
+		data, err := afero.ReadFile(fs, filepath.Join("tests", "__snapshots__", "foo.json"))
+		require.NoError(t, err)
+		require.Equal(t, "{\n  \"name\": \"foo\"\n}\n", string(data))
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := afero.WriteFile(fs, "foo.json", []byte(`{"name":"foo"}`), 0644)
+		require.NoError(t, err)
+
+		s := NewSnapshot(fs)
+
+		err = s.Match("foo.json", map[string]any{"name": "foo"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		err := afero.WriteFile(fs, "foo.json", []byte(`{"name":"foo"}`), 0644)
+		require.NoError(t, err)
+
+		s := NewSnapshot(fs)
+
+		err = s.Match("foo.json", map[string]any{"name": "bar"})
+		require.ErrorIs(t, err, ErrSnapshotMismatch)
+		require.Contains(t, err.Error(), "-name: \"foo\"")
+		require.Contains(t, err.Error(), "+name: \"bar\"")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		s := NewSnapshot(afero.NewMemMapFs())
+
+		err := s.Match("foo.json", faker.Word())
+		require.ErrorIs(t, err, ErrSnapshotNotFound)
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		s := NewSnapshot(fs)
+		s.SetUpdate(true)
+
+		payload := map[string]any{
+			"id":    faker.UUIDHyphenated(),
+			"items": []any{map[string]any{"id": faker.UUIDHyphenated(), "created_at": faker.Timestamp()}},
+		}
+
+		err := s.Match("foo.json", payload, "id", "$.items[*].created_at")
+		require.NoError(t, err)
+
+		s.SetUpdate(false)
+
+		payload = map[string]any{
+			"id":    faker.UUIDHyphenated(),
+			"items": []any{map[string]any{"id": faker.UUIDHyphenated(), "created_at": faker.Timestamp()}},
+		}
+
+		err = s.Match("foo.json", payload, "id", "$.items[*].created_at")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,106 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TAPReporter writes test results in the Test Anything Protocol, emitting the plan when flushed.
+type TAPReporter struct {
+	writer io.Writer
+	count  int
+	mu     sync.Mutex
+}
+
+type tapDiagnostic struct {
+	Message  string   `yaml:"message,omitempty"`
+	Node     string   `yaml:"node,omitempty"`
+	Payload  any      `yaml:"payload,omitempty"`
+	Flaky    bool     `yaml:"flaky,omitempty"`
+	Retries  []string `yaml:"retries,omitempty"`
+	Duration float64  `yaml:"duration_ms"`
+}
+
+var (
+	_ Reporter = (*TAPReporter)(nil)
+	_ Flusher  = (*TAPReporter)(nil)
+)
+
+// NewTAPReporter creates a new TAPReporter that writes test results to the provided io.Writer.
+func NewTAPReporter(w io.Writer) *TAPReporter {
+	if w == nil {
+		w = io.Discard
+	}
+	return &TAPReporter{writer: w}
+}
+
+// Report writes a test point for the result, followed by a YAML diagnostic block if it failed or was retried.
+func (r *TAPReporter) Report(_ context.Context, result *Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.header(); err != nil {
+		return err
+	}
+	r.count++
+
+	status := "ok"
+	if result.Error != nil {
+		status = "not ok"
+	}
+	if _, err := fmt.Fprintf(r.writer, "%s %d - %s\n", status, r.count, result.Name); err != nil {
+		return err
+	}
+	if result.Error == nil && !result.Flaky() {
+		return nil
+	}
+
+	data, err := yaml.Marshal(tapDiagnostic{
+		Message:  result.Message(),
+		Node:     result.Node,
+		Payload:  result.Payload,
+		Flaky:    result.Flaky(),
+		Retries:  messages(result.Retries),
+		Duration: float64(result.Duration().Microseconds()) / 1000,
+	})
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+
+	_, err = fmt.Fprintf(r.writer, "  ---\n%s\n  ...\n", strings.Join(lines, "\n"))
+	return err
+}
+
+// Flush writes the plan for the reported test points and resets the reporter.
+func (r *TAPReporter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.header(); err != nil {
+		return err
+	}
+
+	count := r.count
+	r.count = 0
+
+	_, err := fmt.Fprintf(r.writer, "1..%d\n", count)
+	return err
+}
+
+func (r *TAPReporter) header() error {
+	if r.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(r.writer, "TAP version 13\n")
+	return err
+}
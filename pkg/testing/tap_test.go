@@ -0,0 +1,71 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPReporter_Report(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewTAPReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "foo", StartTime: now, EndTime: now})
+	require.NoError(t, err)
+	err = reporter.Report(ctx, &Result{Name: "bar", Error: fmt.Errorf("error"), Node: "assert", Payload: "baz", StartTime: now, EndTime: now.Add(time.Millisecond)})
+	require.NoError(t, err)
+
+	err = reporter.Flush()
+	require.NoError(t, err)
+
+	require.Equal(t, "TAP version 13\n"+
+		"ok 1 - foo\n"+
+		"not ok 2 - bar\n"+
+		"  ---\n"+
+		"  message: error\n"+
+		"  node: assert\n"+
+		"  payload: baz\n"+
+		"  duration_ms: 1\n"+
+		"  ...\n"+
+		"1..2\n", output.String())
+}
+
+func TestTAPReporter_Flaky(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	output := &bytes.Buffer{}
+	reporter := NewTAPReporter(output)
+
+	now := time.Now()
+
+	err := reporter.Report(ctx, &Result{Name: "foo", Retries: []error{fmt.Errorf("error")}, StartTime: now, EndTime: now})
+	require.NoError(t, err)
+
+	require.Equal(t, "TAP version 13\n"+
+		"ok 1 - foo\n"+
+		"  ---\n"+
+		"  flaky: true\n"+
+		"  retries:\n"+
+		"      - error\n"+
+		"  duration_ms: 0\n"+
+		"  ...\n", output.String())
+}
+
+func TestTAPReporter_Flush(t *testing.T) {
+	output := &bytes.Buffer{}
+	reporter := NewTAPReporter(output)
+
+	err := reporter.Flush()
+	require.NoError(t, err)
+	require.Equal(t, "TAP version 13\n1..0\n", output.String())
+}
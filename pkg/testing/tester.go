@@ -63,3 +63,8 @@ func (t *Tester) Exit(err error) {
 func (t *Tester) AddExitHook(hook process.ExitHook) bool {
 	return t.proc.AddExitHook(hook)
 }
+
+// Fork creates a Tester with the given name running in a child process of the tester.
+func (t *Tester) Fork(name string) *Tester {
+	return &Tester{name: name, proc: t.proc.Fork()}
+}
@@ -59,3 +59,12 @@ func TestTester_AddExitHook(t *testing.T) {
 	hook := process.ExitFunc(func(err error) {})
 	require.True(t, tester.AddExitHook(hook))
 }
+
+func TestTester_Fork(t *testing.T) {
+	tester := NewTester("foo")
+	defer tester.Exit(nil)
+
+	child := tester.Fork("bar")
+	require.Equal(t, "bar", child.Name())
+	require.Equal(t, tester.Process(), child.Process().Parent())
+}
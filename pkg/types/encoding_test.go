@@ -153,6 +153,23 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestMarshal_Recursive(t *testing.T) {
+	type node struct {
+		Name     string  `json:"name"`
+		Children []*node `json:"children,omitempty"`
+	}
+
+	source := &node{Name: "root", Children: []*node{{Name: "child"}}}
+
+	encoded, err := Marshal(source)
+	require.NoError(t, err)
+
+	var decoded *node
+	err = Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, source, decoded)
+}
+
 func TestShortcut_Encode(t *testing.T) {
 	enc := encoding.NewEncodeAssembler[any, Value]()
 	enc.Add(newShortcutEncoder())
@@ -51,6 +51,12 @@ func TestMap_Set(t *testing.T) {
 
 	r := o.Get(k1)
 	require.Equal(t, v1, r)
+
+	v2 := NewString(faker.UUIDHyphenated())
+
+	n := o.Set(k1, v2)
+	require.Equal(t, v2, n.Get(k1))
+	require.Equal(t, v1, o.Get(k1))
 }
 
 func TestMap_Delete(t *testing.T) {
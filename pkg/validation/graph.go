@@ -0,0 +1,204 @@
+package validation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+// Graph is a directed graph of specs connected through their port references.
+type Graph struct {
+	nodes     []spec.Spec
+	edges     []Edge
+	outbounds map[int][]int
+	inbounds  map[int][]int
+	indexes   map[spec.Spec]int
+}
+
+// Edge connects an output port of a source spec to an input port of a target spec.
+type Edge struct {
+	Source spec.Spec // Source is the spec owning the output port.
+	Out    string    // Out is the name of the output port.
+	Target spec.Spec // Target is the referenced spec, or nil if the reference cannot be resolved.
+	Port   spec.Port // Port is the reference as declared in the source spec.
+}
+
+// NewGraph builds a Graph from the port references of the given specs.
+func NewGraph(specs ...spec.Spec) *Graph {
+	g := &Graph{
+		nodes:     specs,
+		outbounds: make(map[int][]int),
+		inbounds:  make(map[int][]int),
+		indexes:   make(map[spec.Spec]int, len(specs)),
+	}
+
+	ids := make(map[uuid.UUID]int)
+	names := make(map[string]map[string]int)
+	for i, sp := range specs {
+		g.indexes[sp] = i
+		if sp.GetID() != uuid.Nil {
+			ids[sp.GetID()] = i
+		}
+		if sp.GetName() != "" {
+			ns, ok := names[sp.GetNamespace()]
+			if !ok {
+				ns = make(map[string]int)
+				names[sp.GetNamespace()] = ns
+			}
+			if _, ok := ns[sp.GetName()]; !ok {
+				ns[sp.GetName()] = i
+			}
+		}
+	}
+
+	for i, sp := range specs {
+		ports := sp.GetPorts()
+		for _, name := range slices.Sorted(maps.Keys(ports)) {
+			for _, port := range ports[name] {
+				j, ok := -1, false
+				if port.ID != uuid.Nil {
+					j, ok = ids[port.ID]
+				} else if port.Name != "" {
+					j, ok = names[sp.GetNamespace()][port.Name]
+				}
+				if ok && specs[j].GetNamespace() != sp.GetNamespace() {
+					ok = false
+				}
+
+				edge := Edge{Source: sp, Out: name, Port: port}
+				if ok {
+					edge.Target = specs[j]
+					g.outbounds[i] = append(g.outbounds[i], len(g.edges))
+					g.inbounds[j] = append(g.inbounds[j], len(g.edges))
+				}
+				g.edges = append(g.edges, edge)
+			}
+		}
+	}
+
+	return g
+}
+
+// Nodes returns the specs in the graph.
+func (g *Graph) Nodes() []spec.Spec {
+	return append([]spec.Spec(nil), g.nodes...)
+}
+
+// Edges returns all port references, including unresolved ones.
+func (g *Graph) Edges() []Edge {
+	return append([]Edge(nil), g.edges...)
+}
+
+// Outbounds returns the resolved edges leaving the spec.
+func (g *Graph) Outbounds(sp spec.Spec) []Edge {
+	return g.collect(g.outbounds[g.index(sp)])
+}
+
+// Inbounds returns the resolved edges entering the spec.
+func (g *Graph) Inbounds(sp spec.Spec) []Edge {
+	return g.collect(g.inbounds[g.index(sp)])
+}
+
+// Cycles returns the groups of specs that reach each other through port references.
+func (g *Graph) Cycles() [][]spec.Spec {
+	index := 0
+	indexes := make(map[int]int)
+	lows := make(map[int]int)
+	onStack := make(map[int]bool)
+	var stack []int
+	var cycles [][]spec.Spec
+
+	var connect func(v int)
+	connect = func(v int) {
+		indexes[v] = index
+		lows[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		loop := false
+		for _, e := range g.outbounds[v] {
+			w := g.indexes[g.edges[e].Target]
+			if w == v {
+				loop = true
+			}
+			if _, ok := indexes[w]; !ok {
+				connect(w)
+				lows[v] = min(lows[v], lows[w])
+			} else if onStack[w] {
+				lows[v] = min(lows[v], indexes[w])
+			}
+		}
+
+		if lows[v] == indexes[v] {
+			var component []spec.Spec
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append([]spec.Spec{g.nodes[w]}, component...)
+				if w == v {
+					break
+				}
+			}
+			if len(component) > 1 || loop {
+				cycles = append(cycles, component)
+			}
+		}
+	}
+
+	for v := range g.nodes {
+		if _, ok := indexes[v]; !ok {
+			connect(v)
+		}
+	}
+	return cycles
+}
+
+// Unreachable returns the specs that cannot be reached from any spec without inbound references.
+func (g *Graph) Unreachable() []spec.Spec {
+	visited := make(map[int]bool)
+	var queue []int
+	for v := range g.nodes {
+		if len(g.inbounds[v]) == 0 {
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+		for _, e := range g.outbounds[v] {
+			queue = append(queue, g.indexes[g.edges[e].Target])
+		}
+	}
+
+	var unreachable []spec.Spec
+	for v, sp := range g.nodes {
+		if !visited[v] {
+			unreachable = append(unreachable, sp)
+		}
+	}
+	return unreachable
+}
+
+func (g *Graph) index(sp spec.Spec) int {
+	if i, ok := g.indexes[sp]; ok {
+		return i
+	}
+	return -1
+}
+
+func (g *Graph) collect(indexes []int) []Edge {
+	edges := make([]Edge, 0, len(indexes))
+	for _, i := range indexes {
+		edges = append(edges, g.edges[i])
+	}
+	return edges
+}
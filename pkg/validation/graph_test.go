@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestNewGraph(t *testing.T) {
+	sp1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+	}
+	sp2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+		Ports: map[string][]spec.Port{
+			node.PortOut:   {{Name: sp1.GetName(), Port: node.PortIn}},
+			node.PortError: {{ID: uuid.Must(uuid.NewV7()), Port: node.PortIn}},
+		},
+	}
+
+	g := NewGraph(sp1, sp2)
+
+	require.Equal(t, []spec.Spec{sp1, sp2}, g.Nodes())
+	require.Len(t, g.Edges(), 2)
+	require.Len(t, g.Outbounds(sp2), 1)
+	require.Len(t, g.Inbounds(sp1), 1)
+	require.Equal(t, sp1, g.Inbounds(sp1)[0].Target)
+	require.Empty(t, g.Outbounds(sp1))
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	sp1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp3 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp1.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp2.GetID(), Port: node.PortIn}}}
+	sp2.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp1.GetID(), Port: node.PortIn}}}
+	sp3.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp3.GetID(), Port: node.PortIn}}}
+
+	g := NewGraph(sp1, sp2, sp3)
+
+	cycles := g.Cycles()
+	require.Len(t, cycles, 2)
+	require.ElementsMatch(t, []spec.Spec{sp1, sp2}, cycles[0])
+	require.Equal(t, []spec.Spec{sp3}, cycles[1])
+}
+
+func TestGraph_Unreachable(t *testing.T) {
+	sp1 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp2 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp3 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp4 := &spec.Meta{
+		ID:        uuid.Must(uuid.NewV7()),
+		Kind:      faker.UUIDHyphenated(),
+		Namespace: meta.DefaultNamespace,
+	}
+	sp1.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp2.GetID(), Port: node.PortIn}}}
+	sp3.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp4.GetID(), Port: node.PortIn}}}
+	sp4.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp3.GetID(), Port: node.PortIn}}}
+
+	g := NewGraph(sp1, sp2, sp3, sp4)
+
+	require.Equal(t, []spec.Spec{sp3, sp4}, g.Unreachable())
+}
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+// Severity indicates how serious an Issue is.
+type Severity string
+
+// Issue describes a problem found in a spec.
+type Issue struct {
+	Spec     spec.Spec // Spec is the spec the issue was found in.
+	Severity Severity  // Severity indicates whether the issue blocks loading.
+	Err      error     // Err describes the issue.
+}
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+var (
+	ErrUnknownKind   = errors.New("unknown kind")
+	ErrInvalidSpec   = errors.New("invalid spec")
+	ErrDuplicateName = errors.New("duplicate name")
+	ErrMissingTarget = errors.New("missing target")
+	ErrUnknownPort   = errors.New("unknown port")
+	ErrCycle         = errors.New("cycle detected")
+	ErrUnreachable   = errors.New("unreachable node")
+)
+
+var _ error = (*Issue)(nil)
+
+// Errors joins the issues with error severity into a single error.
+func Errors(issues []*Issue) error {
+	var errs []error
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Error returns the issue message prefixed with the spec it was found in.
+func (i *Issue) Error() string {
+	return fmt.Sprintf("%s: %s", nameOf(i.Spec), i.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (i *Issue) Unwrap() error {
+	return i.Err
+}
+
+// invalidSpecError matches ErrInvalidSpec while keeping the error a spec failed to compile with.
+type invalidSpecError struct {
+	err error
+}
+
+func (e *invalidSpecError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidSpec.Error(), e.err.Error())
+}
+
+func (e *invalidSpecError) Is(target error) bool {
+	return target == ErrInvalidSpec
+}
+
+func (e *invalidSpecError) Unwrap() error {
+	return e.err
+}
+
+func nameOf(sp spec.Spec) string {
+	name := sp.GetName()
+	if name == "" && sp.GetID() != uuid.Nil {
+		name = sp.GetID().String()
+	}
+	if name == "" {
+		name = sp.GetKind()
+	}
+	return sp.GetNamespace() + "/" + name
+}
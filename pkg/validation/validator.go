@@ -0,0 +1,194 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+// Config holds the options of a Validator.
+type Config struct {
+	Scheme *scheme.Scheme // Scheme resolves kinds and compiles specs to inspect their ports.
+	Loops  []string       // Loops lists the kinds allowed to close cycles.
+}
+
+// Validator statically checks a set of specs before they are loaded.
+type Validator struct {
+	scheme *scheme.Scheme
+	loops  []string
+}
+
+type exposure struct {
+	ins  map[string]bool
+	outs map[string]bool
+}
+
+// DefaultLoops lists the kinds allowed to close cycles when none are configured.
+var DefaultLoops = []string{"retry", "for"}
+
+var lifecycles = []string{node.PortInit, node.PortActive, node.PortDeative, node.PortDeinit}
+
+// NewValidator creates a new Validator with the given configuration.
+func NewValidator(config Config) *Validator {
+	if config.Loops == nil {
+		config.Loops = DefaultLoops
+	}
+	return &Validator{
+		scheme: config.Scheme,
+		loops:  config.Loops,
+	}
+}
+
+// Validate checks the specs and returns the issues found, ordered by spec.
+func (v *Validator) Validate(specs ...spec.Spec) []*Issue {
+	g := NewGraph(specs...)
+
+	var issues []*Issue
+	report := func(sp spec.Spec, severity Severity, err error) {
+		issues = append(issues, &Issue{Spec: sp, Severity: severity, Err: err})
+	}
+
+	names := make(map[string]spec.Spec)
+	for _, sp := range specs {
+		if sp.GetName() == "" {
+			continue
+		}
+		key := sp.GetNamespace() + "/" + sp.GetName()
+		if _, ok := names[key]; ok {
+			report(sp, SeverityError, errors.WithMessagef(ErrDuplicateName, "%s", sp.GetName()))
+		} else {
+			names[key] = sp
+		}
+	}
+
+	// Nodes are compiled only to look up the ports referenced by links and are closed right away.
+	required := make(map[spec.Spec]*exposure)
+	need := func(sp spec.Spec) *exposure {
+		e, ok := required[sp]
+		if !ok {
+			e = &exposure{ins: map[string]bool{}, outs: map[string]bool{}}
+			required[sp] = e
+		}
+		return e
+	}
+	for _, edge := range g.Edges() {
+		if edge.Target == nil {
+			continue
+		}
+		if !slices.Contains(lifecycles, edge.Out) {
+			need(edge.Source).outs[edge.Out] = false
+		}
+		need(edge.Target).ins[edge.Port.Port] = false
+	}
+
+	exposed := make(map[spec.Spec]*exposure)
+	if v.scheme != nil {
+		for _, sp := range specs {
+			if v.scheme.KnownType(sp.GetKind()) == nil && v.scheme.Codec(sp.GetKind()) == nil {
+				report(sp, SeverityError, errors.WithMessagef(ErrUnknownKind, "%q", sp.GetKind()))
+				continue
+			}
+
+			n, err := v.compile(sp)
+			if err != nil {
+				report(sp, SeverityError, &invalidSpecError{err: err})
+				continue
+			}
+			if n == nil {
+				continue
+			}
+
+			e := need(sp)
+			for name := range e.ins {
+				e.ins[name] = n.In(name) != nil
+			}
+			for name := range e.outs {
+				e.outs[name] = n.Out(name) != nil
+			}
+			exposed[sp] = e
+
+			if err := n.Close(); err != nil {
+				report(sp, SeverityWarning, err)
+			}
+		}
+	}
+
+	for _, edge := range g.Edges() {
+		if edge.Target == nil {
+			report(edge.Source, SeverityError, errors.WithMessagef(ErrMissingTarget, "%s -> %s", edge.Out, reference(edge.Port)))
+			continue
+		}
+
+		if e, ok := exposed[edge.Source]; ok && !slices.Contains(lifecycles, edge.Out) && !e.outs[edge.Out] {
+			report(edge.Source, SeverityError, errors.WithMessagef(ErrUnknownPort, "output port %q is not exposed", edge.Out))
+		}
+		if e, ok := exposed[edge.Target]; ok && !e.ins[edge.Port.Port] {
+			report(edge.Source, SeverityError, errors.WithMessagef(ErrUnknownPort, "%s -> %s, input port %q is not exposed", edge.Out, reference(edge.Port), edge.Port.Port))
+		}
+	}
+
+	for _, cycle := range g.Cycles() {
+		if slices.ContainsFunc(cycle, func(sp spec.Spec) bool { return slices.Contains(v.loops, sp.GetKind()) }) {
+			continue
+		}
+
+		slices.SortFunc(cycle, func(x, y spec.Spec) int {
+			return slices.Index(specs, x) - slices.Index(specs, y)
+		})
+
+		path := make([]string, 0, len(cycle))
+		for _, sp := range cycle {
+			path = append(path, nameOf(sp))
+		}
+		report(cycle[0], SeverityError, errors.WithMessagef(ErrCycle, "%s", strings.Join(path, ", ")))
+	}
+
+	for _, sp := range g.Unreachable() {
+		report(sp, SeverityWarning, ErrUnreachable)
+	}
+
+	slices.SortStableFunc(issues, func(x, y *Issue) int {
+		return slices.Index(specs, x.Spec) - slices.Index(specs, y.Spec)
+	})
+	return issues
+}
+
+// compile compiles the spec to inspect its ports. Specs whose env values are not bound yet are skipped,
+// since their fields are only known once the values are bound.
+func (v *Validator) compile(sp spec.Spec) (node.Node, error) {
+	for _, val := range sp.GetEnv() {
+		if val.Data == nil {
+			return nil, nil
+		}
+	}
+
+	unstructured := &spec.Unstructured{}
+	if err := spec.As(sp, unstructured); err != nil {
+		return nil, err
+	}
+	if err := unstructured.Build(); err != nil {
+		return nil, err
+	}
+
+	decode, err := v.scheme.Decode(unstructured)
+	if err != nil {
+		return nil, err
+	}
+	if decode == spec.Spec(unstructured) {
+		return nil, nil
+	}
+	return v.scheme.Compile(decode)
+}
+
+func reference(port spec.Port) string {
+	target := port.Name
+	if target == "" {
+		target = port.ID.String()
+	}
+	return target + "." + port.Port
+}
@@ -0,0 +1,189 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	kind := faker.UUIDHyphenated()
+
+	s := scheme.New()
+	s.AddKnownType(kind, &spec.Meta{})
+	s.AddCodec(kind, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+		return node.NewOneToOneNode(nil), nil
+	}))
+
+	v := NewValidator(Config{Scheme: s})
+
+	t.Run("Valid", func(t *testing.T) {
+		sp1 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      faker.UUIDHyphenated(),
+		}
+		sp2 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Ports: map[string][]spec.Port{
+				node.PortOut:  {{Name: sp1.GetName(), Port: node.PortIn}},
+				node.PortInit: {{Name: sp1.GetName(), Port: node.PortIn}},
+			},
+		}
+
+		issues := v.Validate(sp1, sp2)
+		require.Empty(t, issues)
+		require.NoError(t, Errors(issues))
+	})
+
+	t.Run("UnknownKind", func(t *testing.T) {
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+		}
+
+		issues := v.Validate(sp)
+		require.Len(t, issues, 1)
+		require.ErrorIs(t, issues[0], ErrUnknownKind)
+		require.Equal(t, SeverityError, issues[0].Severity)
+	})
+
+	t.Run("InvalidSpec", func(t *testing.T) {
+		invalid := faker.UUIDHyphenated()
+
+		s := scheme.New()
+		s.AddKnownType(invalid, &spec.Meta{})
+		s.AddCodec(invalid, scheme.CodecFunc(func(_ spec.Spec) (node.Node, error) {
+			return nil, encoding.ErrUnsupportedValue
+		}))
+
+		v := NewValidator(Config{Scheme: s})
+
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      invalid,
+			Namespace: meta.DefaultNamespace,
+		}
+
+		issues := v.Validate(sp)
+		require.Len(t, issues, 1)
+		require.ErrorIs(t, issues[0], ErrInvalidSpec)
+		require.ErrorIs(t, issues[0], encoding.ErrUnsupportedValue)
+		require.Equal(t, SeverityError, issues[0].Severity)
+
+		sp.Env = map[string]spec.Value{"KEY": {Name: faker.UUIDHyphenated()}}
+
+		issues = v.Validate(sp)
+		require.Empty(t, issues)
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		name := faker.UUIDHyphenated()
+		sp1 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      name,
+		}
+		sp2 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Name:      name,
+		}
+
+		issues := v.Validate(sp1, sp2)
+		require.Len(t, issues, 1)
+		require.ErrorIs(t, issues[0], ErrDuplicateName)
+		require.Equal(t, sp2, issues[0].Spec)
+	})
+
+	t.Run("MissingTarget", func(t *testing.T) {
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Ports: map[string][]spec.Port{
+				node.PortOut: {{Name: faker.UUIDHyphenated(), Port: node.PortIn}},
+			},
+		}
+
+		issues := v.Validate(sp)
+		require.Len(t, issues, 1)
+		require.ErrorIs(t, issues[0], ErrMissingTarget)
+	})
+
+	t.Run("UnknownPort", func(t *testing.T) {
+		sp1 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+		sp2 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+			Ports: map[string][]spec.Port{
+				faker.Word(): {{ID: sp1.GetID(), Port: faker.Word()}},
+			},
+		}
+
+		issues := v.Validate(sp1, sp2)
+		require.Len(t, issues, 2)
+		require.ErrorIs(t, issues[0], ErrUnknownPort)
+		require.ErrorIs(t, issues[1], ErrUnknownPort)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		sp1 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+		sp2 := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+		sp1.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp2.GetID(), Port: node.PortIn}}}
+		sp2.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp1.GetID(), Port: node.PortIn}}}
+
+		issues := v.Validate(sp1, sp2)
+		require.ErrorIs(t, Errors(issues), ErrCycle)
+
+		var warnings int
+		for _, issue := range issues {
+			if issue.Severity == SeverityWarning {
+				require.ErrorIs(t, issue, ErrUnreachable)
+				warnings++
+			}
+		}
+		require.Equal(t, 2, warnings)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		v := NewValidator(Config{Scheme: s, Loops: []string{kind}})
+
+		sp := &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      kind,
+			Namespace: meta.DefaultNamespace,
+		}
+		sp.Ports = map[string][]spec.Port{node.PortOut: {{ID: sp.GetID(), Port: node.PortIn}}}
+
+		issues := v.Validate(sp)
+		require.NoError(t, Errors(issues))
+	})
+}
@@ -16,6 +16,8 @@ type Value struct {
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 	// Annotations hold additional metadata.
 	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// Secret marks the data as sensitive so that it is encrypted at rest and redacted when displayed.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
 	// Data holds the value's actual data.
 	Data any `json:"data" yaml:"data" validate:"required"`
 }
@@ -26,6 +28,7 @@ const (
 	KeyNamespace   = "namespace"
 	KeyName        = "name"
 	KeyAnnotations = "annotations"
+	KeySecret      = "secret"
 	KeyData        = "data"
 )
 
@@ -86,6 +89,11 @@ func (v *Value) SetData(val any) {
 	v.Data = val
 }
 
+// IsSecret reports whether the value holds sensitive data.
+func (v *Value) IsSecret() bool {
+	return v.Secret
+}
+
 // IsIdentified checks whether the Value instance has a unique identifier or name.
 func (v *Value) IsIdentified() bool {
 	return v.ID != uuid.Nil || v.Name != ""
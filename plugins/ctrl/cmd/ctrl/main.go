@@ -26,8 +26,9 @@ var (
 )
 
 var (
-	_ plugin.Plugin   = (*Plugin)(nil)
-	_ scheme.Register = (*Plugin)(nil)
+	_ plugin.Plugin    = (*Plugin)(nil)
+	_ plugin.Dependent = (*Plugin)(nil)
+	_ scheme.Register  = (*Plugin)(nil)
 )
 
 // New creates a new Plugin instance.
@@ -61,6 +62,14 @@ func (p *Plugin) Version() string {
 	return version
 }
 
+// Dependencies requires a language plugin to compile the expressions of nodes such as if and switch.
+func (p *Plugin) Dependencies() []plugin.Dependency {
+	return []plugin.Dependency{
+		{Name: "cel", Group: "language"},
+		{Name: "ecmascript", Group: "language"},
+	}
+}
+
 // Load registers control nodes to the scheme using the provided builder and registry.
 func (p *Plugin) Load(_ context.Context) error {
 	p.mu.Lock()
@@ -90,3 +90,13 @@ func TestPlugin_Unload(t *testing.T) {
 	err = p.Unload(ctx)
 	require.NoError(t, err)
 }
+
+func TestPlugin_Dependencies(t *testing.T) {
+	p := New()
+	deps := p.Dependencies()
+	require.Len(t, deps, 2)
+	for _, dep := range deps {
+		require.False(t, dep.Optional)
+		require.Equal(t, "language", dep.Group)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/siyul-park/uniflow/pkg/node"
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/types"
@@ -49,6 +50,18 @@ type HTTPPayload struct {
 	Status   int         `json:"status"`
 }
 
+// HTTPRequestSchema describes the request payloads emitted by listeners and routed by route nodes.
+var HTTPRequestSchema = &schema.Schema{
+	Type: schema.TypeObject,
+	Properties: map[string]*schema.Schema{
+		"method": {Type: schema.TypeString},
+		"path":   {Type: schema.TypeString},
+		"query":  {Type: schema.TypeObject},
+		"header": {Type: schema.TypeObject},
+	},
+	Required: []string{"method", "path"},
+}
+
 const KindHTTP = "http"
 
 // NewHTTPNodeCodec creates a new codec for HTTPNode.
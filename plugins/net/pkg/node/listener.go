@@ -13,6 +13,7 @@ import (
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/types"
@@ -58,8 +59,9 @@ var (
 )
 
 // NewListenNodeCodec creates a new codec for ListenNodeSpec.
+// The out port is declared to emit HTTPRequestSchema.
 func NewListenNodeCodec() scheme.Codec {
-	return scheme.CodecWithType(func(spec *ListenNodeSpec) (node.Node, error) {
+	return scheme.CodecWithSchemas(scheme.CodecWithType(func(spec *ListenNodeSpec) (node.Node, error) {
 		switch spec.Protocol {
 		case ProtocolHTTP:
 			n := NewHTTPListenNode(fmt.Sprintf("%s:%d", spec.Host, spec.Port))
@@ -72,7 +74,7 @@ func NewListenNodeCodec() scheme.Codec {
 			return n, nil
 		}
 		return nil, errors.WithStack(ErrInvalidProtocol)
-	})
+	}), &spec.Schemas{Outs: map[string]*schema.Schema{node.PortOut: HTTPRequestSchema}})
 }
 
 // NewHTTPListenNode creates a new HTTPListenNode with the specified address.
@@ -9,6 +9,7 @@ import (
 	"github.com/siyul-park/uniflow/pkg/node"
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/types"
@@ -61,14 +62,15 @@ const (
 )
 
 // NewRouteNodeCodec creates a new codec for RouteNodeSpec.
+// The in port is declared to accept HTTPRequestSchema.
 func NewRouteNodeCodec() scheme.Codec {
-	return scheme.CodecWithType(func(spec *RouteNodeSpec) (node.Node, error) {
+	return scheme.CodecWithSchemas(scheme.CodecWithType(func(spec *RouteNodeSpec) (node.Node, error) {
 		n := NewRouteNode()
 		for _, route := range spec.Routes {
 			n.Add(route.Method, route.Path, route.Port)
 		}
 		return n, nil
-	})
+	}), &spec.Schemas{Ins: map[string]*schema.Schema{node.PortIn: HTTPRequestSchema}})
 }
 
 // NewRouteNode creates a new RouteNode.
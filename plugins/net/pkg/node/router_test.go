@@ -11,6 +11,9 @@ import (
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/types"
 	"github.com/stretchr/testify/require"
 )
@@ -34,6 +37,20 @@ func TestRouteNodeCodec_Compile(t *testing.T) {
 	require.NoError(t, n.Close())
 }
 
+func TestRouteNodeCodec_Describe(t *testing.T) {
+	s := scheme.New()
+	s.AddCodec(KindListener, NewListenNodeCodec())
+	s.AddCodec(KindRouter, NewRouteNodeCodec())
+
+	listener := s.Describe(&spec.Meta{Kind: KindListener})
+	router := s.Describe(&spec.Meta{Kind: KindRouter})
+	require.NotNil(t, listener)
+	require.NotNil(t, router)
+
+	require.NoError(t, schema.Compatible(listener.Outs[node.PortOut], router.Ins[node.PortIn]))
+	require.ErrorIs(t, schema.Compatible(&schema.Schema{Type: schema.TypeString}, router.Ins[node.PortIn]), schema.ErrIncompatible)
+}
+
 func TestNewRouteNode(t *testing.T) {
 	n := NewRouteNode()
 	require.NotNil(t, n)
@@ -9,7 +9,9 @@ import (
 	"github.com/siyul-park/sqlbridge/schema"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/secret"
 	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/siyul-park/uniflow/pkg/value"
 	"github.com/xwb1989/sqlparser"
 )
 
@@ -113,6 +115,9 @@ func (t *Table[T]) Scan(ctx context.Context, hint ...schema.ScanHint) (schema.Cu
 		if err := cursor.Decode(&doc); err != nil {
 			return nil, err
 		}
+		if v, ok := any(doc).(*value.Value); ok {
+			doc = any(secret.Redact(v)).(T)
+		}
 
 		raw, err := json.Marshal(doc)
 		if err != nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/siyul-park/sqlbridge/schema"
 	"github.com/siyul-park/uniflow/pkg/driver"
 	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/secret"
+	"github.com/siyul-park/uniflow/pkg/value"
 	"github.com/stretchr/testify/require"
 	"github.com/xwb1989/sqlparser/dependency/sqltypes"
 )
@@ -60,3 +62,39 @@ func TestTable_Scan(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, rows, 1)
 }
+
+func TestTable_ScanSecret(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	s := driver.NewStore()
+
+	tbl := NewTable[*value.Value](s)
+
+	doc := &value.Value{
+		ID:        uuid.Must(uuid.NewV7()),
+		Namespace: meta.DefaultNamespace,
+		Name:      faker.UUIDHyphenated(),
+		Secret:    true,
+		Data:      faker.Password(),
+	}
+
+	err := s.Insert(ctx, []any{doc})
+	require.NoError(t, err)
+
+	cursor, err := tbl.Scan(ctx)
+	require.NoError(t, err)
+
+	rows, err := schema.ReadAll(cursor)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	found := false
+	for i, col := range rows[0].Columns {
+		if col.Name.String() == value.KeyData {
+			require.Equal(t, secret.Redacted, rows[0].Values[i].ToString())
+			found = true
+		}
+	}
+	require.True(t, found)
+}
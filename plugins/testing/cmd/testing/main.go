@@ -22,6 +22,8 @@ import (
 type Plugin struct {
 	runner           *testing.Runner
 	agent            *runtime.Agent
+	snapshot         *testing.Snapshot
+	corpus           *testing.Corpus
 	schemeBuilder    *scheme.Builder
 	hookBuilder      *hook.Builder
 	languageRegistry *language.Registry
@@ -34,9 +36,10 @@ var (
 )
 
 var (
-	_ plugin.Plugin   = (*Plugin)(nil)
-	_ hook.Register   = (*Plugin)(nil)
-	_ scheme.Register = (*Plugin)(nil)
+	_ plugin.Plugin    = (*Plugin)(nil)
+	_ plugin.Dependent = (*Plugin)(nil)
+	_ hook.Register    = (*Plugin)(nil)
+	_ scheme.Register  = (*Plugin)(nil)
 )
 
 // New returns a new Plugin instance.
@@ -60,6 +63,22 @@ func (p *Plugin) SetAgent(agent *runtime.Agent) {
 	p.agent = agent
 }
 
+// SetSnapshot sets the snapshot that assert nodes compare payloads with.
+func (p *Plugin) SetSnapshot(snapshot *testing.Snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.snapshot = snapshot
+}
+
+// SetCorpus sets the corpus that fuzz nodes replay and extend with failing inputs.
+func (p *Plugin) SetCorpus(corpus *testing.Corpus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.corpus = corpus
+}
+
 // SetSchemeBuilder sets the scheme builder for the plugin.
 func (p *Plugin) SetSchemeBuilder(builder *scheme.Builder) {
 	p.mu.Lock()
@@ -94,6 +113,14 @@ func (p *Plugin) Version() string {
 	return version
 }
 
+// Dependencies requires a language plugin to evaluate the expressions of assert and fuzz nodes.
+func (p *Plugin) Dependencies() []plugin.Dependency {
+	return []plugin.Dependency{
+		{Name: "cel", Group: "language"},
+		{Name: "ecmascript", Group: "language"},
+	}
+}
+
 // Load registers testing nodes and hooks to the scheme and hook builder.
 func (p *Plugin) Load(_ context.Context) error {
 	p.mu.Lock()
@@ -140,6 +167,14 @@ func (p *Plugin) AddToHook(h *hook.Hook) error {
 		if node.As(sb, &n) {
 			runner.Register(sb.NamespacedName(), n)
 		}
+		var f *node2.FuzzNode
+		if node.As(sb, &f) {
+			runner.Register(sb.NamespacedName(), f)
+		}
+		var h *node2.HookNode
+		if node.As(sb, &h) {
+			runner.AddHook(h.Phase(), sb.NamespacedName(), h)
+		}
 		return nil
 	}))
 	h.AddUnloadHook(symbol.UnloadFunc(func(sb *symbol.Symbol) error {
@@ -147,6 +182,14 @@ func (p *Plugin) AddToHook(h *hook.Hook) error {
 		if node.As(sb, &n) {
 			runner.Unregister(sb.NamespacedName())
 		}
+		var f *node2.FuzzNode
+		if node.As(sb, &f) {
+			runner.Unregister(sb.NamespacedName())
+		}
+		var h *node2.HookNode
+		if node.As(sb, &h) {
+			runner.RemoveHook(h.Phase(), sb.NamespacedName())
+		}
 		return nil
 	}))
 	return nil
@@ -171,8 +214,12 @@ func (p *Plugin) AddToScheme(s *scheme.Scheme) error {
 		codec scheme.Codec
 		spec  spec.Spec
 	}{
-		{node2.KindTest, node2.NewTestNodeCodec(), &node2.TestNodeSpec{}},
-		{node2.KindAssert, node2.NewAssertNodeCodec(compiler, p.agent), &node2.AssertNodeSpec{}},
+		{node2.KindTest, node2.NewTestNodeCodec(p.agent), &node2.TestNodeSpec{}},
+		{node2.KindAssert, node2.NewAssertNodeCodec(compiler, p.agent, p.snapshot), &node2.AssertNodeSpec{}},
+		{node2.KindFuzz, node2.NewFuzzNodeCodec(compiler, p.corpus), &node2.FuzzNodeSpec{}},
+		{node2.KindMock, node2.NewMockNodeCodec(p.agent), &node2.MockNodeSpec{}},
+		{node2.KindBefore, node2.NewBeforeNodeCodec(), &node2.HookNodeSpec{}},
+		{node2.KindAfter, node2.NewAfterNodeCodec(), &node2.HookNodeSpec{}},
 	}
 
 	for _, def := range definitions {
@@ -62,6 +62,10 @@ func TestPlugin_Load(t *testing.T) {
 	tests := []string{
 		node.KindTest,
 		node.KindAssert,
+		node.KindFuzz,
+		node.KindMock,
+		node.KindBefore,
+		node.KindAfter,
 	}
 
 	for _, tt := range tests {
@@ -98,3 +102,13 @@ func TestPlugin_Unload(t *testing.T) {
 	err = p.Unload(ctx)
 	require.NoError(t, err)
 }
+
+func TestPlugin_Dependencies(t *testing.T) {
+	p := New()
+	deps := p.Dependencies()
+	require.Len(t, deps, 2)
+	for _, dep := range deps {
+		require.False(t, dep.Optional)
+		require.Equal(t, "language", dep.Group)
+	}
+}
@@ -14,13 +14,16 @@ import (
 	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/types"
 )
 
 // AssertNodeSpec defines the specification for Assert node
 type AssertNodeSpec struct {
 	spec.Meta `json:",inline"`
-	Expect    string        `json:"expect"`
+	Expect    string        `json:"expect,omitempty"`
+	Snapshot  string        `json:"snapshot,omitempty"`
+	Normalize []string      `json:"normalize,omitempty"`
 	Target    *spec.Port    `json:"target,omitempty"`
 	Timeout   time.Duration `json:"timeout,omitempty"`
 }
@@ -28,15 +31,18 @@ type AssertNodeSpec struct {
 // AssertNode implements the Assert node functionality
 type AssertNode struct {
 	*node.OneToOneNode
-	expect func(context.Context, any) (bool, error)
-	target func(*process.Process, any, int) (any, int, error)
-	mu     sync.RWMutex
+	name     string
+	expect   func(context.Context, any) (bool, error)
+	snapshot func(any) error
+	target   func(*process.Process, any, int) (any, int, error)
+	mu       sync.RWMutex
 }
 
 // AssertNodeCodec implements scheme.Codec for AssertNode
 type AssertNodeCodec struct {
 	compiler language.Compiler
 	agent    *runtime.Agent
+	snapshot *testing.Snapshot
 }
 
 const KindAssert = "assert"
@@ -49,10 +55,14 @@ var (
 )
 
 // NewAssertNodeCodec creates a codec for AssertNode
-func NewAssertNodeCodec(compiler language.Compiler, agent *runtime.Agent) *AssertNodeCodec {
+func NewAssertNodeCodec(compiler language.Compiler, agent *runtime.Agent, snapshot *testing.Snapshot) *AssertNodeCodec {
+	if snapshot == nil {
+		snapshot = testing.NewSnapshot(nil)
+	}
 	return &AssertNodeCodec{
 		compiler: compiler,
 		agent:    agent,
+		snapshot: snapshot,
 	}
 }
 
@@ -62,12 +72,23 @@ func (c *AssertNodeCodec) Compile(spec spec.Spec) (node.Node, error) {
 		return nil, errors.WithStack(encoding.ErrUnsupportedType)
 	}
 
-	program, err := c.compiler.Compile(converted.Expect)
-	if err != nil {
-		return nil, err
+	var expect func(context.Context, any) (bool, error)
+	if converted.Expect != "" || converted.Snapshot == "" {
+		program, err := c.compiler.Compile(converted.Expect)
+		if err != nil {
+			return nil, err
+		}
+		expect = language.Predicate[any](language.Timeout(program, converted.Timeout))
 	}
 
-	n := NewAssertNode(language.Predicate[any](language.Timeout(program, converted.Timeout)))
+	n := NewAssertNode(expect)
+	n.SetName(spec.GetName())
+
+	if converted.Snapshot != "" {
+		n.SetSnapshot(func(payload any) error {
+			return c.snapshot.Match(converted.Snapshot, payload, converted.Normalize...)
+		})
+	}
 
 	if converted.Target != nil {
 		n.SetTarget(c.Target(spec.GetNamespace(), converted.Target))
@@ -78,6 +99,14 @@ func (c *AssertNodeCodec) Compile(spec spec.Spec) (node.Node, error) {
 
 func (c *AssertNodeCodec) Target(namespace string, target *spec.Port) func(proc *process.Process, payload any, index int) (any, int, error) {
 	return func(proc *process.Process, payload any, index int) (any, int, error) {
+		if sym := lookup(c.agent, namespace, target.ID, target.Name); sym != nil {
+			var mock *MockNode
+			if node.As(sym, &mock) {
+				calls := mock.Calls(proc)
+				return map[string]any{"count": len(calls), "calls": calls}, index, nil
+			}
+		}
+
 		if index < 0 {
 			index = 0
 		}
@@ -114,6 +143,22 @@ func NewAssertNode(expect func(context.Context, any) (bool, error)) *AssertNode
 	return n
 }
 
+// SetName sets the name reported when the assertion fails
+func (n *AssertNode) SetName(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.name = name
+}
+
+// SetSnapshot sets the function comparing the payload with a golden file
+func (n *AssertNode) SetSnapshot(snapshot func(any) error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.snapshot = snapshot
+}
+
 // SetTarget sets the target function
 func (n *AssertNode) SetTarget(target func(*process.Process, any, int) (any, int, error)) {
 	n.mu.Lock()
@@ -144,10 +189,19 @@ func (n *AssertNode) action(proc *process.Process, inPck *packet.Packet) (*packe
 		}
 	}
 
-	if ok, err := n.expect(proc, payload); err != nil {
-		return nil, packet.New(types.NewError(err))
-	} else if !ok {
-		return nil, packet.New(types.NewError(ErrAssertFail))
+	if n.expect != nil {
+		if ok, err := n.expect(proc, payload); err != nil {
+			return nil, packet.New(types.NewError(err))
+		} else if !ok {
+			return nil, packet.New(types.NewError(&testing.Failure{Node: n.name, Payload: payload, Err: ErrAssertFail}))
+		}
+	}
+	if n.snapshot != nil {
+		if err := n.snapshot(payload); errors.Is(err, testing.ErrSnapshotMismatch) || errors.Is(err, testing.ErrSnapshotNotFound) {
+			return nil, packet.New(types.NewError(&testing.Failure{Node: n.name, Payload: payload, Err: err}))
+		} else if err != nil {
+			return nil, packet.New(types.NewError(err))
+		}
 	}
 
 	outPayload, err := types.Marshal([]any{payload, index})
@@ -18,7 +18,9 @@ import (
 	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/symbol"
+	testing2 "github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
 
@@ -27,7 +29,7 @@ func TestNewAssertNodeCodec_Compile(t *testing.T) {
 	agent := runtime.NewAgent()
 	defer agent.Close()
 
-	codec := NewAssertNodeCodec(compiler, agent)
+	codec := NewAssertNodeCodec(compiler, agent, nil)
 	require.NotNil(t, codec)
 
 	t.Run("Compile", func(t *testing.T) {
@@ -53,6 +55,24 @@ func TestNewAssertNodeCodec_Compile(t *testing.T) {
 		require.NoError(t, n.Close())
 	})
 
+	t.Run("Snapshot", func(t *testing.T) {
+		s := &AssertNodeSpec{
+			Meta: spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      faker.UUIDHyphenated(),
+				Namespace: meta.DefaultNamespace,
+				Name:      faker.UUIDHyphenated(),
+			},
+			Snapshot:  "foo.json",
+			Normalize: []string{"id"},
+		}
+
+		n, err := codec.Compile(s)
+		require.NoError(t, err)
+		require.NotNil(t, n)
+		require.NoError(t, n.Close())
+	})
+
 	t.Run("CompileError", func(t *testing.T) {
 		s := &AssertNodeSpec{
 			Meta: spec.Meta{
@@ -75,7 +95,7 @@ func TestAssertNodeCodec_Target(t *testing.T) {
 	agent := runtime.NewAgent()
 	defer agent.Close()
 
-	codec := NewAssertNodeCodec(compiler, agent)
+	codec := NewAssertNodeCodec(compiler, agent, nil)
 	require.NotNil(t, codec)
 
 	proc := process.New()
@@ -185,6 +205,33 @@ func TestAssertNodeCodec_Target(t *testing.T) {
 		require.ErrorIs(t, err, ErrAssertFail)
 		require.Nil(t, result)
 	})
+
+	t.Run("FindMock", func(t *testing.T) {
+		name := faker.UUIDHyphenated()
+
+		n := &symbol.Symbol{
+			Spec: &spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      KindMock,
+				Namespace: meta.DefaultNamespace,
+				Name:      name,
+			},
+			Node: NewMockNode(nil),
+		}
+		defer n.Close()
+
+		agent.Load(n)
+		defer agent.Unload(n)
+
+		target := codec.Target(meta.DefaultNamespace, &spec.Port{
+			Name: name,
+			Port: node.PortIn,
+		})
+
+		result, _, err := target(proc, nil, -1)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"count": 0, "calls": []any(nil)}, result)
+	})
 }
 
 func TestAssertNode_Port(t *testing.T) {
@@ -247,6 +294,8 @@ func TestAssertNode_SendAndReceive(t *testing.T) {
 		})
 		defer assert.Close()
 
+		assert.SetName("assert")
+
 		in := port.NewOut()
 		in.Link(assert.In(node.PortIn))
 
@@ -270,6 +319,60 @@ func TestAssertNode_SendAndReceive(t *testing.T) {
 			require.NotNil(t, outPck)
 			outReader.Receive(outPck)
 			require.ErrorIs(t, outPck.Payload().(types.Error), ErrAssertFail)
+
+			var failure *testing2.Failure
+			require.ErrorAs(t, outPck.Payload().(types.Error), &failure)
+			require.Equal(t, "assert", failure.Node)
+			require.Equal(t, 99, failure.Payload)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
+	t.Run("SnapshotMismatch", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+		err := afero.WriteFile(fs, "foo.json", []byte("10"), 0644)
+		require.NoError(t, err)
+
+		snapshot := testing2.NewSnapshot(fs)
+
+		assert := NewAssertNode(nil)
+		defer assert.Close()
+
+		assert.SetName("assert")
+		assert.SetSnapshot(func(payload any) error {
+			return snapshot.Match("foo.json", payload)
+		})
+
+		in := port.NewOut()
+		in.Link(assert.In(node.PortIn))
+
+		out := port.NewIn()
+		assert.Out(node.PortError).Link(out)
+
+		proc := process.New()
+		defer proc.Exit(nil)
+
+		inWriter := in.Open(proc)
+		outReader := out.Open(proc)
+
+		inPayload, err := types.Marshal([]any{99, -1})
+		require.NoError(t, err)
+
+		inWriter.Write(packet.New(inPayload))
+
+		select {
+		case outPck := <-outReader.Read():
+			require.NotNil(t, outPck)
+			outReader.Receive(outPck)
+			require.ErrorIs(t, outPck.Payload().(types.Error), testing2.ErrSnapshotMismatch)
+
+			var failure *testing2.Failure
+			require.ErrorAs(t, outPck.Payload().(types.Error), &failure)
+			require.Equal(t, "assert", failure.Node)
 		case <-ctx.Done():
 			require.Fail(t, ctx.Err().Error())
 		}
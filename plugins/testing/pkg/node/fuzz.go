@@ -0,0 +1,391 @@
+package node
+
+import (
+	"context"
+	"math/rand/v2"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/siyul-park/uniflow/pkg/language"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/testing"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// FuzzNodeSpec defines the specifications for creating a FuzzNode.
+type FuzzNodeSpec struct {
+	spec.Meta `json:",inline"`
+	Schema    *schema.Schema `json:"schema,omitempty"`
+	Seeds     []any          `json:"seeds,omitempty"`
+	Invariant string         `json:"invariant,omitempty"`
+	Runs      int            `json:"runs,omitempty"`
+	Corpus    string         `json:"corpus,omitempty"`
+	Timeout   time.Duration  `json:"timeout,omitempty"`
+}
+
+// FuzzNode is a test node that sends generated payloads through its output port and checks an invariant on the responses.
+type FuzzNode struct {
+	outPort   *port.OutPort
+	name      string
+	schema    *schema.Schema
+	seeds     []any
+	runs      int
+	invariant func(context.Context, any) (bool, error)
+	corpus    *testing.Corpus
+	filename  string
+	mu        sync.RWMutex
+}
+
+const KindFuzz = "fuzz"
+
+const (
+	defaultFuzzRuns = 100
+	maxShrinks      = 1000
+)
+
+var ErrInvariantViolated = errors.New("invariant violated")
+
+var (
+	_ node.Node     = (*FuzzNode)(nil)
+	_ testing.Suite = (*FuzzNode)(nil)
+)
+
+// NewFuzzNodeCodec creates and returns a codec for decoding FuzzNodeSpec.
+func NewFuzzNodeCodec(compiler language.Compiler, corpus *testing.Corpus) scheme.Codec {
+	if corpus == nil {
+		corpus = testing.NewCorpus(nil)
+	}
+	return scheme.CodecWithType(func(sp *FuzzNodeSpec) (node.Node, error) {
+		var invariant func(context.Context, any) (bool, error)
+		if sp.Invariant != "" {
+			program, err := compiler.Compile(sp.Invariant)
+			if err != nil {
+				return nil, err
+			}
+			invariant = language.Predicate[any](language.Timeout(program, sp.Timeout))
+		}
+
+		n := NewFuzzNode(invariant)
+		n.SetName(sp.GetName())
+		n.SetSchema(sp.Schema)
+		n.SetSeeds(sp.Seeds)
+		if sp.Runs > 0 {
+			n.SetRuns(sp.Runs)
+		}
+
+		filename := sp.Corpus
+		if filename == "" && sp.GetName() != "" {
+			filename = filepath.Join("__corpus__", sp.GetName()+".json")
+		}
+		if filename != "" {
+			n.SetCorpus(corpus, filename)
+		}
+		return n, nil
+	})
+}
+
+// NewFuzzNode creates and returns a new instance of FuzzNode checking the invariant on every response.
+func NewFuzzNode(invariant func(context.Context, any) (bool, error)) *FuzzNode {
+	return &FuzzNode{
+		outPort:   port.NewOut(),
+		runs:      defaultFuzzRuns,
+		invariant: invariant,
+	}
+}
+
+// SetName sets the name reported when the invariant is violated.
+func (n *FuzzNode) SetName(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.name = name
+}
+
+// SetSchema sets the schema the generated payloads conform to.
+func (n *FuzzNode) SetSchema(schema *schema.Schema) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.schema = schema
+}
+
+// SetSeeds sets the example payloads that are sent first and mutated when no schema is set.
+func (n *FuzzNode) SetSeeds(seeds []any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.seeds = seeds
+}
+
+// SetRuns sets the number of generated payloads.
+func (n *FuzzNode) SetRuns(runs int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.runs = runs
+}
+
+// SetCorpus sets the corpus file replayed before generating payloads and extended with failing inputs.
+func (n *FuzzNode) SetCorpus(corpus *testing.Corpus, filename string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.corpus = corpus
+	n.filename = filename
+}
+
+// Run replays the corpus and the seeds, then sends generated payloads until one fails or the runs are exhausted.
+// A failing input is shrunk to a minimal reproduction and added to the corpus.
+func (n *FuzzNode) Run(t *testing.Tester) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	proc := t.Process()
+	writer := n.outPort.Open(proc)
+
+	var inputs []any
+	if n.corpus != nil {
+		entries, err := n.corpus.Load(n.filename)
+		if err != nil {
+			t.Exit(err)
+			return
+		}
+		inputs = append(inputs, entries...)
+	}
+	inputs = append(inputs, n.seeds...)
+
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	for i := range len(inputs) + n.runs {
+		select {
+		case <-proc.Done():
+			return
+		default:
+		}
+
+		var input types.Value
+		var err error
+		if i < len(inputs) {
+			input, err = types.Marshal(inputs[i])
+		} else {
+			input, err = types.Marshal(n.generate(r))
+		}
+		if err != nil {
+			t.Exit(err)
+			return
+		}
+
+		output, err := n.check(proc, writer, input)
+		if err == nil {
+			continue
+		}
+
+		input, output, err = n.shrink(proc, writer, input, output, err)
+		if n.corpus != nil {
+			if err := n.corpus.Add(n.filename, input); err != nil {
+				t.Exit(err)
+				return
+			}
+		}
+
+		payload := map[string]any{"input": types.InterfaceOf(input), "output": types.InterfaceOf(output)}
+		t.Exit(&testing.Failure{Node: n.name, Payload: payload, Err: err})
+		return
+	}
+	t.Exit(nil)
+}
+
+// In returns nil as this node does not use an input port.
+func (n *FuzzNode) In(_ string) *port.InPort {
+	return nil
+}
+
+// Out returns the output port if the name matches.
+func (n *FuzzNode) Out(name string) *port.OutPort {
+	switch name {
+	case node.PortOut:
+		return n.outPort
+	default:
+		return nil
+	}
+}
+
+// Close closes the output port of the FuzzNode.
+func (n *FuzzNode) Close() error {
+	n.outPort.Close()
+	return nil
+}
+
+func (n *FuzzNode) generate(r *rand.Rand) any {
+	if n.schema == nil && len(n.seeds) > 0 {
+		seed, err := types.Marshal(n.seeds[r.IntN(len(n.seeds))])
+		if err != nil {
+			return nil
+		}
+		return types.InterfaceOf(mutate(r, seed))
+	}
+	return n.schema.Generate(r)
+}
+
+func (n *FuzzNode) check(proc *process.Process, writer *packet.Writer, input types.Value) (types.Value, error) {
+	backPck := packet.Send(writer, packet.New(input))
+	if err, ok := backPck.Payload().(types.Error); ok {
+		return nil, err.Unwrap()
+	}
+
+	output := backPck.Payload()
+	if n.invariant != nil {
+		env := map[string]any{"input": types.InterfaceOf(input), "output": types.InterfaceOf(output)}
+		if ok, err := n.invariant(proc, env); err != nil {
+			return output, err
+		} else if !ok {
+			return output, errors.WithStack(ErrInvariantViolated)
+		}
+	}
+	return output, nil
+}
+
+func (n *FuzzNode) shrink(proc *process.Process, writer *packet.Writer, input, output types.Value, err error) (types.Value, types.Value, error) {
+	budget := maxShrinks
+	for shrunk := true; shrunk && budget > 0; {
+		shrunk = false
+		for _, candidate := range shrink(input) {
+			if budget--; budget < 0 {
+				break
+			}
+			if n.schema != nil && n.schema.Validate(candidate) != nil {
+				continue
+			}
+			if out, e := n.check(proc, writer, candidate); e != nil {
+				input, output, err = candidate, out, e
+				shrunk = true
+				break
+			}
+		}
+	}
+	return input, output, err
+}
+
+func shrink(val types.Value) []types.Value {
+	var candidates []types.Value
+	switch v := val.(type) {
+	case types.Map:
+		keys := v.Keys()
+		slices.SortFunc(keys, types.Compare)
+		for _, key := range keys {
+			candidates = append(candidates, v.Delete(key))
+		}
+		for _, key := range keys {
+			for _, c := range shrink(v.Get(key)) {
+				candidates = append(candidates, v.Set(key, c))
+			}
+		}
+	case types.Slice:
+		size := v.Len()
+		if size > 1 {
+			candidates = append(candidates, v.Sub(0, size/2), v.Sub(size/2, size))
+		}
+		for i := range size {
+			candidates = append(candidates, v.Sub(0, i).Append(v.Sub(i+1, size).Values()...))
+		}
+		for i, elem := range v.Range() {
+			for _, c := range shrink(elem) {
+				candidates = append(candidates, v.Set(i, c))
+			}
+		}
+	case types.String:
+		runes := []rune(v.String())
+		size := len(runes)
+		if size > 0 {
+			candidates = append(candidates, types.NewString(""))
+		}
+		if size > 1 {
+			candidates = append(candidates,
+				types.NewString(string(runes[:size/2])),
+				types.NewString(string(runes[size/2:])),
+				types.NewString(string(runes[1:])),
+				types.NewString(string(runes[:size-1])),
+			)
+		}
+	case types.Integer:
+		if i := v.Int(); i != 0 {
+			candidates = append(candidates, types.NewInt64(0))
+			if i < 0 {
+				candidates = append(candidates, types.NewInt64(-i))
+			}
+			for d := i / 2; d != 0; d /= 2 {
+				candidates = append(candidates, types.NewInt64(i-d))
+			}
+		}
+	case types.Uinteger:
+		if u := v.Uint(); u != 0 {
+			candidates = append(candidates, types.NewUint64(0))
+			for d := u / 2; d != 0; d /= 2 {
+				candidates = append(candidates, types.NewUint64(u-d))
+			}
+		}
+	case types.Float:
+		if f := v.Float(); f != 0 {
+			candidates = append(candidates, types.NewFloat64(0))
+			if t := float64(int64(f)); t != f {
+				candidates = append(candidates, types.NewFloat64(t))
+			} else {
+				candidates = append(candidates, types.NewFloat64(float64(int64(f)/2)))
+			}
+			if f < 0 {
+				candidates = append(candidates, types.NewFloat64(-f))
+			}
+		}
+	case types.Boolean:
+		if v.Bool() {
+			candidates = append(candidates, types.False)
+		}
+	}
+	return candidates
+}
+
+func mutate(r *rand.Rand, val types.Value) types.Value {
+	switch v := val.(type) {
+	case types.Map:
+		if v.Len() > 0 && r.IntN(4) > 0 {
+			keys := v.Keys()
+			slices.SortFunc(keys, types.Compare)
+			key := keys[r.IntN(len(keys))]
+			if r.IntN(4) == 0 {
+				return v.Delete(key)
+			}
+			return v.Set(key, mutate(r, v.Get(key)))
+		}
+	case types.Slice:
+		if v.Len() > 0 && r.IntN(4) > 0 {
+			i := r.IntN(v.Len())
+			switch r.IntN(4) {
+			case 0:
+				return v.Sub(0, i).Append(v.Sub(i+1, v.Len()).Values()...)
+			case 1:
+				return v.Append(v.Get(i))
+			default:
+				return v.Set(i, mutate(r, v.Get(i)))
+			}
+		}
+	}
+
+	if r.IntN(2) == 0 {
+		if s := (&schema.Schema{Type: schema.TypeOf(val)}); s.Type != "" {
+			generated, err := types.Marshal(s.Generate(r))
+			if err == nil {
+				return generated
+			}
+		}
+	}
+	generated, _ := types.Marshal((*schema.Schema)(nil).Generate(r))
+	return generated
+}
@@ -0,0 +1,218 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/pkg/errors"
+	"github.com/siyul-park/uniflow/pkg/language/text"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/schema"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	testing2 "github.com/siyul-park/uniflow/pkg/testing"
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFuzzNodeCodec_Compile(t *testing.T) {
+	codec := NewFuzzNodeCodec(text.NewCompiler(), nil)
+	require.NotNil(t, codec)
+
+	spec := &FuzzNodeSpec{
+		Meta:      spec.Meta{Name: faker.Word()},
+		Schema:    &schema.Schema{Type: schema.TypeString},
+		Invariant: "true",
+		Runs:      10,
+	}
+
+	n, err := codec.Compile(spec)
+	require.NoError(t, err)
+	require.NotNil(t, n)
+	require.NoError(t, n.Close())
+}
+
+func TestNewFuzzNode(t *testing.T) {
+	n := NewFuzzNode(nil)
+	require.NotNil(t, n)
+	require.NoError(t, n.Close())
+}
+
+func TestFuzzNode_Port(t *testing.T) {
+	n := NewFuzzNode(nil)
+	defer n.Close()
+
+	require.Nil(t, n.In(node.PortIn))
+	require.NotNil(t, n.Out(node.PortOut))
+}
+
+func TestFuzzNode_SendAndReceive(t *testing.T) {
+	minimum := 0.0
+	maximum := 100.0
+
+	sch := &schema.Schema{
+		Type: schema.TypeObject,
+		Properties: map[string]*schema.Schema{
+			"items": {Type: schema.TypeArray, Items: &schema.Schema{Type: schema.TypeInteger, Minimum: &minimum, Maximum: &maximum}},
+		},
+		Required: []string{"items"},
+	}
+
+	short := func(_ context.Context, env any) (bool, error) {
+		input, _ := types.Marshal(env.(map[string]any)["input"])
+		items, ok := types.Lookup(input, "items").(types.Slice)
+		return !ok || items.Len() < 3, nil
+	}
+
+	t.Run("Pass", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		n1 := NewFuzzNode(func(_ context.Context, _ any) (bool, error) { return true, nil })
+		defer n1.Close()
+
+		n1.SetSchema(sch)
+		n1.SetRuns(10)
+
+		var count atomic.Int32
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			count.Add(1)
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			require.Equal(t, int32(10), count.Load())
+			require.ErrorIs(t, tester.Err(), context.Canceled)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
+	t.Run("Shrink", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+		corpus := testing2.NewCorpus(fs)
+
+		n1 := NewFuzzNode(short)
+		defer n1.Close()
+
+		n1.SetSchema(sch)
+		n1.SetSeeds([]any{map[string]any{"items": []any{7, 42, 3, 99}}})
+		n1.SetRuns(0)
+		n1.SetCorpus(corpus, "corpus.json")
+
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			var failure *testing2.Failure
+			require.True(t, errors.As(tester.Err(), &failure))
+			require.ErrorIs(t, failure, ErrInvariantViolated)
+
+			data, err := json.Marshal(failure.Payload)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"input":{"items":[0,0,0]},"output":{"items":[0,0,0]}}`, string(data))
+
+			entries, err := corpus.Load("corpus.json")
+			require.NoError(t, err)
+			require.Equal(t, []any{map[string]any{"items": []any{0.0, 0.0, 0.0}}}, entries)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
+	t.Run("Corpus", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		fs := afero.NewMemMapFs()
+		corpus := testing2.NewCorpus(fs)
+		require.NoError(t, corpus.Add("corpus.json", map[string]any{"items": []any{0, 0, 0}}))
+
+		n1 := NewFuzzNode(short)
+		defer n1.Close()
+
+		n1.SetRuns(0)
+		n1.SetCorpus(corpus, "corpus.json")
+
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			require.ErrorIs(t, tester.Err(), ErrInvariantViolated)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		n1 := NewFuzzNode(nil)
+		defer n1.Close()
+
+		n1.SetSeeds([]any{faker.Word()})
+
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			if _, ok := inPck.Payload().(types.String); ok {
+				return nil, packet.New(types.NewError(errors.New(faker.Sentence())))
+			}
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			var failure *testing2.Failure
+			require.True(t, errors.As(tester.Err(), &failure))
+			require.Equal(t, "", failure.Payload.(map[string]any)["input"])
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+}
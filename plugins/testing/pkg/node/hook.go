@@ -0,0 +1,94 @@
+package node
+
+import (
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/testing"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// HookNodeSpec defines the specifications for creating a HookNode.
+type HookNodeSpec struct {
+	spec.Meta `json:",inline"`
+	Each      bool `json:"each,omitempty"`
+}
+
+// HookNode runs a flow before or after the tests, such as seeding or cleaning up shared resources.
+type HookNode struct {
+	phase   testing.Phase
+	outPort *port.OutPort
+}
+
+const (
+	KindBefore = "before"
+	KindAfter  = "after"
+)
+
+var (
+	_ node.Node     = (*HookNode)(nil)
+	_ testing.Suite = (*HookNode)(nil)
+)
+
+// NewBeforeNodeCodec creates a codec for HookNode running before all tests, or before each test if Each is set.
+func NewBeforeNodeCodec() scheme.Codec {
+	return scheme.CodecWithType(func(sp *HookNodeSpec) (node.Node, error) {
+		if sp.Each {
+			return NewHookNode(testing.BeforeEach), nil
+		}
+		return NewHookNode(testing.BeforeAll), nil
+	})
+}
+
+// NewAfterNodeCodec creates a codec for HookNode running after all tests, or after each test if Each is set.
+func NewAfterNodeCodec() scheme.Codec {
+	return scheme.CodecWithType(func(sp *HookNodeSpec) (node.Node, error) {
+		if sp.Each {
+			return NewHookNode(testing.AfterEach), nil
+		}
+		return NewHookNode(testing.AfterAll), nil
+	})
+}
+
+// NewHookNode creates a new HookNode running at the given phase.
+func NewHookNode(phase testing.Phase) *HookNode {
+	return &HookNode{phase: phase, outPort: port.NewOut()}
+}
+
+// Phase returns the phase at which the hook runs.
+func (n *HookNode) Phase() testing.Phase {
+	return n.phase
+}
+
+// Run sends a packet through the output port and fails if an error comes back.
+func (n *HookNode) Run(t *testing.Tester) {
+	writer := n.outPort.Open(t.Process())
+
+	backPck := packet.Send(writer, packet.New(nil))
+	if err, ok := backPck.Payload().(types.Error); ok {
+		t.Exit(err.Unwrap())
+		return
+	}
+	t.Exit(nil)
+}
+
+// In returns nil as this node does not use an input port.
+func (n *HookNode) In(_ string) *port.InPort {
+	return nil
+}
+
+// Out returns the output port if the name matches.
+func (n *HookNode) Out(name string) *port.OutPort {
+	if name == node.PortOut {
+		return n.outPort
+	}
+	return nil
+}
+
+// Close closes the output port of the HookNode.
+func (n *HookNode) Close() error {
+	n.outPort.Close()
+	return nil
+}
@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/pkg/errors"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/process"
+	testing2 "github.com/siyul-park/uniflow/pkg/testing"
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBeforeNodeCodec_Compile(t *testing.T) {
+	codec := NewBeforeNodeCodec()
+	require.NotNil(t, codec)
+
+	n, err := codec.Compile(&HookNodeSpec{})
+	require.NoError(t, err)
+	require.Equal(t, testing2.BeforeAll, n.(*HookNode).Phase())
+	require.NoError(t, n.Close())
+
+	n, err = codec.Compile(&HookNodeSpec{Each: true})
+	require.NoError(t, err)
+	require.Equal(t, testing2.BeforeEach, n.(*HookNode).Phase())
+	require.NoError(t, n.Close())
+}
+
+func TestNewAfterNodeCodec_Compile(t *testing.T) {
+	codec := NewAfterNodeCodec()
+	require.NotNil(t, codec)
+
+	n, err := codec.Compile(&HookNodeSpec{})
+	require.NoError(t, err)
+	require.Equal(t, testing2.AfterAll, n.(*HookNode).Phase())
+	require.NoError(t, n.Close())
+
+	n, err = codec.Compile(&HookNodeSpec{Each: true})
+	require.NoError(t, err)
+	require.Equal(t, testing2.AfterEach, n.(*HookNode).Phase())
+	require.NoError(t, n.Close())
+}
+
+func TestNewHookNode(t *testing.T) {
+	n := NewHookNode(testing2.BeforeAll)
+	require.NotNil(t, n)
+	require.NoError(t, n.Close())
+}
+
+func TestHookNode_Port(t *testing.T) {
+	n := NewHookNode(testing2.BeforeAll)
+	defer n.Close()
+
+	require.Nil(t, n.In(node.PortIn))
+	require.NotNil(t, n.Out(node.PortOut))
+}
+
+func TestHookNode_Run(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		n1 := NewHookNode(testing2.BeforeAll)
+		defer n1.Close()
+
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			require.ErrorIs(t, tester.Err(), context.Canceled)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+		defer cancel()
+
+		n1 := NewHookNode(testing2.BeforeAll)
+		defer n1.Close()
+
+		cause := errors.New(faker.Sentence())
+		n2 := node.NewOneToOneNode(func(_ *process.Process, _ *packet.Packet) (*packet.Packet, *packet.Packet) {
+			return nil, packet.New(types.NewError(cause))
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortOut).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			require.ErrorIs(t, tester.Err(), cause)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package node
+
+import (
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/siyul-park/uniflow/pkg/encoding"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/scheme"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+)
+
+// MockNodeSpec defines the specification for Mock node
+type MockNodeSpec struct {
+	spec.Meta `json:",inline"`
+	Target    *spec.Port   `json:"target"`
+	Returns   []MockReturn `json:"returns,omitempty"`
+}
+
+// MockReturn defines a canned response of Mock node
+type MockReturn struct {
+	Payload types.Value `json:"payload,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// MockNode stands in for the input port of another symbol within the test processes it intercepts
+type MockNode struct {
+	target  func() (*port.InPort, error)
+	returns []types.Value
+	mu      sync.RWMutex
+}
+
+// MockNodeCodec implements scheme.Codec for MockNode
+type MockNodeCodec struct {
+	agent *runtime.Agent
+}
+
+type mockCalls struct {
+	payloads []any
+	mu       sync.Mutex
+}
+
+const KindMock = "mock"
+
+var ErrTargetNotFound = errors.New("target not found")
+
+var (
+	_ node.Node    = (*MockNode)(nil)
+	_ scheme.Codec = (*MockNodeCodec)(nil)
+)
+
+// NewMockNodeCodec creates a codec for MockNode
+func NewMockNodeCodec(agent *runtime.Agent) *MockNodeCodec {
+	return &MockNodeCodec{agent: agent}
+}
+
+func (c *MockNodeCodec) Compile(sp spec.Spec) (node.Node, error) {
+	converted, ok := sp.(*MockNodeSpec)
+	if !ok {
+		return nil, errors.WithStack(encoding.ErrUnsupportedType)
+	}
+	if converted.Target == nil {
+		return nil, errors.WithStack(ErrTargetNotFound)
+	}
+
+	returns := make([]types.Value, 0, len(converted.Returns))
+	for _, r := range converted.Returns {
+		if r.Error != "" {
+			returns = append(returns, types.NewError(errors.New(r.Error)))
+		} else {
+			returns = append(returns, r.Payload)
+		}
+	}
+
+	n := NewMockNode(returns)
+	n.SetTarget(c.Target(sp.GetNamespace(), converted.Target))
+	return n, nil
+}
+
+func (c *MockNodeCodec) Target(namespace string, target *spec.Port) func() (*port.InPort, error) {
+	return func() (*port.InPort, error) {
+		if sym := lookup(c.agent, namespace, target.ID, target.Name); sym != nil {
+			if in := sym.In(target.Port); in != nil {
+				return in, nil
+			}
+		}
+		return nil, errors.WithStack(ErrTargetNotFound)
+	}
+}
+
+// NewMockNode creates a new Mock node returning the payloads in sequence, repeating the last one
+func NewMockNode(returns []types.Value) *MockNode {
+	return &MockNode{returns: returns}
+}
+
+// SetTarget sets the function resolving the input port to intercept
+func (n *MockNode) SetTarget(target func() (*port.InPort, error)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.target = target
+}
+
+// Intercept replaces the target with the mock for the process and its descendants
+func (n *MockNode) Intercept(proc *process.Process) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.target == nil {
+		return errors.WithStack(ErrTargetNotFound)
+	}
+
+	in, err := n.target()
+	if err != nil {
+		return err
+	}
+
+	proc.SetValue(n, &mockCalls{})
+	in.Intercept(proc, port.ListenFunc(func(proc *process.Process) {
+		reader := in.Open(proc)
+		for inPck := range reader.Read() {
+			reader.Receive(n.call(proc, inPck))
+		}
+	}))
+	return nil
+}
+
+// Calls returns the payloads received by the mock within the process
+func (n *MockNode) Calls(proc *process.Process) []any {
+	calls, ok := proc.Value(n).(*mockCalls)
+	if !ok {
+		return nil
+	}
+
+	calls.mu.Lock()
+	defer calls.mu.Unlock()
+
+	return append([]any(nil), calls.payloads...)
+}
+
+// In returns nil as this node does not use an input port.
+func (n *MockNode) In(_ string) *port.InPort {
+	return nil
+}
+
+// Out returns nil as this node does not use an output port.
+func (n *MockNode) Out(_ string) *port.OutPort {
+	return nil
+}
+
+// Close does nothing as the mock owns no ports.
+func (n *MockNode) Close() error {
+	return nil
+}
+
+func (n *MockNode) call(proc *process.Process, inPck *packet.Packet) *packet.Packet {
+	calls, ok := proc.Value(n).(*mockCalls)
+	if !ok {
+		return packet.New(types.NewError(errors.WithStack(ErrTargetNotFound)))
+	}
+
+	calls.mu.Lock()
+	calls.payloads = append(calls.payloads, types.InterfaceOf(inPck.Payload()))
+	count := len(calls.payloads)
+	calls.mu.Unlock()
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if len(n.returns) == 0 {
+		return packet.New(nil)
+	}
+	return packet.New(n.returns[min(count, len(n.returns))-1])
+}
+
+func lookup(agent *runtime.Agent, namespace string, id uuid.UUID, name string) *symbol.Symbol {
+	if agent == nil {
+		return nil
+	}
+	for _, sym := range agent.Symbols() {
+		if sym.Namespace() != namespace {
+			continue
+		}
+		if (id != uuid.Nil && id == sym.ID()) || (name != "" && name == sym.Name()) {
+			return sym
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/siyul-park/uniflow/pkg/meta"
+	"github.com/siyul-park/uniflow/pkg/node"
+	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/runtime"
+	"github.com/siyul-park/uniflow/pkg/spec"
+	"github.com/siyul-park/uniflow/pkg/symbol"
+	"github.com/siyul-park/uniflow/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockNodeCodec_Compile(t *testing.T) {
+	agent := runtime.NewAgent()
+	defer agent.Close()
+
+	codec := NewMockNodeCodec(agent)
+
+	t.Run("Compile", func(t *testing.T) {
+		s := &MockNodeSpec{
+			Meta: spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      KindMock,
+				Namespace: meta.DefaultNamespace,
+				Name:      faker.UUIDHyphenated(),
+			},
+			Target:  &spec.Port{Name: faker.UUIDHyphenated(), Port: node.PortIn},
+			Returns: []MockReturn{{Payload: types.NewString(faker.Word())}, {Error: faker.Sentence()}},
+		}
+
+		n, err := codec.Compile(s)
+		require.NoError(t, err)
+		require.NotNil(t, n)
+		require.NoError(t, n.Close())
+	})
+
+	t.Run("NoTarget", func(t *testing.T) {
+		s := &MockNodeSpec{
+			Meta: spec.Meta{
+				ID:        uuid.Must(uuid.NewV7()),
+				Kind:      KindMock,
+				Namespace: meta.DefaultNamespace,
+			},
+		}
+
+		_, err := codec.Compile(s)
+		require.ErrorIs(t, err, ErrTargetNotFound)
+	})
+}
+
+func TestMockNodeCodec_Target(t *testing.T) {
+	agent := runtime.NewAgent()
+	defer agent.Close()
+
+	codec := NewMockNodeCodec(agent)
+
+	name := faker.UUIDHyphenated()
+	sb := &symbol.Symbol{
+		Spec: &spec.Meta{
+			ID:        uuid.Must(uuid.NewV7()),
+			Kind:      faker.UUIDHyphenated(),
+			Namespace: meta.DefaultNamespace,
+			Name:      name,
+		},
+		Node: node.NewOneToOneNode(nil),
+	}
+	defer sb.Close()
+
+	agent.Load(sb)
+	defer agent.Unload(sb)
+
+	in, err := codec.Target(meta.DefaultNamespace, &spec.Port{Name: name, Port: node.PortIn})()
+	require.NoError(t, err)
+	require.Equal(t, sb.In(node.PortIn), in)
+
+	_, err = codec.Target(meta.DefaultNamespace, &spec.Port{Name: faker.UUIDHyphenated(), Port: node.PortIn})()
+	require.ErrorIs(t, err, ErrTargetNotFound)
+}
+
+func TestMockNode_Intercept(t *testing.T) {
+	target := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+		return packet.New(types.NewString("real")), nil
+	})
+	defer target.Close()
+
+	cause := faker.Sentence()
+
+	mock := NewMockNode([]types.Value{types.NewString("mocked"), types.NewError(errors.New(cause))})
+	mock.SetTarget(func() (*port.InPort, error) {
+		return target.In(node.PortIn), nil
+	})
+	defer mock.Close()
+
+	out := port.NewOut()
+	defer out.Close()
+
+	out.Link(target.In(node.PortIn))
+
+	t.Run("Intercepted", func(t *testing.T) {
+		proc := process.New()
+		defer proc.Exit(nil)
+
+		err := mock.Intercept(proc)
+		require.NoError(t, err)
+
+		child := proc.Fork()
+		defer child.Exit(nil)
+
+		writer := out.Open(child)
+
+		backPck := packet.Send(writer, packet.New(types.NewInt(1)))
+		require.Equal(t, types.NewString("mocked"), backPck.Payload())
+
+		backPck = packet.Send(writer, packet.New(types.NewInt(2)))
+		require.ErrorContains(t, backPck.Payload().(types.Error), cause)
+
+		backPck = packet.Send(writer, packet.New(types.NewInt(3)))
+		require.ErrorContains(t, backPck.Payload().(types.Error), cause)
+
+		require.Equal(t, []any{1, 2, 3}, mock.Calls(proc))
+	})
+
+	t.Run("NotIntercepted", func(t *testing.T) {
+		proc := process.New()
+		defer proc.Exit(nil)
+
+		writer := out.Open(proc)
+
+		backPck := packet.Send(writer, packet.New(nil))
+		require.Equal(t, types.NewString("real"), backPck.Payload())
+		require.Nil(t, mock.Calls(proc))
+	})
+}
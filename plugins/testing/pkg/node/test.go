@@ -1,9 +1,15 @@
 package node
 
 import (
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 	"github.com/siyul-park/uniflow/pkg/node"
 	"github.com/siyul-park/uniflow/pkg/packet"
 	"github.com/siyul-park/uniflow/pkg/port"
+	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/runtime"
 	"github.com/siyul-park/uniflow/pkg/scheme"
 	"github.com/siyul-park/uniflow/pkg/spec"
 	"github.com/siyul-park/uniflow/pkg/testing"
@@ -13,24 +19,56 @@ import (
 // TestNodeSpec defines the specifications for creating a TestNode.
 type TestNodeSpec struct {
 	spec.Meta `json:",inline"`
+	Mocks     []string `json:"mocks,omitempty"`
+	Fixture   string   `json:"fixture,omitempty"`
 }
 
 // TestNode is a test node implementing node.Node and node.Suite interfaces.
 type TestNode struct {
 	outPorts [2]*port.OutPort
+	mocks    func() ([]*MockNode, error)
+	fixture  types.Value
+	mu       sync.RWMutex
 }
 
 const KindTest = "test"
 
+var ErrFixtureNotFound = errors.New("fixture not found")
+
 var (
 	_ node.Node     = (*TestNode)(nil)
 	_ testing.Suite = (*TestNode)(nil)
 )
 
 // NewTestNodeCodec creates and returns a codec for decoding TestNodeSpec.
-func NewTestNodeCodec() scheme.Codec {
-	return scheme.CodecWithType(func(_ *TestNodeSpec) (node.Node, error) {
-		return NewTestNode(), nil
+func NewTestNodeCodec(agent *runtime.Agent) scheme.Codec {
+	return scheme.CodecWithType(func(sp *TestNodeSpec) (node.Node, error) {
+		n := NewTestNode()
+		if sp.Fixture != "" {
+			val, ok := sp.GetEnv()[sp.Fixture]
+			if !ok {
+				return nil, errors.WithMessagef(ErrFixtureNotFound, "%q", sp.Fixture)
+			}
+			fixture, err := types.Marshal(val.Data)
+			if err != nil {
+				return nil, err
+			}
+			n.SetFixture(fixture)
+		}
+		if len(sp.Mocks) > 0 {
+			n.SetMocks(func() ([]*MockNode, error) {
+				mocks := make([]*MockNode, 0, len(sp.Mocks))
+				for _, name := range sp.Mocks {
+					var mock *MockNode
+					if sym := lookup(agent, sp.GetNamespace(), uuid.Nil, name); sym == nil || !node.As(sym, &mock) {
+						return nil, errors.WithMessagef(ErrTargetNotFound, "mock %q", name)
+					}
+					mocks = append(mocks, mock)
+				}
+				return mocks, nil
+			})
+		}
+		return n, nil
 	})
 }
 
@@ -39,14 +77,38 @@ func NewTestNode() *TestNode {
 	return &TestNode{outPorts: [2]*port.OutPort{port.NewOut(), port.NewOut()}}
 }
 
+// SetMocks sets the function resolving the mocks that intercept their targets while the test runs.
+func (n *TestNode) SetMocks(mocks func() ([]*MockNode, error)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.mocks = mocks
+}
+
+// SetFixture sets the payload sent through the first output port when the test starts.
+func (n *TestNode) SetFixture(fixture types.Value) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.fixture = fixture
+}
+
 // Run executes the test logic, sending packets through output ports and handling errors.
 func (n *TestNode) Run(t *testing.Tester) {
 	proc := t.Process()
 
+	if err := n.intercept(proc); err != nil {
+		t.Exit(err)
+		return
+	}
+
 	writer0 := n.outPorts[0].Open(proc)
 	writer1 := n.outPorts[1].Open(proc)
 
-	outPck0 := packet.New(nil)
+	n.mu.RLock()
+	outPck0 := packet.New(n.fixture)
+	n.mu.RUnlock()
+
 	backPck0 := packet.Send(writer0, outPck0)
 	if backPck0 == packet.None {
 		t.Exit(nil)
@@ -87,6 +149,26 @@ func (n *TestNode) Out(name string) *port.OutPort {
 	return nil
 }
 
+func (n *TestNode) intercept(proc *process.Process) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if n.mocks == nil {
+		return nil
+	}
+
+	mocks, err := n.mocks()
+	if err != nil {
+		return err
+	}
+	for _, mock := range mocks {
+		if err := mock.Intercept(proc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close closes all output ports of the TestNode.
 func (n *TestNode) Close() error {
 	for _, outPort := range n.outPorts {
@@ -10,14 +10,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/siyul-park/uniflow/pkg/node"
 	"github.com/siyul-park/uniflow/pkg/packet"
+	"github.com/siyul-park/uniflow/pkg/port"
 	"github.com/siyul-park/uniflow/pkg/process"
+	"github.com/siyul-park/uniflow/pkg/spec"
 	testing2 "github.com/siyul-park/uniflow/pkg/testing"
 	"github.com/siyul-park/uniflow/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewTestNodeCodec_Compile(t *testing.T) {
-	codec := NewTestNodeCodec()
+	codec := NewTestNodeCodec(nil)
 	require.NotNil(t, codec)
 
 	spec := &TestNodeSpec{}
@@ -28,6 +30,30 @@ func TestNewTestNodeCodec_Compile(t *testing.T) {
 	require.NoError(t, n.Close())
 }
 
+func TestNewTestNodeCodec_CompileFixture(t *testing.T) {
+	codec := NewTestNodeCodec(nil)
+
+	t.Run("Found", func(t *testing.T) {
+		sp := &TestNodeSpec{
+			Meta: spec.Meta{
+				Env: map[string]spec.Value{"USERS": {Data: []any{faker.Word()}}},
+			},
+			Fixture: "USERS",
+		}
+
+		n, err := codec.Compile(sp)
+		require.NoError(t, err)
+		require.NoError(t, n.Close())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		sp := &TestNodeSpec{Fixture: "USERS"}
+
+		_, err := codec.Compile(sp)
+		require.ErrorIs(t, err, ErrFixtureNotFound)
+	})
+}
+
 func TestNewTestNode(t *testing.T) {
 	n := NewTestNode()
 	require.NotNil(t, n)
@@ -75,6 +101,39 @@ func TestTestNode_SendAndReceive(t *testing.T) {
 		}
 	})
 
+	t.Run("Fixture", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		fixture := types.NewString(faker.Word())
+
+		n1 := NewTestNode()
+		defer n1.Close()
+
+		n1.SetFixture(fixture)
+
+		payloads := make(chan types.Value, 1)
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			payloads <- inPck.Payload()
+			return inPck, nil
+		})
+		defer n2.Close()
+
+		n1.Out(node.PortWithIndex(node.PortOut, 0)).Link(n2.In(node.PortIn))
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case payload := <-payloads:
+			require.Equal(t, fixture, payload)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
+
 	t.Run("SingleError", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
 		defer cancel()
@@ -196,4 +255,43 @@ func TestTestNode_SendAndReceive(t *testing.T) {
 			require.Fail(t, ctx.Err().Error())
 		}
 	})
+
+	t.Run("Mock", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 60*time.Second)
+		defer cancel()
+
+		n1 := NewTestNode()
+		defer n1.Close()
+
+		var count atomic.Int32
+		n2 := node.NewOneToOneNode(func(_ *process.Process, inPck *packet.Packet) (*packet.Packet, *packet.Packet) {
+			count.Add(1)
+			return nil, packet.New(types.NewError(errors.New(faker.Sentence())))
+		})
+		defer n2.Close()
+
+		mock := NewMockNode([]types.Value{types.NewString(faker.Word())})
+		mock.SetTarget(func() (*port.InPort, error) {
+			return n2.In(node.PortIn), nil
+		})
+		defer mock.Close()
+
+		n1.Out(node.PortWithIndex(node.PortOut, 0)).Link(n2.In(node.PortIn))
+		n1.SetMocks(func() ([]*MockNode, error) {
+			return []*MockNode{mock}, nil
+		})
+
+		tester := testing2.NewTester("")
+		defer tester.Exit(nil)
+
+		go n1.Run(tester)
+
+		select {
+		case <-tester.Done():
+			require.Equal(t, int32(0), count.Load())
+			require.ErrorIs(t, tester.Err(), context.Canceled)
+		case <-ctx.Done():
+			require.Fail(t, ctx.Err().Error())
+		}
+	})
 }